@@ -6,33 +6,29 @@ import (
 	"strings"
 )
 
-// Helper function to remove the last discard from a specific player's list after a call.
-// Assumes gs.LastDiscard holds the tile that was called.
-func removeLastDiscardFromPlayer(gs *GameState, playerIndexWhoDiscarded int) {
+// markLastDiscardCalled records that the discarder's latest discard was taken by a call. The tile
+// stays in their river (for Furiten, Nagashi Mangan and replays), marked as Called.
+func markLastDiscardCalled(gs *GameState, playerIndexWhoDiscarded int) {
 	if playerIndexWhoDiscarded < 0 || playerIndexWhoDiscarded >= len(gs.Players) || gs.LastDiscard == nil {
-		gs.AddToGameLog(fmt.Sprintf("Warning: removeLastDiscardFromPlayer with invalid index %d or nil LastDiscard.", playerIndexWhoDiscarded))
-		// fmt.Println("Warning: Attempted to remove last discard with invalid index or nil LastDiscard.")
+		gs.AddToGameLog(fmt.Sprintf("Warning: markLastDiscardCalled with invalid index %d or nil LastDiscard.", playerIndexWhoDiscarded))
+		// fmt.Println("Warning: Attempted to mark last discard with invalid index or nil LastDiscard.")
 		return
 	}
 	player := gs.Players[playerIndexWhoDiscarded]
 	if len(player.Discards) > 0 {
 		// Check if the last element in the discarder's pile matches gs.LastDiscard
 		lastIndex := len(player.Discards) - 1
-		if player.Discards[lastIndex].ID == gs.LastDiscard.ID {
-			player.Discards = player.Discards[:lastIndex]
-			// gs.AddToGameLog(fmt.Sprintf("Debug: Removed %s from P%d's discards after call.", gs.LastDiscard.Name, playerIndexWhoDiscarded+1))
+		if player.Discards[lastIndex].Tile.ID == gs.LastDiscard.ID {
+			player.Discards[lastIndex].Called = true
 		} else {
-			// Fallback/Warning: The last element didn't match. This could happen if multiple events occurred rapidly
-			// or if gs.LastDiscard was somehow updated before this ran for the correct discard.
-			// For now, log it. A more robust system might search the last few discards if performance isn't an issue.
-			gs.AddToGameLog(fmt.Sprintf("Warning: Last discard mismatch when removing called tile %s for P%d. Discards: %v",
-				gs.LastDiscard.Name, playerIndexWhoDiscarded+1, TilesToNames(player.Discards)))
-			// fmt.Printf("Warning: Last discard mismatch when removing called tile %s for P%d. Discards: %v\n",
-			// 	gs.LastDiscard.Name, playerIndexWhoDiscarded+1, TilesToNames(player.Discards))
+			gs.AddToGameLog(fmt.Sprintf("Warning: Last discard mismatch when marking called tile %s for P%d. Discards: %v",
+				gs.LastDiscard.Name, playerIndexWhoDiscarded+1, RiverNames(player)))
+			// fmt.Printf("Warning: Last discard mismatch when marking called tile %s for P%d. Discards: %v\n",
+			// 	gs.LastDiscard.Name, playerIndexWhoDiscarded+1, RiverNames(player))
 		}
 	} else {
-		gs.AddToGameLog(fmt.Sprintf("Warning: Attempted to remove last discard from P%d, but their discard list is empty.", playerIndexWhoDiscarded+1))
-		// fmt.Printf("Warning: Attempted to remove last discard from P%d, but their discard list is empty.\n", playerIndexWhoDiscarded+1)
+		gs.AddToGameLog(fmt.Sprintf("Warning: Attempted to mark last discard of P%d as called, but their discard list is empty.", playerIndexWhoDiscarded+1))
+		// fmt.Printf("Warning: Attempted to mark last discard of P%d as called, but their discard list is empty.\n", playerIndexWhoDiscarded+1)
 	}
 }
 
@@ -75,32 +71,34 @@ func DiscardTile(gs *GameState, player *Player, tileIndex int) (Tile, bool) {
 			}
 		}
 	}
+
+	player.KuikaeForbidden = nil // The restriction only covers the discard right after the call
 	discardedTile := player.Hand[tileIndex]
 
 	// --- Perform Discard ---
 	player.Hand = append(player.Hand[:tileIndex], player.Hand[tileIndex+1:]...)
-	player.Discards = append(player.Discards, discardedTile)
+	player.Discards = append(player.Discards, Discard{
+		Tile:       discardedTile,
+		Turn:       gs.TurnNumber,
+		Tsumogiri:  player.JustDrawnTile != nil && player.JustDrawnTile.ID == discardedTile.ID,
+		RiichiTile: player.IsRiichiPending,
+	})
+	gs.FlipPendingKanDora() // An open Kan's delayed dora is turned once its replacement discard is made
 	gs.LastDiscard = &discardedTile
 	playerDiscarderIndex := gs.CurrentPlayerIndex // Store index of player who is discarding
 	gs.TurnNumber++                               // Increment turn number *within the round*
 
 	player.JustDrawnTile = nil // Clear the "just drawn" status after discard decision is locked in
 
-	// Ssuufon Renda Check: Record first un-interrupted discard for each player by their initial seat order
-	if gs.IsFirstGoAround && !gs.AnyCallMadeThisRound && !player.HasMadeFirstDiscardThisRound {
-		initialSeatOrder := player.InitialTurnOrder // This should be 0 for East, 1 for South, etc. at game start.
-		if initialSeatOrder >= 0 && initialSeatOrder < 4 {
-			gs.FirstTurnDiscards[initialSeatOrder] = discardedTile
-			gs.FirstTurnDiscardCount++
-			if gs.FirstTurnDiscardCount == 4 { // All four players made their first un-interrupted discard
-				if CheckSsuufonRenda(gs) {
-					gs.AddToGameLog("Ssuufon Renda! Round ends in an abortive draw.")
-					// fmt.Println("Ssuufon Renda! Round ends in an abortive draw.")
-					gs.GamePhase = PhaseRoundEnd
-					gs.RoundWinner = nil       // Mark as draw
-					return discardedTile, true // Game/round ends
-				}
-			}
+	// Ssuufon Renda Check: on the fourth discard of an uninterrupted first go-around
+	if gs.IsFirstGoAround && !gs.AnyCallMadeThisRound && gs.TurnNumber == len(gs.Players) {
+		if gs.Rules.DrawEnabled(DrawSuufonRenda) && CheckSsuufonRenda(gs) {
+			gs.AddToGameLog("Ssuufon Renda! Round ends in an abortive draw.")
+			// fmt.Println("Ssuufon Renda! Round ends in an abortive draw.")
+			gs.GamePhase = PhaseRoundEnd
+			gs.RoundWinner = nil // Mark as draw
+			gs.DrawType = DrawSuufonRenda
+			return discardedTile, true // Game/round ends
 		}
 	}
 	player.HasMadeFirstDiscardThisRound = true
@@ -132,37 +130,28 @@ func DiscardTile(gs *GameState, player *Player, tileIndex int) (Tile, bool) {
 		otherPlayerIndex := (playerDiscarderIndex + i) % len(gs.Players)
 		otherPlayer := gs.Players[otherPlayerIndex]
 
-		// Check Ron
-		if CanDeclareRon(otherPlayer, discardedTile, gs) {
+		legalCalls := LegalCallsOnDiscard(gs, otherPlayer, discardedTile, playerDiscarderIndex)
+		if contains(legalCalls, ActionRon) {
 			potentialRonCallers = append(potentialRonCallers, struct {
 				*Player
 				int
 			}{otherPlayer, otherPlayerIndex})
 		}
-		// Players in Riichi cannot make open calls (Pon, Chi, Daiminkan)
-		if otherPlayer.IsRiichi {
-			continue
-		}
-		// Check Daiminkan (Open Kan)
-		if CanDeclareDaiminkan(otherPlayer, discardedTile) {
+		if contains(legalCalls, ActionKan) { // Daiminkan (Open Kan)
 			potentialKanCallers = append(potentialKanCallers, struct {
 				*Player
 				int
 			}{otherPlayer, otherPlayerIndex})
 		}
-		// Check Pon
-		if CanDeclarePon(otherPlayer, discardedTile) {
+		if contains(legalCalls, ActionPon) {
 			potentialPonCallers = append(potentialPonCallers, struct {
 				*Player
 				int
 			}{otherPlayer, otherPlayerIndex})
 		}
-		// Check Chi (Only player to the left of the discarder)
-		if otherPlayerIndex == (playerDiscarderIndex+1)%len(gs.Players) {
-			if CanDeclareChi(otherPlayer, discardedTile) {
-				chiCaller = otherPlayer
-				chiCallerIndex = otherPlayerIndex
-			}
+		if contains(legalCalls, ActionChi) { // Only ever offered to the player to the left of the discarder
+			chiCaller = otherPlayer
+			chiCallerIndex = otherPlayerIndex
 		}
 	}
 
@@ -171,92 +160,88 @@ func DiscardTile(gs *GameState, player *Player, tileIndex int) (Tile, bool) {
 
 	// 1. Ron
 	if len(potentialRonCallers) > 0 {
-		// Sanchahou Check (Three players Ron)
-		if len(potentialRonCallers) >= 3 {
+		maxRonWinners := gs.Rules.MultipleRon
+		if maxRonWinners < 1 {
+			maxRonWinners = 1
+		}
+		// Sanchahou Check (Three players Ron), unless triple Ron is played or the abort is switched off
+		sanchahouPossible := false
+		if len(potentialRonCallers) >= 3 && maxRonWinners < 3 && gs.Rules.DrawEnabled(DrawSanchahou) {
 			gs.SanchahouRonners = make([]*Player, len(potentialRonCallers))
 			for i, prc := range potentialRonCallers {
 				gs.SanchahouRonners[i] = prc.Player
 			}
+			sanchahouPossible = CheckSanchahou(gs)
+		}
 
-			if CheckSanchahou(gs) { // CheckSanchahou confirms if all 3 will actually Ron
-				// Simulate choice for Sanchahou decision
-				actualRonners := 0
-				for _, prc := range potentialRonCallers {
-					isHumanRonner := gs.GetPlayerIndex(prc.Player) == 0
-					ronConfirmChoice := true // AI accepts
-					if isHumanRonner {
-						fmt.Printf("--- Player %s (%s) Opportunity (Sanchahou context) ---\n", prc.Player.Name, "Ron")
-						ronConfirmChoice = GetPlayerChoice(gs.InputReader, fmt.Sprintf("%s, declare RON on %s? (y/n): ", prc.Player.Name, discardedTile.Name))
-					}
-					if ronConfirmChoice {
-						actualRonners++
-					} else {
-						gs.AddToGameLog(fmt.Sprintf("%s declined Ron in Sanchahou context.", prc.Player.Name))
-					}
-				}
-				if actualRonners >= 3 {
-					gs.AddToGameLog("Sanchahou! Round ends in an abortive draw as >=3 players confirmed Ron.")
-					// fmt.Println("Sanchahou! Round ends in an abortive draw.")
-					gs.GamePhase = PhaseRoundEnd
-					gs.RoundWinner = nil
-					return discardedTile, true
+		// Offer Ron closest to the discarder first (potentialRonCallers is in turn order). Offers stop
+		// once maxRonWinners have accepted, except that everyone is asked when Sanchahou may abort.
+		ronWinners := []*Player{}
+		falseRon := "" // Why the human's manual Ron does not win; settled after the real winners
+		for _, prc := range potentialRonCallers {
+			if len(ronWinners) >= maxRonWinners && !sanchahouPossible {
+				break
+			}
+			gs.AddToGameLog(fmt.Sprintf("%s (P%d) has Ron opportunity on %s.", prc.Player.Name, prc.int+1, discardedTile.Name))
+			// fmt.Printf("--- Player %s (%s) Opportunity ---\n", prc.Player.Name, "Ron")
+			ronConfirm := true // Default AI to accept
+			if prc.int == 0 {  // Assuming player 0 is human
+				ronConfirm = gs.Input.Confirm(gs, prc.Player, ActionRon, discardedTile, fmt.Sprintf("%s, declare RON on %s? (y/n): ", prc.Player.Name, discardedTile.Name))
+			}
+			if ronConfirm && prc.int == 0 {
+				if falseRon = falseWinReason(gs, prc.Player, discardedTile, false); falseRon != "" {
+					continue // Manual declarations only
 				}
-				// If fewer than 3 confirmed, proceed with Atamahane below.
-				// Update potentialRonCallers to only those who confirmed.
-				confirmedRonCallers := []struct {
-					*Player
-					int
-				}{}
-				// Re-prompt for confirmation, this time with Atamahane in mind.
-				// This part is complex. For now, if Sanchahou condition met but not all confirm,
-				// it might fall through to Atamahane with fewer players.
+			}
+			if ronConfirm {
+				ronWinners = append(ronWinners, prc.Player)
+			} else if CanDeclareRon(prc.Player, discardedTile, gs) { // Passing on a manual offer that is no win costs nothing
+				declineRon(gs, prc.Player, discardedTile)
 			}
 		}
 
-		// Atamahane: Closest player in turn order wins. potentialRonCallers is already sorted by turn order.
-		winnerInfo := potentialRonCallers[0]
-		winner, winnerIndex := winnerInfo.Player, winnerInfo.int
-
-		isHumanWinner := winnerIndex == 0 // Assuming player 0 is human
-		gs.AddToGameLog(fmt.Sprintf("%s (P%d) has Ron opportunity on %s.", winner.Name, winnerIndex+1, discardedTile.Name))
-		// fmt.Printf("--- Player %s (%s) Opportunity ---\n", winner.Name, "Ron")
-
-		ronConfirm := true // Default AI to accept
-		if isHumanWinner {
-			ronConfirm = GetPlayerChoice(gs.InputReader, fmt.Sprintf("%s, declare RON on %s? (y/n): ", winner.Name, discardedTile.Name))
-		} else {
-			// fmt.Printf("(%s can Ron... AI Accepts)\n", winner.Name)
+		if falseRon != "" {
+			if len(ronWinners) == 0 {
+				HandleChombo(gs, gs.Players[0], falseRon) // The round is redealt
+				return discardedTile, true
+			}
+			gs.AddToGameLog(fmt.Sprintf("%s's %s is set aside: the Ron by %s settles the hand.", gs.Players[0].Name, falseRon, ronWinners[0].Name))
+		}
+		if sanchahouPossible && len(ronWinners) >= 3 {
+			gs.AddToGameLog("Sanchahou! Round ends in an abortive draw as >=3 players confirmed Ron.")
+			// fmt.Println("Sanchahou! Round ends in an abortive draw.")
+			gs.GamePhase = PhaseRoundEnd
+			gs.RoundWinner = nil
+			gs.DrawType = DrawSanchahou
+			RefundRiichiDeclaration(gs, player) // The declaration tile was Ronned, so the Riichi never stood
+			return discardedTile, true
+		}
+		if len(ronWinners) > maxRonWinners {
+			ronWinners = ronWinners[:maxRonWinners] // Atamahane: the players closest to the discarder win
 		}
 
-		if ronConfirm {
-			gs.AddToGameLog(fmt.Sprintf("!!! RON by %s (P%d) on %s from %s (P%d) !!!",
-				winner.Name, winnerIndex+1, discardedTile.Name, player.Name, playerDiscarderIndex+1))
-			// fmt.Printf("\n!!! RON by %s on %s !!!\n", winner.Name, discardedTile.Name)
+		if len(ronWinners) > 0 {
+			for _, winner := range ronWinners {
+				gs.AddToGameLog(fmt.Sprintf("!!! RON by %s (P%d) on %s from %s (P%d) !!!",
+					winner.Name, gs.GetPlayerIndex(winner)+1, discardedTile.Name, player.Name, playerDiscarderIndex+1))
+				// fmt.Printf("\n!!! RON by %s on %s !!!\n", winner.Name, discardedTile.Name)
+			}
 			for _, p_ := range gs.Players {
 				p_.IsIppatsu = false
 			} // Ron breaks Ippatsu
 
 			// For Ron, CurrentPlayerIndex should be the DISCARDER when HandleWin is called.
 			// gs.CurrentPlayerIndex is currently playerDiscarderIndex.
-			HandleWin(gs, winner, discardedTile, false) // Sets GamePhase to RoundEnd
-			return discardedTile, true                  // Game/round ends
-		} else { // Declined Ron
-			gs.AddToGameLog(fmt.Sprintf("%s declined Ron on %s.", winner.Name, discardedTile.Name))
-			// fmt.Printf("%s declined Ron.\n", winner.Name)
-			winner.IsFuriten = true                  // Temporary Furiten for missing Ron
-			winner.DeclinedRonOnTurn = gs.TurnNumber // Record turn of declined Ron
-			winner.DeclinedRonTileID = discardedTile.ID
-			if winner.IsRiichi { // If Riichi player misses Ron on a wait tile
-				for _, wait := range winner.RiichiDeclaredWaits {
-					if wait.Suit == discardedTile.Suit && wait.Value == discardedTile.Value {
-						winner.IsPermanentRiichiFuriten = true
-						gs.AddToGameLog(fmt.Sprintf("%s is now in Permanent Riichi Furiten for missing wait %s.", winner.Name, wait.Name))
-						break
-					}
-				}
+			if len(ronWinners) == 1 {
+				HandleWin(gs, ronWinners[0], discardedTile, false) // Sets GamePhase to RoundEnd
+			} else {
+				gs.AddToGameLog(fmt.Sprintf("%s: %d players win on %s.", If(len(ronWinners) == 2, "Double Ron", "Triple Ron"), len(ronWinners), discardedTile.Name))
+				HandleMultipleRon(gs, ronWinners, discardedTile)
 			}
+			return discardedTile, true // Game/round ends
 		}
 	}
+	AcceptRiichiDeclaration(gs, player) // No Ron on the declaration tile: the Riichi stands
 
 	// 2. Kan / Pon (Kan has priority over Pon if from same player or closer player)
 	// If multiple Kan/Pon callers, player closest to discarder in turn order gets priority.
@@ -295,11 +280,11 @@ func DiscardTile(gs *GameState, player *Player, tileIndex int) (Tile, bool) {
 		gs.AddToGameLog(fmt.Sprintf("%s (P%d) has %s opportunity on %s.", caller.Name, callerIndex+1, callType, discardedTile.Name))
 		// fmt.Printf("--- Player %s (%s) Opportunity ---\n", caller.Name, callType)
 
-		confirmCall := true // AI default
+		noYakuNote := warnNoYakuCall(gs, caller, callType, discardedTile)
+		confirmCall := noYakuNote == "" // AI default: call unless the hand would be left without a yaku
 		if isHumanCaller {
 			DisplayPlayerState(caller)
-			confirmCall = GetPlayerChoice(gs.InputReader, fmt.Sprintf("%s, declare %s on %s? (y/n): ", caller.Name, strings.ToUpper(callType), discardedTile.Name))
-		} else { /* AI logic for call decision */
+			confirmCall = gs.Input.Confirm(gs, caller, callType, discardedTile, fmt.Sprintf("%s, declare %s on %s%s? (y/n): ", caller.Name, strings.ToUpper(callType), discardedTile.Name, noYakuNote))
 		}
 
 		if confirmCall {
@@ -308,8 +293,8 @@ func DiscardTile(gs *GameState, player *Player, tileIndex int) (Tile, bool) {
 				p_.IsIppatsu = false
 			} // Any call breaks Ippatsu
 
-			removeLastDiscardFromPlayer(gs, playerDiscarderIndex) // Remove from original discarder's pile
-			gs.CurrentPlayerIndex = callerIndex                   // Turn shifts to caller
+			markLastDiscardCalled(gs, playerDiscarderIndex) // Stays in the discarder's river, marked as called
+			gs.CurrentPlayerIndex = callerIndex             // Turn shifts to caller
 
 			if callType == "Kan" { // Daiminkan
 				HandleKanAction(gs, caller, discardedTile, "Daiminkan")
@@ -333,14 +318,15 @@ func DiscardTile(gs *GameState, player *Player, tileIndex int) (Tile, bool) {
 		chiConfirmedAndHandled := false
 		if isHumanChiCaller {
 			DisplayPlayerState(chiCaller)
-			choiceNum, sequence := GetChiChoice(gs, chiCaller, discardedTile)
+			warnNoYakuCall(gs, chiCaller, ActionChi, discardedTile)
+			choiceNum, sequence := gs.Input.ChooseChi(gs, chiCaller, discardedTile)
 			if choiceNum > 0 {
 				callMade = true
 				chiConfirmedAndHandled = true
 				for _, p_ := range gs.Players {
 					p_.IsIppatsu = false
 				}
-				removeLastDiscardFromPlayer(gs, playerDiscarderIndex)
+				markLastDiscardCalled(gs, playerDiscarderIndex)
 				gs.CurrentPlayerIndex = chiCallerIndex
 				HandleChiAction(gs, chiCaller, discardedTile, sequence, playerDiscarderIndex) // Pass original discarder index
 				PromptDiscard(gs, chiCaller)
@@ -369,6 +355,24 @@ func DiscardTile(gs *GameState, player *Player, tileIndex int) (Tile, bool) {
 	return discardedTile, false // Game continues
 }
 
+// declineRon applies the Furiten a player takes on by passing on a Ron.
+func declineRon(gs *GameState, player *Player, discardedTile Tile) {
+	gs.AddToGameLog(fmt.Sprintf("%s declined Ron on %s.", player.Name, discardedTile.Name))
+	// fmt.Printf("%s declined Ron.\n", player.Name)
+	player.IsFuriten = true                  // Temporary Furiten for missing Ron
+	player.DeclinedRonOnTurn = gs.TurnNumber // Record turn of declined Ron
+	player.DeclinedRonTileID = discardedTile.ID
+	if player.IsRiichi { // If Riichi player misses Ron on a wait tile
+		for _, wait := range player.RiichiDeclaredWaits {
+			if wait.Suit == discardedTile.Suit && wait.Value == discardedTile.Value {
+				player.IsPermanentRiichiFuriten = true
+				gs.AddToGameLog(fmt.Sprintf("%s is now in Permanent Riichi Furiten for missing wait %s.", player.Name, wait.Name))
+				break
+			}
+		}
+	}
+}
+
 // isCloser determines if newCallerIdx is closer to discarderIdx than oldCallerIdx.
 // Helper for call priority. Assumes clockwise turn order.
 func isCloser(newCallerIdx, oldCallerIdx, discarderIdx, numPlayers int) bool {
@@ -415,8 +419,10 @@ func HandlePonAction(gs *GameState, player *Player, discardedTile Tile, discarde
 		player.Melds = append(player.Melds, newMeld)
 		if discarderPlayerIndex >= 0 && discarderPlayerIndex < len(gs.Players) {
 			gs.Players[discarderPlayerIndex].HasHadDiscardCalledThisRound = true
+			UpdatePaoOnCall(gs, player, discarderPlayerIndex) // Third dragon or fourth wind Pon
 		}
 		sort.Sort(BySuitValue(player.Hand))
+		player.KuikaeForbidden = KuikaeTiles(gs.Rules, discardedTile, nil)
 	} else {
 		gs.AddToGameLog(fmt.Sprintf("Error: %s Pon failed, couldn't find 2 tiles for %s.", player.Name, discardedTile.Name))
 		// fmt.Println("Error: Could not find 2 tiles for Pon in player's hand.")
@@ -483,6 +489,7 @@ func HandleChiAction(gs *GameState, player *Player, discardedTile Tile, sequence
 			gs.Players[discarderPlayerIndex].HasHadDiscardCalledThisRound = true
 		}
 		sort.Sort(BySuitValue(player.Hand))
+		player.KuikaeForbidden = KuikaeTiles(gs.Rules, discardedTile, sequence)
 	} else {
 		gs.AddToGameLog(fmt.Sprintf("Error: Chi for %s found %d hand tiles, expected 2.", player.Name, foundCount))
 		// fmt.Printf("Error: Found %d hand tiles for Chi, expected 2.\n", foundCount)
@@ -494,6 +501,9 @@ func HandleKanAction(gs *GameState, player *Player, targetTile Tile, kanType str
 	gs.AnyCallMadeThisRound = true
 	gs.IsFirstGoAround = false
 	gs.TotalKansDeclaredThisRound++ // Increment global Kan counter for Suukaikan
+	gs.RinshanPaoSourceIndex = -1   // Only a Daiminkan makes its discarder liable for the Rinshan draw
+	player.KuikaeForbidden = nil    // A Kan ends the turn's kuikae restriction; the next discard follows a Rinshan draw
+	gs.FlipPendingKanDora()         // A further Kan turns the indicator an earlier open Kan still owes
 	gs.AddToGameLog(fmt.Sprintf("%s (P%d) declares %s with %s. Total Kans this round: %d",
 		player.Name, gs.GetPlayerIndex(player)+1, kanType, targetTile.Name, gs.TotalKansDeclaredThisRound))
 	// fmt.Printf("\n%s declares %s with %s!\n", player.Name, kanType, targetTile.Name)
@@ -501,7 +511,7 @@ func HandleKanAction(gs *GameState, player *Player, targetTile Tile, kanType str
 	// Suukaikan abortive draw check (pre-emptive based on number of kans)
 	// Note: CheckSuukaikan returns true if conditions for *potential* abort are met.
 	// The actual abort happens if Rinshan draw fails.
-	if CheckSuukaikan(gs) {
+	if gs.Rules.DrawEnabled(DrawSuukaikan) && CheckSuukaikan(gs) {
 		gs.AddToGameLog("Condition for Suukaikan (4+ Kans by multiple players) is met. Abort if Rinshan draw fails.")
 	}
 
@@ -603,12 +613,9 @@ func HandleKanAction(gs *GameState, player *Player, targetTile Tile, kanType str
 			player.Hand = RemoveTilesByIndices(player.Hand, indicesToRemove)
 			if originalDiscarderIndex >= 0 && originalDiscarderIndex < len(gs.Players) {
 				gs.Players[originalDiscarderIndex].HasHadDiscardCalledThisRound = true
-				// Pao check for Daisangen/Daisuushii if this Daiminkan enables it
-				if isPaoConditionMetByKan(player, targetTile, "Daiminkan", gs.Players[originalDiscarderIndex]) {
-					player.PaoSourcePlayerIndex = originalDiscarderIndex // Kanner is target, discarder is source
-					gs.AddToGameLog(fmt.Sprintf("PAO Triggered: %s's discard of %s for Daiminkan by %s may lead to Pao.",
-						gs.Players[originalDiscarderIndex].Name, targetTile.Name, player.Name))
-				}
+				// Pao check for Daisangen/Daisuushii (and Suukantsu) if this Daiminkan completes it
+				UpdatePaoOnCall(gs, player, originalDiscarderIndex)
+				gs.RinshanPaoSourceIndex = originalDiscarderIndex
 			}
 			success = true
 		} else {
@@ -626,11 +633,9 @@ func HandleKanAction(gs *GameState, player *Player, targetTile Tile, kanType str
 		}
 
 		foundPonIndex := -1
-		var originalPon Meld
 		for i, meld := range player.Melds {
 			if meld.Type == "Pon" && meld.Tiles[0].Suit == targetTile.Suit && meld.Tiles[0].Value == targetTile.Value {
 				foundPonIndex = i
-				originalPon = meld
 				break
 			}
 		}
@@ -681,7 +686,7 @@ func HandleKanAction(gs *GameState, player *Player, targetTile Tile, kanType str
 			// fmt.Printf("--- Player %s (%s) Opportunity ---\n", robbingPlayer.Name, "Chankan")
 			chankanConfirm := gs.GetPlayerIndex(robbingPlayer) != 0 // AI default
 			if gs.GetPlayerIndex(robbingPlayer) == 0 {              // Human player
-				chankanConfirm = GetPlayerChoice(gs.InputReader, fmt.Sprintf("%s, declare CHANKAN (Ron) on %s? (y/n): ", robbingPlayer.Name, tileToAdd.Name))
+				chankanConfirm = gs.Input.Confirm(gs, robbingPlayer, ActionChankan, tileToAdd, fmt.Sprintf("%s, declare CHANKAN (Ron) on %s? (y/n): ", robbingPlayer.Name, tileToAdd.Name))
 			} else {
 				// fmt.Printf("(%s can Chankan... AI Accepts)\n", robbingPlayer.Name)
 			}
@@ -694,12 +699,10 @@ func HandleKanAction(gs *GameState, player *Player, targetTile Tile, kanType str
 
 				// CurrentPlayerIndex for HandleWin should be the player *attempting* the Kan,
 				// as they "exposed" the tile for Chankan.
-				originalCurrentPlayerIndex := gs.CurrentPlayerIndex // Save current player (who is the Kanner)
-				gs.CurrentPlayerIndex = gs.GetPlayerIndex(player)   // Set current player to the Kanner for HandleWin context
+				gs.CurrentPlayerIndex = gs.GetPlayerIndex(player) // Set current player to the Kanner for HandleWin context
 
-				HandleWin(gs, robbingPlayer, tileToAdd, false) // false for Ron
+				HandleWin(gs, robbingPlayer, tileToAdd, false) // false for Ron; the round ends, so the index is not restored
 
-				// gs.CurrentPlayerIndex = originalCurrentPlayerIndex // Restore if needed, though game probably ends
 				gs.TotalKansDeclaredThisRound-- // Kan was robbed, not completed successfully for Kanner
 				return                          // Win takes precedence, stop Kan processing.
 			} else {
@@ -717,15 +720,8 @@ func HandleKanAction(gs *GameState, player *Player, targetTile Tile, kanType str
 		// FromPlayer remains from the original Pon
 		player.Hand = RemoveTilesByIndices(player.Hand, indicesToRemove) // Remove the single added tile
 
-		// Pao check for Shouminkan: if originalPon.FromPlayer was another player
-		// and this Shouminkan completes Daisangen/Daisuushii for 'player'.
-		if originalPon.FromPlayer != -1 && originalPon.FromPlayer != gs.GetPlayerIndex(player) {
-			if isPaoConditionMetByKan(player, targetTile, "Shouminkan", gs.Players[originalPon.FromPlayer]) {
-				player.PaoSourcePlayerIndex = originalPon.FromPlayer
-				gs.AddToGameLog(fmt.Sprintf("PAO Triggered: %s's original Pon, now Shouminkan by %s with %s, may lead to Pao.",
-					gs.Players[originalPon.FromPlayer].Name, player.Name, targetTile.Name))
-			}
-		}
+		// No new Pao: the original Pon already made its discarder liable if it completed
+		// Daisangen/Daisuushii, and the added tile was self-drawn.
 		success = true
 
 	default:
@@ -747,36 +743,42 @@ func HandleKanAction(gs *GameState, player *Player, targetTile Tile, kanType str
 	sort.Sort(BySuitValue(player.Melds[len(player.Melds)-1].Tiles))
 
 	if rinshanRequired {
-		rinshanTile, empty := gs.DrawRinshanTile() // This also reveals Kan Dora now
+		rinshanTile, empty := gs.DrawRinshanTile()
 		if empty {
 			gs.AddToGameLog(fmt.Sprintf("Could not draw Rinshan tile for %s after %s (no tiles left?).", player.Name, kanType))
 			// fmt.Println("Could not draw Rinshan tile (no tiles left?).")
 			// Check for Suukaikan abortive draw if 4 Kans were declared by different players and no Rinshan
-			if CheckSuukaikan(gs) { // Checks if 4+ Kans by >= 2 players
+			if gs.Rules.DrawEnabled(DrawSuukaikan) && CheckSuukaikan(gs) { // Checks if 4+ Kans by >= 2 players
 				gs.AddToGameLog("Suukaikan! Rinshan tiles exhausted after 4th+ Kan by multiple players. Round ends in an abortive draw.")
 				// fmt.Println("Suukaikan! Rinshan tiles exhausted. Round ends in an abortive draw.")
 				gs.GamePhase = PhaseRoundEnd
 				gs.RoundWinner = nil
+				gs.DrawType = DrawSuukaikan
 				return
 			}
 			PromptDiscard(gs, player) // Player still needs to discard even if no Rinshan
 			return
 		}
+		gs.RevealKanDora(kanType) // At once, or pending until the discard (RuleSet.KanDoraTiming)
 		// gs.AddToGameLog(fmt.Sprintf("%s draws Rinshan tile: %s", player.Name, rinshanTile.Name)) // Logged in DrawRinshanTile
 		// fmt.Printf("%s draws Rinshan tile: %s\n", player.Name, rinshanTile.Name)
 
-		gs.IsRinshanWin = true // Set Rinshan flag before Tsumo check
-		canTsumoOnRinshan := CanDeclareTsumo(player, gs)
-		gs.IsRinshanWin = false // Reset after check
-
-		if canTsumoOnRinshan {
+		gs.IsRinshanWin = true // Kept set through HandleWin for Rinshan Kaihou and Rinshan pao
+		if CanDeclareTsumo(player, gs) {
 			gs.AddToGameLog(fmt.Sprintf("!!! TSUMO (Rinshan Kaihou potentially) by %s on %s !!!", player.Name, rinshanTile.Name))
 			// fmt.Printf("\n!!! TSUMO (Rinshan Kaihou potentially) by %s on %s !!!\n", player.Name, rinshanTile.Name)
+			if gs.Rules.KanDoraTiming == KanDoraDelayed {
+				gs.FlipPendingKanDora() // The open Kan's dora counts for the Rinshan Kaihou
+			}
 			HandleWin(gs, player, rinshanTile, true) // true = Tsumo
-			return                                   // End Kan handling, win takes precedence
+			gs.IsRinshanWin = false
+			return // End Kan handling, win takes precedence
 		}
+		gs.IsRinshanWin = false // Reset after check
 		// Player must discard again after Kan + Rinshan draw
+		gs.IsKanburiDiscard = true // A Ron on this discard is Kanburi
 		PromptDiscard(gs, player)
+		gs.IsKanburiDiscard = false
 	} else {
 		// Should not happen with current Kan types (Ankan, Daiminkan, Shouminkan all require Rinshan)
 		PromptDiscard(gs, player)
@@ -798,7 +800,7 @@ func HandleRiichiAction(gs *GameState, player *Player, discardTileIndexInHand in
 			tempHand13 = append(tempHand13, t)
 		}
 	}
-	if !IsTenpai(tempHand13, player.Melds) {
+	if !IsTenpai(tempHand13, player.Melds) && !(gs.Rules.ManualDeclarations && gs.GetPlayerIndex(player) == 0) {
 		gs.AddToGameLog(fmt.Sprintf("Error: %s Riichi on %s failed internal Tenpai check.", player.Name, riichiDiscardCandidate.Name))
 		// fmt.Printf("Error: Discarding %s for Riichi does not result in Tenpai. Choose another tile.\n", riichiDiscardCandidate.Name)
 		return false // Invalid Riichi discard choice
@@ -816,6 +818,7 @@ func HandleRiichiAction(gs *GameState, player *Player, discardTileIndexInHand in
 	// fmt.Printf("(Score: %d -> %d, Riichi Sticks: %d)\n", player.Score+RiichiBet, player.Score, gs.RiichiSticks)
 
 	player.IsRiichi = true
+	player.IsRiichiPending = true     // Accepted once the declaration tile passes; TransferPoints refunds the stick if it is Ronned
 	player.RiichiTurn = gs.TurnNumber // Turn when Riichi discard is made (before TurnNumber increments in DiscardTile)
 	player.IsIppatsu = true           // Eligible for Ippatsu
 
@@ -855,6 +858,44 @@ func HandleRiichiAction(gs *GameState, player *Player, discardTileIndexInHand in
 
 // HandleWin processes a win by Tsumo or Ron. Calculates score and updates phase.
 func HandleWin(gs *GameState, winner *Player, winningTile Tile, isTsumo bool) {
+	handleWins(gs, []*Player{winner}, winningTile, isTsumo)
+}
+
+// HandleMultipleRon processes a double or triple Ron on the current player's discard. Each winner
+// is scored and paid separately by the discarder; only the winner closest to the discarder
+// collects the Riichi sticks and Honba.
+func HandleMultipleRon(gs *GameState, winners []*Player, winningTile Tile) {
+	ordered := append([]*Player(nil), winners...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return isCloser(gs.GetPlayerIndex(ordered[i]), gs.GetPlayerIndex(ordered[j]), gs.CurrentPlayerIndex, len(gs.Players))
+	})
+	handleWins(gs, ordered, winningTile, false)
+}
+
+// handleWins scores each winner in order (Honba to the first; TransferPoints gives it the Riichi
+// sticks too) and ends the round.
+func handleWins(gs *GameState, winners []*Player, winningTile Tile, isTsumo bool) {
+	gs.RoundWinners, gs.WinResults = nil, nil
+	for i, winner := range winners {
+		honba := 0
+		if i == 0 {
+			honba = gs.Honba
+		}
+		scoreWin(gs, winner, winningTile, isTsumo, honba)
+	}
+
+	gs.GamePhase = PhaseRoundEnd
+	gs.RoundWinner, gs.LastWinResult = nil, nil // Draw if no win could be scored
+	if len(gs.RoundWinners) > 0 {
+		gs.RoundWinner, gs.LastWinResult = gs.RoundWinners[0], gs.WinResults[0]
+	}
+	// gs.AddToGameLog(fmt.Sprintf("\n--- Round Over. Winner: %s ---", winner.Name))
+	// fmt.Println("\n--- Round Over ---")
+}
+
+// scoreWin scores one win and transfers its points, with honba counters added to the payment.
+// It appends to gs.RoundWinners and gs.WinResults unless the hand has no Yaku.
+func scoreWin(gs *GameState, winner *Player, winningTile Tile, isTsumo bool, honba int) {
 	eventPrefix := fmt.Sprintf("\n--- Round End: %s (P%d) Wins! ---", winner.Name, gs.GetPlayerIndex(winner)+1)
 	gs.AddToGameLog(eventPrefix)
 	// fmt.Printf(eventPrefix + "\n")
@@ -893,16 +934,12 @@ func HandleWin(gs *GameState, winner *Player, winningTile Tile, isTsumo bool) {
 	}
 
 	// gs.AddToGameLog("Calculating Yaku...")
-	allWinningTiles := getAllTilesInHand(winner, winningTile, isTsumo)
-	isMenzen := isMenzenchin(winner, isTsumo, winningTile)
 	yakuListResults, han := IdentifyYaku(winner, winningTile, isTsumo, gs)
 
 	if len(yakuListResults) == 0 {
 		gs.AddToGameLog(fmt.Sprintf("!!! CRITICAL ERROR: No Yaku for %s's win on %s. Aborting round.", winner.Name, winningTile.Name))
 		// fmt.Println("!!! CRITICAL ERROR: No Yaku found for a declared winning hand! !!!")
-		gs.GamePhase = PhaseRoundEnd
-		gs.RoundWinner = nil // Treat as draw/error
-		return
+		return // Not recorded as a win; with no other winner the round is a draw
 	}
 	yakuNames := []string{}
 	for _, r := range yakuListResults {
@@ -911,107 +948,30 @@ func HandleWin(gs *GameState, winner *Player, winningTile Tile, isTsumo bool) {
 	gs.AddToGameLog(fmt.Sprintf("%s Yaku: %v (%d Han)", winner.Name, yakuNames, han))
 	// fmt.Printf("Yaku: %v (%d Han)\n", yakuNames, han)
 
-	var decomposition []DecomposedGroup
-	var fu int
-	isYakumanWin := false
-	for _, y := range yakuListResults {
-		if y.Han >= 13 || strings.Contains(y.Name, "Yakuman") {
-			isYakumanWin = true
-			break
-		}
-	}
-	isChiitoitsu := false
-	for _, y := range yakuListResults {
-		if y.Name == "Chiitoitsu" {
-			isChiitoitsu = true
-			break
-		}
-	}
-
-	if isYakumanWin {
-		fu = 0 // Fu usually not directly used for Yakuman point table lookups
-		gs.AddToGameLog("Yakuman hand - Fu calculation for standard scoring table skipped.")
-		// fmt.Println("Hand is Yakuman - Fu calculation skipped.")
-	} else if isChiitoitsu {
-		// CalculateFu will return 25 for Chiitoitsu
-		fu = CalculateFu(winner, nil, winningTile, isTsumo, isMenzen, yakuListResults, gs)
-		gs.AddToGameLog(fmt.Sprintf("Chiitoitsu hand - Calculated Fu: %d (should be 25)", fu))
-		// fmt.Println("Hand is Chiitoitsu - Using fixed 25 Fu.")
-	} else {
-		var decompSuccess bool
-		// gs.AddToGameLog("Decomposing standard hand...")
-		decomposition, decompSuccess = DecomposeWinningHand(winner, allWinningTiles)
-		if !decompSuccess {
-			gs.AddToGameLog(fmt.Sprintf("!!! ERROR: Failed to decompose %s's standard winning hand! Using fallback Fu 30.", winner.Name))
-			// fmt.Println("!!! ERROR: Failed to decompose standard winning hand! Cannot calculate Fu accurately. !!!")
-			fu = 30 // Fallback Fu value
-		} else {
-			// gs.AddToGameLog("Calculating Fu based on decomposition...")
-			fu = CalculateFu(winner, decomposition, winningTile, isTsumo, isMenzen, yakuListResults, gs)
-		}
-	}
-	if !isYakumanWin { // Don't log Fu for Yakuman where it's mostly irrelevant for points
-		gs.AddToGameLog(fmt.Sprintf("Calculated Fu: %d", fu))
-		// fmt.Printf("Fu: %d\n", fu)
-	}
+	fu, _ := CalculateWinFu(gs, winner, winningTile, isTsumo, yakuListResults)
 
 	// payment := CalculatePointPayment(han, fu, winner.SeatWind == gs.PrevalentWind, isTsumo, gs.Honba, gs.RiichiSticks)
 	// isWinnerDealer needs to check if winner's SEAT is East (or current dealer)
 	isWinnerTheDealer := (gs.Players[gs.DealerIndexThisRound] == winner)
-	payment := CalculatePointPayment(han, fu, isWinnerTheDealer, isTsumo, gs.Honba, gs.RiichiSticks)
+	payment := CalculateWinPayment(yakuListResults, han, fu, gs.Rules, isWinnerTheDealer, isTsumo, honba)
+	if payment.Pao = DeterminePao(gs, winner, yakuListResults, han, fu, isTsumo); payment.Pao != nil {
+		gs.AddToGameLog(fmt.Sprintf("PAO Condition: %s is responsible for %s's %s!", gs.Players[payment.Pao.PlayerIndex].Name, winner.Name, payment.Pao.Reason))
+	}
 	gs.AddToGameLog(fmt.Sprintf("Score Value: %s", payment.Description))
 	// fmt.Printf("Score Value: %s\n", payment.Description)
-
-	// --- Pao (Responsibility Payment) Logic ---
-	if winner.PaoSourcePlayerIndex != -1 && isYakumanWin {
-		// Pao applies if the Yakuman was directly enabled by a specific call from PaoSourcePlayerIndex
-		// This check is simplified. A full Pao check would re-verify if the specific Yakuman
-		// (e.g., Daisangen, Daisuushii) was indeed completed by the PaoSourcePlayer's discard/meld.
-		paoSourcePlayer := gs.Players[winner.PaoSourcePlayerIndex]
-		gs.AddToGameLog(fmt.Sprintf("PAO Condition: %s is responsible for %s's Yakuman!", paoSourcePlayer.Name, winner.Name))
-		// fmt.Printf("PAO: %s is responsible for %s's Yakuman!\n", paoSourcePlayer.Name, winner.Name)
-
-		// Pao player pays the full amount.
-		var paoAmount int
-		if isTsumo {
-			// Refined Pao for Tsumo Yakuman: Pao source pays the full Ron value.
-			paoAmount = payment.RonValue // This already includes Honba bonuses for a Ron.
-			gs.AddToGameLog(fmt.Sprintf("PAO Tsumo Yakuman: %s (Pao source) pays full Ron value %d to %s.", paoSourcePlayer.Name, paoAmount, winner.Name))
-			paoSourcePlayer.Score -= paoAmount
-			winner.Score += paoAmount
-			if paoSourcePlayer.Score < 0 {
-				CheckAndHandleBust(gs, paoSourcePlayer, winner)
-			}
-
-			// Winner still gets Riichi sticks
-			if gs.RiichiSticks > 0 {
-				riichiBonus := gs.RiichiSticks * RiichiBet
-				winner.Score += riichiBonus
-				gs.AddToGameLog(fmt.Sprintf("%s also collects %d Riichi stick points.", winner.Name, riichiBonus))
-				gs.RiichiSticks = 0
-			}
-			// Other players pay nothing for the Tsumo Yakuman itself.
-			// Honba is included in the paoAmount (payment.RonValue).
-		} else { // Ron Yakuman (Pao source is the discarder)
-			paoAmount = payment.RonValue // Discarder (Pao source) pays full Ron value
-			gs.AddToGameLog(fmt.Sprintf("PAO Ron Yakuman: %s (Pao source/discarder) pays %d to %s.", paoSourcePlayer.Name, paoAmount, winner.Name))
-			paoSourcePlayer.Score -= paoAmount
-			winner.Score += paoAmount
-			if paoSourcePlayer.Score < 0 {
-				CheckAndHandleBust(gs, paoSourcePlayer, winner)
-			}
-			// Winner still gets Riichi sticks
-			if gs.RiichiSticks > 0 {
-				riichiBonus := gs.RiichiSticks * RiichiBet
-				winner.Score += riichiBonus
-				gs.AddToGameLog(fmt.Sprintf("%s also collects %d Riichi stick points.", winner.Name, riichiBonus))
-				gs.RiichiSticks = 0
-			}
-			// Honba is included in paoAmount.
-		}
-	} else { // Normal Point Transfer (No Pao, or Pao but not Yakuman)
-		TransferPoints(gs, winner, discarder, isTsumo, payment)
-	}
+	gs.RoundWinners = append(gs.RoundWinners, winner)
+	gs.WinResults = append(gs.WinResults, &WinResult{
+		Winner:      winner,
+		Discarder:   discarder,
+		WinningTile: winningTile,
+		IsTsumo:     isTsumo,
+		Yaku:        yakuListResults,
+		Han:         han,
+		Fu:          fu,
+		Payment:     payment,
+	})
+
+	TransferPoints(gs, winner, discarder, isTsumo, payment) // Routes any Pao liability (see pao.go)
 
 	scoreLog := "Scores: "
 	for i, p := range gs.Players {
@@ -1020,11 +980,6 @@ func HandleWin(gs *GameState, winner *Player, winningTile Tile, isTsumo bool) {
 	gs.AddToGameLog(scoreLog)
 	// fmt.Println("--- Scores After Transfer ---")
 	// for i, p := range gs.Players { fmt.Printf("P%d %s: %d\n", i+1, p.Name, p.Score) }
-
-	gs.GamePhase = PhaseRoundEnd
-	gs.RoundWinner = winner
-	// gs.AddToGameLog(fmt.Sprintf("\n--- Round Over. Winner: %s ---", winner.Name))
-	// fmt.Println("\n--- Round Over ---")
 }
 
 // PromptDiscard forces the specified player (usually after a call or Kan) to discard.
@@ -1055,7 +1010,7 @@ func PromptDiscard(gs *GameState, player *Player) {
 		kanConfirm := !isHuman // AI default: Kan if possible and safe
 		if isHuman {
 			DisplayPlayerState(player) // Show hand before Kan choice
-			kanConfirm = GetPlayerChoice(gs.InputReader, fmt.Sprintf("Declare %s with %s before discarding? (y/n): ", kanType, kanTarget.Name))
+			kanConfirm = gs.Input.Confirm(gs, player, kanType, kanTarget, fmt.Sprintf("Declare %s with %s before discarding? (y/n): ", kanType, kanTarget.Name))
 		} else { // AI Kan logic (after call/Kan)
 			if player.IsRiichi && checkWaitChangeForRiichiKan(player, gs, kanTarget, kanType) {
 				kanConfirm = false // AI: Don't Kan if Riichi and it changes waits
@@ -1084,43 +1039,17 @@ func PromptDiscard(gs *GameState, player *Player) {
 	if isHuman {
 		DisplayPlayerState(player) // Show hand again if no Kan chosen, before discard
 		// fmt.Println("Choose tile to discard:")
-		discardIndex = GetPlayerDiscardChoice(gs.InputReader, player)
+		discardIndex = gs.Input.ChooseDiscard(gs, player)
+		for discardIndex >= 0 && discardIndex < len(player.Hand) && IsKuikaeDiscard(player, player.Hand[discardIndex]) {
+			gs.AddToGameLog(fmt.Sprintf("Kuikae: %s may not be discarded right after %s's call. Choose another tile.", player.Hand[discardIndex].Name, player.Name))
+			// fmt.Printf("Kuikae: %s may not be discarded right after your call. Choose another tile.\n", player.Hand[discardIndex].Name)
+			discardIndex = gs.Input.ChooseDiscard(gs, player)
+		}
 	} else { // AI discard logic after call/Kan
 		// gs.AddToGameLog(fmt.Sprintf("AI %s thinking for discard after call/Kan...", player.Name))
-		// Basic AI: discard the tile that was just drawn (player.JustDrawnTile),
-		// or if that's null (e.g. after Pon/Chi), discard last tile.
-		if player.JustDrawnTile != nil { // Should be set if this was after Rinshan
-			foundDrawn := false
-			for i, t := range player.Hand {
-				if t.ID == player.JustDrawnTile.ID {
-					discardIndex = i
-					foundDrawn = true
-					break
-				}
-			}
-			if !foundDrawn { // Fallback
-				if len(player.Hand) > 0 {
-					discardIndex = len(player.Hand) - 1
-				} else {
-					discardIndex = -1
-				}
-			}
-		} else { // After Pon/Chi, no JustDrawnTile from Rinshan. Discard last.
-			if len(player.Hand) > 0 {
-				discardIndex = len(player.Hand) - 1
-			} else {
-				discardIndex = -1
-			}
-		}
-
-		// Safety check for AI
-		if discardIndex < 0 || discardIndex >= len(player.Hand) {
-			if len(player.Hand) > 0 {
-				discardIndex = 0
-			} else {
-				discardIndex = -1
-			} // Error state if no hand
-		}
+		// Basic AI: discard the tile drawn from the dead wall after a Kan, or the last tile after
+		// a Pon/Chi, passing over tiles the kuikae rules forbid.
+		discardIndex = DefaultDiscardIndex(player)
 	}
 
 	if discardIndex >= 0 && discardIndex < len(player.Hand) {
@@ -1129,8 +1058,8 @@ func PromptDiscard(gs *GameState, player *Player) {
 		gs.AddToGameLog(fmt.Sprintf("Error: Invalid discard index %d for %s in PromptDiscard (after call/Kan).", discardIndex, player.Name))
 		// fmt.Printf("Error: Invalid discard index %d chosen in PromptDiscard.\n", discardIndex)
 		if len(player.Hand) > 0 {
-			// fmt.Println("Defaulting to discard index 0.")
-			DiscardTile(gs, player, 0) // Fallback
+			// fmt.Println("Defaulting to the AI's choice.")
+			DiscardTile(gs, player, DefaultDiscardIndex(player)) // Fallback
 		} else {
 			gs.GamePhase = PhaseRoundEnd
 			gs.RoundWinner = nil
@@ -0,0 +1,470 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// setupDoubleRon returns a game where P1 is about to discard an 8s that both P2 and P3 (the
+// dealer) can Ron with Pinfu + Tanyao. P4 is given the same wait by TestDiscardTile_TripleRon.
+func setupDoubleRon() (*GameState, *Player, Tile) {
+	gs := createTestGameState(nil)
+	gs.GamePhase = PhasePlayerTurn
+	gs.CurrentPlayerIndex = 0
+	gs.DealerIndexThisRound = 2
+	gs.IsFirstGoAround = false
+	gs.Wall = TilesFromString("1m 1m 1m 1m") // Keep Houtei out of the way
+	for i, p := range gs.Players {
+		p.HasMadeFirstDiscardThisRound = true
+		p.Hand = TilesFromString("2m 3m 4m 5p 6p 7p 3s 4s 5s 6s 7s 8m 8m")
+		for j := range p.Hand {
+			p.Hand[j].ID = 20*i + j
+		}
+	}
+	gs.Players[3].Hand = TilesFromString("1m 1m 1m 9m 9m 9m 1s 1s 1s 9s 9s 9s W") // Not waiting on 8s
+	for j := range gs.Players[3].Hand {
+		gs.Players[3].Hand[j].ID = 60 + j
+	}
+	discarder := gs.Players[0]
+	discarder.Hand = append(TilesFromString("1p 1p 1p 9p 9p 9p E E E S S S N"), TilesFromString("8s")...) // 8s last, to discard
+	for j := range discarder.Hand {
+		discarder.Hand[j].ID = 100 + j
+	}
+	eightSou := discarder.Hand[len(discarder.Hand)-1]
+	return gs, discarder, eightSou
+}
+
+func TestDiscardTile_AtamahaneByDefault(t *testing.T) {
+	gs, discarder, eightSou := setupDoubleRon()
+
+	if _, roundOver := DiscardTile(gs, discarder, len(discarder.Hand)-1); !roundOver {
+		t.Fatalf("TestDiscardTile_AtamahaneByDefault: Expected a Ron on %s", eightSou.Name)
+	}
+	if len(gs.RoundWinners) != 1 || gs.RoundWinner != gs.Players[1] {
+		t.Errorf("TestDiscardTile_AtamahaneByDefault: Expected only P2 (closest) to win, got %d winners", len(gs.RoundWinners))
+	}
+	if gs.Players[2].Score != InitialScore {
+		t.Errorf("TestDiscardTile_AtamahaneByDefault: Expected P3 to be head-bumped, got score %d", gs.Players[2].Score)
+	}
+}
+
+func TestDiscardTile_DoubleRon(t *testing.T) {
+	gs, discarder, _ := setupDoubleRon()
+	gs.Rules.MultipleRon = 2
+	gs.Honba = 1
+	gs.RiichiSticks = 2
+
+	if _, roundOver := DiscardTile(gs, discarder, len(discarder.Hand)-1); !roundOver {
+		t.Fatalf("TestDiscardTile_DoubleRon: Expected the round to end on a double Ron")
+	}
+	if len(gs.WinResults) != 2 || gs.RoundWinners[0] != gs.Players[1] || gs.RoundWinners[1] != gs.Players[2] {
+		t.Fatalf("TestDiscardTile_DoubleRon: Expected P2 then P3 to win, got %d results", len(gs.WinResults))
+	}
+	first, second := gs.WinResults[0].Payment, gs.WinResults[1].Payment
+	if first.Honba != 1 || second.Honba != 0 {
+		t.Errorf("TestDiscardTile_DoubleRon: Expected Honba only on the closest winner, got %d and %d", first.Honba, second.Honba)
+	}
+	if gs.Players[1].Score != InitialScore+first.RonValue+2*RiichiBet {
+		t.Errorf("TestDiscardTile_DoubleRon: Expected P2 to collect %d plus the Riichi sticks, got score %d", first.RonValue, gs.Players[1].Score)
+	}
+	if gs.Players[2].Score != InitialScore+second.RonValue {
+		t.Errorf("TestDiscardTile_DoubleRon: Expected P3 to collect %d, got score %d", second.RonValue, gs.Players[2].Score)
+	}
+	if discarder.Score != InitialScore-first.RonValue-second.RonValue {
+		t.Errorf("TestDiscardTile_DoubleRon: Expected discarder to pay both winners (%d), got score %d", first.RonValue+second.RonValue, discarder.Score)
+	}
+	if !IsDealerWin(gs) {
+		t.Errorf("TestDiscardTile_DoubleRon: Expected a dealer win (renchan) with the dealer among the winners")
+	}
+}
+
+func TestHandleMultipleRon_OrdersByDistance(t *testing.T) {
+	gs, discarder, eightSou := setupDoubleRon()
+	discarder.Hand = discarder.Hand[:len(discarder.Hand)-1]
+	gs.RiichiSticks = 1
+
+	HandleMultipleRon(gs, []*Player{gs.Players[2], gs.Players[1]}, eightSou)
+
+	if gs.RoundWinner != gs.Players[1] || gs.LastWinResult != gs.WinResults[0] {
+		t.Errorf("TestHandleMultipleRon_OrdersByDistance: Expected P2 (closest to the discarder) first")
+	}
+	if gs.Players[1].Score != InitialScore+gs.WinResults[0].Payment.RonValue+RiichiBet {
+		t.Errorf("TestHandleMultipleRon_OrdersByDistance: Expected P2 to take the Riichi stick, got score %d", gs.Players[1].Score)
+	}
+}
+
+func TestDiscardTile_TripleRon(t *testing.T) {
+	for _, multipleRon := range []int{2, 3} {
+		gs, discarder, _ := setupDoubleRon()
+		gs.Rules.MultipleRon = multipleRon
+		gs.Players[3].Hand = append([]Tile(nil), gs.Players[1].Hand...)
+		for j := range gs.Players[3].Hand {
+			gs.Players[3].Hand[j].ID = 60 + j
+		}
+
+		DiscardTile(gs, discarder, len(discarder.Hand)-1)
+		if multipleRon == 2 && (gs.RoundWinner != nil || len(gs.RoundWinners) != 0) {
+			t.Errorf("TestDiscardTile_TripleRon: Expected a Sanchahou abortive draw with double Ron, got %d winners", len(gs.RoundWinners))
+		}
+		if multipleRon == 3 && len(gs.RoundWinners) != 3 {
+			t.Errorf("TestDiscardTile_TripleRon: Expected three winners with triple Ron, got %d", len(gs.RoundWinners))
+		}
+	}
+}
+
+func TestDiscardTile_SanchahouSwitchedOff(t *testing.T) {
+	gs, discarder, _ := setupDoubleRon()
+	gs.Rules.Draws[DrawSanchahou] = DrawRule{Enabled: false}
+	gs.Players[3].Hand = append([]Tile(nil), gs.Players[1].Hand...)
+	for j := range gs.Players[3].Hand {
+		gs.Players[3].Hand[j].ID = 60 + j
+	}
+
+	DiscardTile(gs, discarder, len(discarder.Hand)-1)
+	if gs.RoundWinner != gs.Players[1] || gs.DrawType != "" {
+		t.Errorf("TestDiscardTile_SanchahouSwitchedOff: Expected atamahane to P2 instead of an abortive draw, got draw %q", gs.DrawType)
+	}
+}
+
+func TestKuikaeTiles(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.ForbidGenbutsuKuikae, rules.ForbidSujiKuikae = true, true
+	cases := []struct {
+		called, sequence string
+		expected         []string
+	}{
+		{"4m", "2m 3m 4m", []string{"4m", "1m"}}, // Ryanmen, called on the high end
+		{"2m", "2m 3m 4m", []string{"2m", "5m"}}, // Ryanmen, called on the low end
+		{"3m", "2m 3m 4m", []string{"3m"}},       // Kanchan: no suji swap
+		{"7s", "7s 8s 9s", []string{"7s"}},       // Penchan: no suji swap
+	}
+	for _, c := range cases {
+		called := TilesFromString(c.called)[0]
+		got := KuikaeTiles(rules, called, TilesFromString(c.sequence))
+		expected := TilesFromString(strings.Join(c.expected, " "))
+		if !compareTileSlicesUnordered(got, expected) {
+			t.Errorf("TestKuikaeTiles: Expected %v forbidden after calling %s for %s, got %v", c.expected, c.called, c.sequence, TilesToNames(got))
+		}
+	}
+	if got := KuikaeTiles(rules, TilesFromString("5p")[0], nil); len(got) != 1 || got[0].Value != 5 {
+		t.Errorf("TestKuikaeTiles: Expected only the called 5p to be forbidden after a Pon, got %v", TilesToNames(got))
+	}
+	rules.ForbidGenbutsuKuikae, rules.ForbidSujiKuikae = false, false
+	if got := KuikaeTiles(rules, TilesFromString("4m")[0], TilesFromString("2m 3m 4m")); len(got) != 0 {
+		t.Errorf("TestKuikaeTiles: Expected nothing forbidden with kuikae allowed, got %v", TilesToNames(got))
+	}
+}
+
+func TestCanDeclareChi_NoLegalDiscardAfterKuikae(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.Rules.ForbidGenbutsuKuikae, gs.Rules.ForbidSujiKuikae = true, true
+	player := gs.Players[1]
+	player.Hand = TilesFromString("1m 2m 3m 4m") // Chi on 4m with 23m would leave only 1m and 4m
+	for j := range player.Hand {
+		player.Hand[j].ID = 200 + j
+	}
+	discard := TilesFromString("4m")[0]
+	discard.ID = 300
+
+	if CanDeclareChi(player, discard, gs) || len(FindPossibleChiSequences(player, discard, gs)) != 0 {
+		t.Errorf("TestCanDeclareChi_NoLegalDiscardAfterKuikae: Expected Chi to be refused when only swap tiles would be left")
+	}
+	gs.Rules.ForbidSujiKuikae = false
+	if !CanDeclareChi(player, discard, gs) {
+		t.Errorf("TestCanDeclareChi_NoLegalDiscardAfterKuikae: Expected Chi to be allowed when 1m may be discarded")
+	}
+
+	player.Hand = TilesFromString("5p 5p 5p 5p")
+	discard = TilesFromString("5p")[0]
+	if CanDeclarePon(player, discard, gs) {
+		t.Errorf("TestCanDeclareChi_NoLegalDiscardAfterKuikae: Expected Pon to be refused when only 5p would be left")
+	}
+}
+
+// discardScript answers ChooseDiscard from a fixed list of hand indices.
+type discardScript struct {
+	AutoInput
+	choices []int
+	asked   int
+}
+
+func (in *discardScript) ChooseDiscard(gs *GameState, player *Player) int {
+	choice := in.choices[in.asked]
+	in.asked++
+	return choice
+}
+
+func kuikaeDiscardState() (*GameState, *Player) {
+	gs := createTestGameState(nil)
+	gs.Rules.ForbidGenbutsuKuikae, gs.Rules.ForbidSujiKuikae = true, true
+	gs.GamePhase = PhasePlayerTurn
+	gs.Wall = TilesFromString("1m 1m 1m 1m")
+	player := gs.Players[0]
+	player.Hand = TilesFromString("1m 4m 7p 9s E")
+	player.KuikaeForbidden = KuikaeTiles(gs.Rules, TilesFromString("4m")[0], TilesFromString("2m 3m 4m"))
+	return gs, player
+}
+
+func TestPromptDiscard_KuikaeAsksHumanAgain(t *testing.T) {
+	gs, player := kuikaeDiscardState()
+	input := &discardScript{choices: []int{1, 0, 1, 0, 3}} // 4m and 1m are refused until 9s
+	gs.Input = input
+
+	PromptDiscard(gs, player)
+	if input.asked != 5 {
+		t.Errorf("TestPromptDiscard_KuikaeAsksHumanAgain: Expected 5 discard prompts, got %d", input.asked)
+	}
+	if len(player.Discards) != 1 || player.Discards[0].Tile.Suit != "Sou" {
+		t.Errorf("TestPromptDiscard_KuikaeAsksHumanAgain: Expected 9s to be discarded, got %v", player.Discards)
+	}
+	if player.KuikaeForbidden != nil {
+		t.Errorf("TestPromptDiscard_KuikaeAsksHumanAgain: Expected the restriction to end with the discard")
+	}
+}
+
+func TestPromptDiscard_KuikaeAIPicksLegalTile(t *testing.T) {
+	gs, _ := kuikaeDiscardState()
+	player := gs.Players[2]
+	hand := TilesFromString("1m 4m 7p 9s")
+	player.Hand = []Tile{hand[2], hand[3], hand[0], hand[1]} // The AI's usual pick, the last tile, is the called 4m
+	player.KuikaeForbidden = KuikaeTiles(gs.Rules, TilesFromString("4m")[0], TilesFromString("2m 3m 4m"))
+	gs.CurrentPlayerIndex = 2
+
+	PromptDiscard(gs, player)
+	if len(player.Discards) != 1 || player.Discards[0].Tile.Suit == "Man" {
+		t.Errorf("TestPromptDiscard_KuikaeAIPicksLegalTile: Expected a tile other than 1m/4m to be discarded, got %v", player.Discards)
+	}
+}
+
+func TestHandleRiichiAction_StickRefundedOnDealIn(t *testing.T) {
+	gs, discarder, _ := setupDoubleRon() // Discarding the 8s leaves P1 waiting on N
+
+	if !HandleRiichiAction(gs, discarder, len(discarder.Hand)-1) {
+		t.Fatalf("TestHandleRiichiAction_StickRefundedOnDealIn: Expected the Riichi declaration to be valid")
+	}
+	if gs.RoundWinner != gs.Players[1] {
+		t.Fatalf("TestHandleRiichiAction_StickRefundedOnDealIn: Expected P2 to Ron the declaration tile")
+	}
+	ronValue := gs.LastWinResult.Payment.RonValue
+	if discarder.Score != InitialScore-ronValue || gs.Players[1].Score != InitialScore+ronValue {
+		t.Errorf("TestHandleRiichiAction_StickRefundedOnDealIn: Expected only the Ron (%d) to change hands, got scores %d and %d", ronValue, discarder.Score, gs.Players[1].Score)
+	}
+	if gs.RiichiSticks != 0 || discarder.IsRiichi || discarder.IsRiichiPending {
+		t.Errorf("TestHandleRiichiAction_StickRefundedOnDealIn: Expected the Riichi to be cancelled, got %d sticks, IsRiichi %v", gs.RiichiSticks, discarder.IsRiichi)
+	}
+}
+
+func TestHandleRiichiAction_AcceptedWhenDeclarationTilePasses(t *testing.T) {
+	gs, discarder, _ := setupDoubleRon()
+	for _, p := range gs.Players[1:3] {
+		p.Hand = append([]Tile(nil), gs.Players[3].Hand...) // Nobody waits on 8s
+	}
+
+	HandleRiichiAction(gs, discarder, len(discarder.Hand)-1)
+	if gs.GamePhase == PhaseRoundEnd {
+		t.Fatalf("TestHandleRiichiAction_AcceptedWhenDeclarationTilePasses: Expected no Ron on the declaration tile")
+	}
+	if !discarder.IsRiichi || discarder.IsRiichiPending {
+		t.Errorf("TestHandleRiichiAction_AcceptedWhenDeclarationTilePasses: Expected the Riichi to be accepted")
+	}
+	if discarder.Score != InitialScore-RiichiBet || gs.RiichiSticks != 1 {
+		t.Errorf("TestHandleRiichiAction_AcceptedWhenDeclarationTilePasses: Expected the stick on the table, got score %d and %d sticks", discarder.Score, gs.RiichiSticks)
+	}
+}
+
+func TestCanDeclareRiichi_Below1000(t *testing.T) {
+	gs, discarder, _ := setupDoubleRon()
+	discarder.Score = -2000
+
+	if ok, _ := CanDeclareRiichi(discarder, gs); ok {
+		t.Errorf("TestCanDeclareRiichi_Below1000: Expected Riichi to be refused with fewer than 1000 points")
+	}
+	gs.Rules.RiichiBelowZero = true
+	if ok, _ := CanDeclareRiichi(discarder, gs); !ok {
+		t.Errorf("TestCanDeclareRiichi_Below1000: Expected Riichi to be allowed with RiichiBelowZero")
+	}
+}
+
+func TestDiscardTile_DealInToOpenRiichi(t *testing.T) {
+	for _, dealInYakuman := range []bool{false, true} {
+		gs, discarder, _ := setupDoubleRon()
+		gs.Rules.LocalYaku = []string{LocalYakuOpenRiichi}
+		if dealInYakuman {
+			gs.Rules.LocalYaku = append(gs.Rules.LocalYaku, LocalYakuOpenRiichiDealIn)
+		}
+		winner := gs.Players[1]
+		winner.IsRiichi, winner.IsOpenRiichi, winner.RiichiTurn = true, true, 0
+
+		DiscardTile(gs, discarder, len(discarder.Hand)-1)
+		if gs.LastWinResult == nil || gs.RoundWinner != winner {
+			t.Fatalf("TestDiscardTile_DealInToOpenRiichi: Expected P2 to Ron")
+		}
+		got := hasYaku(gs.LastWinResult.Yaku, LocalYakuOpenRiichiDealIn, 13)
+		if got != dealInYakuman {
+			t.Errorf("TestDiscardTile_DealInToOpenRiichi: Expected deal-in yakuman %v, got %v (%v)", dealInYakuman, got, gs.LastWinResult.Yaku)
+		}
+		if !dealInYakuman && !hasYaku(gs.LastWinResult.Yaku, LocalYakuOpenRiichi, 1) {
+			t.Errorf("TestDiscardTile_DealInToOpenRiichi: Expected Open Riichi for 1 han, got %v", gs.LastWinResult.Yaku)
+		}
+	}
+}
+
+// setupRinshanKaihou returns a game where P2 holds three Red dragons and wins on the Rinshan
+// tile (4m) after a Kan. The first Kan dora indicator is 8s, making P2's 9s pair worth 2 Dora.
+func setupRinshanKaihou(rinshanTile string) (*GameState, *Player, Tile) {
+	gs := createTestGameState(nil)
+	gs.GamePhase = PhasePlayerTurn
+	gs.CurrentPlayerIndex = 1
+	gs.IsFirstGoAround = false
+	gs.Wall = TilesFromString("1m 1m 1m 1m")
+	gs.DeadWall = make([]Tile, DeadWallSize)
+	for i := range gs.DeadWall {
+		gs.DeadWall[i] = TilesFromString("W")[0]
+		gs.DeadWall[i].ID = 200 + i
+	}
+	gs.DeadWall[0] = TilesFromString(rinshanTile)[0]
+	gs.DeadWall[0].ID = 200
+	gs.DeadWall[DeadWallSize-5] = TilesFromString("8s")[0] // First Kan dora indicator
+	gs.DeadWall[DeadWallSize-5].ID = 209
+	gs.DoraIndicators = []Tile{gs.DeadWall[DeadWallSize-3]} // W: North is Dora
+	gs.UraDoraIndicators = []Tile{}
+
+	player := gs.Players[1]
+	player.HasMadeFirstDiscardThisRound = true
+	player.Hand = TilesFromString("2p 3p 4p 5s 6s 7s 2m 3m 9s 9s r r r")
+	for j := range player.Hand {
+		player.Hand[j].ID = 100 + j
+	}
+	red := TilesFromString("r")[0]
+	red.ID = 150
+	return gs, player, red
+}
+
+func TestHandleKanAction_KanDoraTimingOnRinshanKaihou(t *testing.T) {
+	cases := []struct {
+		timing, kanType string
+		expectedDora    int
+	}{
+		{KanDoraImmediate, "Daiminkan", 2},
+		{KanDoraDelayed, "Daiminkan", 2},
+		{KanDoraAfterDiscard, "Daiminkan", 0},
+		{KanDoraAfterDiscard, "Ankan", 2}, // An Ankan always turns its indicator at once
+	}
+	for _, c := range cases {
+		gs, player, red := setupRinshanKaihou("4m")
+		gs.Rules.KanDoraTiming = c.timing
+		if c.kanType == "Ankan" {
+			player.Hand = append(player.Hand, red)
+		}
+
+		HandleKanAction(gs, player, red, c.kanType)
+		if gs.RoundWinner != player || gs.LastWinResult == nil {
+			t.Fatalf("TestHandleKanAction_KanDoraTimingOnRinshanKaihou: Expected a Rinshan Kaihou (%s, %s)", c.timing, c.kanType)
+		}
+		doraName := fmt.Sprintf("Dora %d", c.expectedDora)
+		hasDora := false
+		for _, y := range gs.LastWinResult.Yaku {
+			if strings.HasPrefix(y.Name, "Dora") {
+				hasDora = y.Name == doraName
+			}
+		}
+		if hasDora != (c.expectedDora > 0) {
+			t.Errorf("TestHandleKanAction_KanDoraTimingOnRinshanKaihou: Expected %d Dora with %s timing on a %s, got %v", c.expectedDora, c.timing, c.kanType, gs.LastWinResult.Yaku)
+		}
+	}
+}
+
+func TestDiscardTile_FlipsDelayedKanDora(t *testing.T) {
+	gs, player, red := setupRinshanKaihou("1p") // Not a winning draw: P2 discards it
+	gs.Rules.KanDoraTiming = KanDoraAfterDiscard
+
+	HandleKanAction(gs, player, red, "Daiminkan")
+	if len(gs.DoraIndicators) != 2 || gs.PendingKanDora != 0 {
+		t.Errorf("TestDiscardTile_FlipsDelayedKanDora: Expected the Kan dora to be turned by the replacement discard, got %d indicators (%d pending)", len(gs.DoraIndicators), gs.PendingKanDora)
+	}
+}
+
+func TestRevealUraDoraIndicators_KanUra(t *testing.T) {
+	for _, kanUra := range []bool{true, false} {
+		gs, _, _ := setupRinshanKaihou("4m")
+		gs.Rules.KanUra = kanUra
+		gs.RevealKanDoraIndicator()
+
+		gs.RevealUraDoraIndicators()
+		expected := IfElseInt(kanUra, 2, 1)
+		if len(gs.UraDoraIndicators) != expected {
+			t.Errorf("TestRevealUraDoraIndicators_KanUra: Expected %d ura indicators with KanUra %v, got %d", expected, kanUra, len(gs.UraDoraIndicators))
+		}
+	}
+}
+
+func TestDiscardTile_CalledTileStaysInRiver(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.GamePhase = PhasePlayerTurn
+	gs.CurrentPlayerIndex = 0
+	gs.IsFirstGoAround = false
+	gs.Wall = TilesFromString("1m 1m 1m 1m")
+	discarder, caller := gs.Players[0], gs.Players[1]
+	discarder.Hand = TilesFromString("9m 5p")
+	discarder.Hand[0].ID, discarder.Hand[1].ID = 10, 11
+	caller.Hand = TilesFromString("5p 5p 1s 2s E")
+	for j := range caller.Hand {
+		caller.Hand[j].ID = 20 + j
+	}
+
+	DiscardTile(gs, discarder, 1) // 5p, Ponned by P2 (AI)
+	if len(caller.Melds) != 1 || caller.Melds[0].Type != "Pon" {
+		t.Fatalf("TestDiscardTile_CalledTileStaysInRiver: Expected P2 to Pon the 5p")
+	}
+	if len(discarder.Discards) != 1 || !discarder.Discards[0].Called {
+		t.Errorf("TestDiscardTile_CalledTileStaysInRiver: Expected the 5p to stay in P1's river marked as called, got %v", RiverNames(discarder))
+	}
+	if !discarder.HasHadDiscardCalledThisRound {
+		t.Errorf("TestDiscardTile_CalledTileStaysInRiver: Expected P1 to be marked as having had a discard called")
+	}
+}
+
+func TestDiscardTile_RecordsRiver(t *testing.T) {
+	gs, discarder, _ := setupDoubleRon()
+	for _, p := range gs.Players[1:3] {
+		p.Hand = append([]Tile(nil), gs.Players[3].Hand...) // Nobody waits on 8s
+	}
+	drawn := discarder.Hand[len(discarder.Hand)-1]
+	discarder.JustDrawnTile = &drawn
+	gs.TurnNumber = 7
+
+	HandleRiichiAction(gs, discarder, len(discarder.Hand)-1)
+	if len(discarder.Discards) != 1 {
+		t.Fatalf("TestDiscardTile_RecordsRiver: Expected one discard, got %d", len(discarder.Discards))
+	}
+	d := discarder.Discards[0]
+	if d.Tile.ID != drawn.ID || d.Turn != 7 || !d.Tsumogiri || !d.RiichiTile || d.Called {
+		t.Errorf("TestDiscardTile_RecordsRiver: Expected a tsumogiri Riichi tile on turn 7, got %+v", d)
+	}
+}
+
+func TestDiscardTile_SsuufonRenda(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.GamePhase = PhasePlayerTurn
+	gs.TurnNumber = 0
+	gs.IsFirstGoAround = true
+	gs.Wall = TilesFromString("1m 1m 1m 1m")
+	for i, p := range gs.Players {
+		p.Hand = TilesFromString("1m 5p 9s E")
+		for j := range p.Hand {
+			p.Hand[j].ID = 10*i + j
+		}
+	}
+
+	for i := 0; i < 4; i++ {
+		gs.CurrentPlayerIndex = i
+		_, roundOver := DiscardTile(gs, gs.Players[i], 3) // East
+		if roundOver != (i == 3) {
+			t.Fatalf("TestDiscardTile_SsuufonRenda: Expected the round to end only on the fourth East, got %v after discard %d", roundOver, i+1)
+		}
+	}
+	if gs.DrawType != DrawSuufonRenda {
+		t.Errorf("TestDiscardTile_SsuufonRenda: Expected a Suufon Renda draw, got %q", gs.DrawType)
+	}
+}
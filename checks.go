@@ -166,21 +166,15 @@ func IsChiitoitsu(hand []Tile) bool {
 	if len(hand) != 14 {
 		return false
 	}
-	tileCountsByID := make(map[int]int)
-	for _, t := range hand {
-		tileCountsByID[t.ID]++
-	}
-	pairCountByID := 0
-	for _, count := range tileCountsByID {
+	pairCount := 0
+	for _, count := range tileKindCounts(hand) { // Pairs are by tile type: every tile ID is unique
 		if count == 2 {
-			pairCountByID++
-		} else if count == 4 {
-			pairCountByID += 2
+			pairCount++
 		} else if count != 0 {
-			return false
+			return false // Four of a kind is not two pairs: the seven pairs must be distinct
 		}
 	}
-	return pairCountByID == 7
+	return pairCount == 7
 }
 
 // IsTenpai checks if a 13-tile hand state (currentHand + melds) is one tile away from being complete.
@@ -233,7 +227,8 @@ func FindTenpaiWaits(currentHand []Tile, melds []Meld) []Tile {
 }
 
 // FindPossibleChiSequences identifies the sets of *two hand tiles* needed to form Chi with the discard.
-func FindPossibleChiSequences(player *Player, discardedTile Tile) [][]Tile {
+// Sequences after which every remaining tile would be a kuikae swap are left out.
+func FindPossibleChiSequences(player *Player, discardedTile Tile, gs *GameState) [][]Tile {
 	var sequences [][]Tile
 	if IsHonor(discardedTile) {
 		return sequences
@@ -290,6 +285,16 @@ func FindPossibleChiSequences(player *Player, discardedTile Tile) [][]Tile {
 		}
 	}
 	for _, seq := range foundSequencesMap {
+		remaining := []Tile{}
+		for _, t := range hand {
+			if t.ID != seq[0].ID && t.ID != seq[1].ID {
+				remaining = append(remaining, t)
+			}
+		}
+		fullSequence := []Tile{seq[0], seq[1], discardedTile}
+		if !hasDiscardAfterCall(remaining, KuikaeTiles(gs.Rules, discardedTile, fullSequence)) {
+			continue
+		}
 		sequences = append(sequences, seq)
 	}
 	sort.Slice(sequences, func(i, j int) bool {
@@ -329,24 +334,7 @@ func CanDeclareRon(player *Player, discardedTile Tile, gs *GameState) bool {
 		return false
 	}
 
-	if gs.Honba >= RyanhanShibariHonbaThreshold {
-		yakuResults, _ := IdentifyYaku(player, discardedTile, false, gs)
-		hanWithoutDora := 0
-		for _, yr := range yakuResults {
-			if !strings.HasPrefix(yr.Name, "Dora") {
-				hanWithoutDora += yr.Han
-			}
-		}
-		if hanWithoutDora < 2 {
-			return false
-		}
-	} else {
-		_, han := IdentifyYaku(player, discardedTile, false, gs)
-		if han == 0 {
-			return false
-		}
-	}
-	return true
+	return hasWinningYaku(player, discardedTile, false, gs)
 }
 
 // CanDeclareTsumo checks if the player can win by Tsumo after drawing.
@@ -358,61 +346,145 @@ func CanDeclareTsumo(player *Player, gs *GameState) bool {
 	}
 
 	if !IsCompleteHand(player.Hand, player.Melds) {
-		return false
-	}
-
-	if gs.Honba >= RyanhanShibariHonbaThreshold {
-		yakuResults, _ := IdentifyYaku(player, *player.JustDrawnTile, true, gs)
-		hanWithoutDora := 0
-		for _, yr := range yakuResults {
-			if !strings.HasPrefix(yr.Name, "Dora") {
-				hanWithoutDora += yr.Han
-			}
-		}
-		if hanWithoutDora < 2 {
+		// Shiisanpuutaa (local yakuman) is the one Tsumo that is not a complete hand
+		if !gs.Rules.LocalYakuEnabled(LocalYakuShiisanpuutaa) {
 			return false
 		}
-	} else {
-		_, han := IdentifyYaku(player, *player.JustDrawnTile, true, gs)
-		if han == 0 {
+		if ok, _, _ := checkShiisanpuutaa(player, gs, true, player.Hand); !ok {
 			return false
 		}
 	}
-	return true
+
+	return hasWinningYaku(player, *player.JustDrawnTile, true, gs)
+}
+
+// hasWinningYaku reports whether a complete hand winning on winningTile has a yaku, or under
+// Ryanhan Shibari (RyanhanShibariHonbaThreshold Honba or more) two han without Dora. The check
+// is a precheck, so IdentifyYaku's log lines are dropped.
+func hasWinningYaku(player *Player, winningTile Tile, isTsumo bool, gs *GameState) bool {
+	quiet, log := gs.QuietLog, gs.GameLog
+	gs.QuietLog = true
+	yakuResults, han := IdentifyYaku(player, winningTile, isTsumo, gs)
+	gs.QuietLog, gs.GameLog = quiet, log
+
+	if gs.Honba < RyanhanShibariHonbaThreshold {
+		return han > 0
+	}
+	hanWithoutDora := 0
+	for _, yr := range yakuResults {
+		if !strings.HasPrefix(yr.Name, "Dora") {
+			hanWithoutDora += yr.Han
+		}
+	}
+	return hanWithoutDora >= 2
 }
 
 // CanDeclarePon checks if a player can call Pon on a discarded tile.
-func CanDeclarePon(player *Player, discardedTile Tile) bool {
+// A Pon that would leave only kuikae-forbidden tiles to discard is refused.
+func CanDeclarePon(player *Player, discardedTile Tile, gs *GameState) bool {
 	if player.IsRiichi {
 		return false
 	}
 	count := 0
+	remaining := []Tile{}
 	for _, tile := range player.Hand {
-		if tile.Suit == discardedTile.Suit && tile.Value == discardedTile.Value {
+		if tile.Suit == discardedTile.Suit && tile.Value == discardedTile.Value && count < 2 {
 			count++
+			continue
 		}
+		remaining = append(remaining, tile)
+	}
+	if count < 2 {
+		return false
 	}
-	return count >= 2
+	return hasDiscardAfterCall(remaining, KuikaeTiles(gs.Rules, discardedTile, nil))
 }
 
 // CanDeclareChi checks if a player can call Chi on a discarded tile.
-func CanDeclareChi(player *Player, discardedTile Tile) bool {
+func CanDeclareChi(player *Player, discardedTile Tile, gs *GameState) bool {
 	if player.IsRiichi {
 		return false
 	}
 	if IsHonor(discardedTile) {
 		return false
 	}
+	return len(FindPossibleChiSequences(player, discardedTile, gs)) > 0
+}
 
-	val, suit, hand := discardedTile.Value, discardedTile.Suit, player.Hand
-	if val >= 3 && HasTileWithValue(hand, suit, val-2) && HasTileWithValue(hand, suit, val-1) {
-		return true
+// KuikaeTiles returns the tile types that may not be discarded right after calling calledTile.
+// sequence is the full 3-tile Chi (nil for a Pon). The called tile itself is genbutsu kuikae;
+// for a ryanmen Chi, the tile three away on the open end is suji kuikae (calling 4m with 23m
+// forbids 1m, calling 1m with 23m forbids 4m). A kanchan Chi has no suji swap.
+func KuikaeTiles(rules RuleSet, calledTile Tile, sequence []Tile) []Tile {
+	forbidden := []Tile{}
+	if rules.ForbidGenbutsuKuikae {
+		forbidden = append(forbidden, calledTile)
+	}
+	if rules.ForbidSujiKuikae && len(sequence) == 3 {
+		low, high := sequence[0].Value, sequence[0].Value
+		for _, t := range sequence {
+			if t.Value < low {
+				low = t.Value
+			}
+			if t.Value > high {
+				high = t.Value
+			}
+		}
+		sujiValue := 0
+		if calledTile.Value == low {
+			sujiValue = calledTile.Value + 3
+		} else if calledTile.Value == high {
+			sujiValue = calledTile.Value - 3
+		}
+		if sujiValue >= 1 && sujiValue <= 9 {
+			forbidden = append(forbidden, Tile{Suit: calledTile.Suit, Value: sujiValue, Name: fmt.Sprintf("%s %d", calledTile.Suit, sujiValue), ID: -1})
+		}
 	}
-	if val >= 2 && val <= 8 && HasTileWithValue(hand, suit, val-1) && HasTileWithValue(hand, suit, val+1) {
-		return true
+	return forbidden
+}
+
+// IsKuikaeDiscard reports whether discarding tile right now would be a forbidden swap after a call.
+func IsKuikaeDiscard(player *Player, tile Tile) bool {
+	for _, f := range player.KuikaeForbidden {
+		if f.Suit == tile.Suit && f.Value == tile.Value {
+			return true
+		}
 	}
-	if val <= 7 && HasTileWithValue(hand, suit, val+1) && HasTileWithValue(hand, suit, val+2) {
-		return true
+	return false
+}
+
+// DefaultDiscardIndex returns the discard an automatic player makes: the tile just drawn, else the
+// last tile in hand, passing over kuikae swaps. It only returns a forbidden tile when nothing else
+// is left (CanDeclarePon/CanDeclareChi refuse calls that lead there), and -1 for an empty hand.
+func DefaultDiscardIndex(player *Player) int {
+	if player.JustDrawnTile != nil {
+		for i, t := range player.Hand {
+			if t.ID == player.JustDrawnTile.ID && !IsKuikaeDiscard(player, t) {
+				return i
+			}
+		}
+	}
+	for i := len(player.Hand) - 1; i >= 0; i-- {
+		if !IsKuikaeDiscard(player, player.Hand[i]) {
+			return i
+		}
+	}
+	return len(player.Hand) - 1
+}
+
+// hasDiscardAfterCall reports whether the hand left after a call holds a tile outside forbidden.
+func hasDiscardAfterCall(remaining []Tile, forbidden []Tile) bool {
+	for _, t := range remaining {
+		isForbidden := false
+		for _, f := range forbidden {
+			if f.Suit == t.Suit && f.Value == t.Value {
+				isForbidden = true
+				break
+			}
+		}
+		if !isForbidden {
+			return true
+		}
 	}
 	return false
 }
@@ -442,6 +514,33 @@ func CanDeclareDaiminkan(player *Player, discardedTile Tile) bool {
 	return countInHand == 3
 }
 
+// LegalCallsOnDiscard lists the calls a player may make on another player's discard,
+// in priority order (Ron, Kan, Pon, Chi). DiscardTile and the GUI/web clients share it
+// so a front end never offers a call the engine would reject.
+func LegalCallsOnDiscard(gs *GameState, player *Player, discardedTile Tile, discarderIndex int) []string {
+	calls := []string{}
+	playerIndex := gs.GetPlayerIndex(player)
+	if playerIndex == discarderIndex {
+		return calls
+	}
+	if CanDeclareRon(player, discardedTile, gs) || ManualDeclarationOffered(gs, player, player.Hand) {
+		calls = append(calls, ActionRon)
+	}
+	if player.IsRiichi { // Players in Riichi cannot make open calls (Pon, Chi, Daiminkan)
+		return calls
+	}
+	if CanDeclareDaiminkan(player, discardedTile) {
+		calls = append(calls, ActionKan)
+	}
+	if CanDeclarePon(player, discardedTile, gs) {
+		calls = append(calls, ActionPon)
+	}
+	if playerIndex == (discarderIndex+1)%len(gs.Players) && CanDeclareChi(player, discardedTile, gs) {
+		calls = append(calls, ActionChi)
+	}
+	return calls
+}
+
 // compareTileSlicesUnordered checks if two slices of Tiles contain the same set of tile types.
 func compareTileSlicesUnordered(s1, s2 []Tile) bool {
 	if len(s1) != len(s2) {
@@ -674,7 +773,11 @@ func CanDeclareRiichi(player *Player, gs *GameState) (bool, []RiichiOption) {
 		return false, options
 	}
 
-	if player.Score < RiichiBet {
+	minScore := RiichiBet // The bet may not take the player to a bust; with BustAtZero that needs more than 1000
+	if gs.Rules.BustAtZero {
+		minScore++
+	}
+	if player.Score < minScore && !gs.Rules.RiichiBelowZero {
 		return false, options
 	}
 	if len(gs.Wall) < 4 {
@@ -684,6 +787,9 @@ func CanDeclareRiichi(player *Player, gs *GameState) (bool, []RiichiOption) {
 		return false, options
 	}
 
+	if gs.Rules.ManualDeclarations && gs.GetPlayerIndex(player) == 0 {
+		return true, manualRiichiOptions(player.Hand) // Unchecked: a noten Riichi is a chombo at the draw
+	}
 	options = FindRiichiOptions(player.Hand, player.Melds)
 	return len(options) > 0, options
 }
@@ -714,20 +820,22 @@ func CheckKyuushuuKyuuhai(hand []Tile, melds []Meld) bool {
 // --- Abortive Draw Condition Checks ---
 
 // CheckSsuufonRenda (Four Players Discard Same Wind on First Uninterrupted Turn).
+// Reads each player's river: exactly one discard, none called, all the same wind.
 func CheckSsuufonRenda(gs *GameState) bool {
-	if gs.FirstTurnDiscardCount < 4 {
-		return false
-	}
-
-	firstDiscard := gs.FirstTurnDiscards[0]
-	if firstDiscard.Suit != "Wind" {
+	if gs.AnyCallMadeThisRound || len(gs.Players) != 4 {
 		return false
 	}
 
-	for i := 1; i < 4; i++ {
-		if gs.FirstTurnDiscards[i].Suit != "Wind" || gs.FirstTurnDiscards[i].Value != firstDiscard.Value {
+	firstDiscard := Tile{}
+	for i, p := range gs.Players {
+		if len(p.Discards) != 1 || p.Discards[0].Called {
 			return false
 		}
+		tile := p.Discards[0].Tile
+		if tile.Suit != "Wind" || (i > 0 && tile.Value != firstDiscard.Value) {
+			return false
+		}
+		firstDiscard = tile
 	}
 	gs.AddToGameLog("Ssuufon Renda condition met (4 same first wind discards).")
 	return true
@@ -748,6 +856,21 @@ func CheckSuuRiichi(gs *GameState) bool {
 	return false
 }
 
+// checkNagashiMangan (Every Discard a Terminal or Honor, None Called). Only checked at an
+// exhaustive draw (see HandleNagashiMangan), where it pays as a mangan. Called tiles stay in
+// the river, so every tile the player discarded is checked.
+func checkNagashiMangan(player *Player, gs *GameState) (bool, string, int) {
+	if player.HasHadDiscardCalledThisRound || len(player.Discards) == 0 {
+		return false, "", 0
+	}
+	for _, d := range player.Discards {
+		if !IsTerminalOrHonor(d.Tile) || d.Called {
+			return false, "", 0
+		}
+	}
+	return true, "Nagashi Mangan", 5
+}
+
 // CheckSanchahou (Three Players Ron on the Same Discard).
 func CheckSanchahou(gs *GameState) bool {
 	if len(gs.SanchahouRonners) >= 3 {
@@ -768,7 +891,7 @@ func CheckSuukaikan(gs *GameState) bool {
 	for playerIdx, p := range gs.Players {
 		playerKanCount := 0
 		for _, m := range p.Melds {
-			if strings.Contains(m.Type, "Kan") {
+			if IsKanMeld(m) {
 				playerKanCount++
 			}
 		}
@@ -791,9 +914,14 @@ func CheckSuukaikan(gs *GameState) bool {
 func CheckSuukantsu(player *Player) bool {
 	kanCount := 0
 	for _, meld := range player.Melds {
-		if strings.Contains(meld.Type, "Kan") {
+		if IsKanMeld(meld) {
 			kanCount++
 		}
 	}
 	return kanCount == 4
 }
+
+// IsKanMeld reports whether the meld is an Ankan, Daiminkan or Shouminkan.
+func IsKanMeld(meld Meld) bool {
+	return meld.Type == "Ankan" || meld.Type == "Daiminkan" || meld.Type == "Shouminkan"
+}
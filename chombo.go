@@ -0,0 +1,121 @@
+package main
+
+import "fmt"
+
+// Chombo: with RuleSet.ManualDeclarations the human seat's Ron, Tsumo and Riichi are not checked
+// before they are offered, as at a physical table. A Ron or Tsumo that does not win, and a Riichi
+// found noten at an exhaustive draw, are chombo: the player pays the RuleSet.ChomboPenalty, the
+// Riichi sticks of the hand go back, and the round is redealt with the same dealer and Honba.
+
+// DrawChombo is the DrawType of a round ended by a chombo. It is not a RuleSet.Draws entry:
+// the round is always redealt without a Honba.
+const DrawChombo = "Chombo"
+
+// Chombo penalties (RuleSet.ChomboPenalty).
+const (
+	ChomboMangan  = "mangan"   // Paid at once like a mangan Tsumo in reverse: 4000 all from a dealer, 2000/4000 from a non-dealer
+	ChomboGameEnd = "game-end" // RuleSet.ChomboPoints come off the player's final placement points
+)
+
+// ManualDeclarationOffered reports whether a Ron or Tsumo is offered to player without the win
+// check: ManualDeclarations is on, player is the human seat and the hand before the winning
+// tile (hand13) is tenpai, whether or not the tile wins, the hand has a yaku or it is furiten.
+func ManualDeclarationOffered(gs *GameState, player *Player, hand13 []Tile) bool {
+	return gs.Rules.ManualDeclarations && gs.GetPlayerIndex(player) == 0 && IsTenpai(hand13, player.Melds)
+}
+
+// falseWinReason explains why a Ron on winningTile (or a Tsumo, with player.JustDrawnTile in
+// the hand) is not a legal win, or returns "" if it is.
+func falseWinReason(gs *GameState, player *Player, winningTile Tile, isTsumo bool) string {
+	hand := player.Hand
+	if !isTsumo {
+		if player.IsFuriten || player.IsPermanentRiichiFuriten {
+			return fmt.Sprintf("Ron on %s while furiten", winningTile.Name)
+		}
+		hand = append(append([]Tile{}, player.Hand...), winningTile)
+	}
+	win := If(isTsumo, "Tsumo", "Ron")
+	if isTsumo && CanDeclareTsumo(player, gs) || !isTsumo && CanDeclareRon(player, winningTile, gs) {
+		return ""
+	}
+	if !IsCompleteHand(hand, player.Melds) {
+		return fmt.Sprintf("%s on %s without a complete hand", win, winningTile.Name)
+	}
+	if gs.Honba >= RyanhanShibariHonbaThreshold {
+		return fmt.Sprintf("%s on %s without two han at %d Honba", win, winningTile.Name, gs.Honba)
+	}
+	return fmt.Sprintf("%s on %s without a yaku", win, winningTile.Name)
+}
+
+// manualRiichiOptions offers Riichi on every discard, with no waits shown, so that the player
+// decides for themselves whether the hand is tenpai.
+func manualRiichiOptions(hand []Tile) []RiichiOption {
+	options := make([]RiichiOption, len(hand))
+	for i, tile := range hand {
+		options[i] = RiichiOption{DiscardIndex: i, DiscardTile: tile}
+	}
+	return options
+}
+
+// HandleChombo penalizes player for reason and ends the round to be redealt. The Riichi sticks
+// put out this hand go back to their owners.
+func HandleChombo(gs *GameState, player *Player, reason string) {
+	gs.AddToGameLog(fmt.Sprintf("!!! CHOMBO by %s: %s !!!", player.Name, reason))
+	// fmt.Printf("\n!!! CHOMBO by %s: %s !!!\n", player.Name, reason)
+	player.Chombos++
+
+	for _, p := range gs.Players {
+		if p.IsRiichi {
+			p.IsRiichi, p.IsRiichiPending, p.IsIppatsu = false, false, false
+			p.Score += RiichiBet
+			if gs.RiichiSticks > 0 {
+				gs.RiichiSticks--
+			}
+			gs.AddToGameLog(fmt.Sprintf("%s's Riichi stick is returned. Score: %d.", p.Name, p.Score))
+		}
+	}
+
+	switch gs.Rules.ChomboPenalty {
+	case ChomboGameEnd:
+		gs.AddToGameLog(fmt.Sprintf("%s will lose %d points at the end of the game.", player.Name, gs.Rules.ChomboPoints))
+	default: // ChomboMangan
+		isDealer := gs.Players[gs.DealerIndexThisRound] == player
+		for _, p := range gs.Players {
+			if p == player {
+				continue
+			}
+			pay := 2000
+			if isDealer || gs.Players[gs.DealerIndexThisRound] == p {
+				pay = 4000
+			}
+			player.Score -= pay
+			p.Score += pay
+		}
+		gs.AddToGameLog(fmt.Sprintf("%s pays a mangan for the chombo. Score: %d.", player.Name, player.Score))
+	}
+
+	gs.GamePhase = PhaseRoundEnd
+	gs.RoundWinner, gs.RoundWinners = nil, nil
+	gs.LastWinResult, gs.WinResults = nil, nil
+	gs.DrawType = DrawChombo
+}
+
+// CheckNotenRiichi penalizes the first player found in Riichi but not tenpai at an exhaustive
+// draw, as the hands are shown. It reports whether there was a chombo.
+func CheckNotenRiichi(gs *GameState) bool {
+	for _, p := range gs.Players {
+		if p.IsRiichi && !IsTenpai(p.Hand, p.Melds) {
+			HandleChombo(gs, p, "Riichi without a tenpai hand")
+			return true
+		}
+	}
+	return false
+}
+
+// chomboPenaltyPoints is what a player's chombos take off their final placement points.
+func chomboPenaltyPoints(rules RuleSet, chombos int) int {
+	if rules.ChomboPenalty != ChomboGameEnd {
+		return 0
+	}
+	return chombos * rules.ChomboPoints
+}
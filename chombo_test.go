@@ -0,0 +1,161 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// acceptInput accepts every confirmation, as a player declaring without checking.
+type acceptInput struct{ AutoInput }
+
+func (acceptInput) Confirm(gs *GameState, player *Player, action string, tile Tile, prompt string) bool {
+	return true
+}
+
+func TestHandleChombo_ManganAndRedeal(t *testing.T) {
+	gs := createTestGameState(nil) // Dealer is P1
+	gs.Honba = 2
+	offender, riichi := gs.Players[1], gs.Players[2]
+	riichi.IsRiichi = true
+	riichi.Score -= RiichiBet
+	gs.RiichiSticks = 1
+
+	HandleChombo(gs, offender, "test")
+	if gs.GamePhase != PhaseRoundEnd || gs.DrawType != DrawChombo || gs.RoundWinner != nil || offender.Chombos != 1 {
+		t.Errorf("TestHandleChombo_ManganAndRedeal: Expected the round to end by chombo, got phase %v, draw %q, %d chombos", gs.GamePhase, gs.DrawType, offender.Chombos)
+	}
+	want := []int{InitialScore + 4000, InitialScore - 8000, InitialScore + 2000, InitialScore + 2000}
+	for i, p := range gs.Players {
+		if p.Score != want[i] {
+			t.Errorf("TestHandleChombo_ManganAndRedeal: Expected P%d at %d (dealer 4000, others 2000, Riichi stick back), got %d", i+1, want[i], p.Score)
+		}
+	}
+	if gs.RiichiSticks != 0 || riichi.IsRiichi {
+		t.Errorf("TestHandleChombo_ManganAndRedeal: Expected the Riichi stick returned, got %d sticks, Riichi %v", gs.RiichiSticks, riichi.IsRiichi)
+	}
+	if keeps, honba := RenchanAfterRound(gs); !keeps || honba != 2 {
+		t.Errorf("TestHandleChombo_ManganAndRedeal: Expected a redeal with the same dealer and 2 Honba, got %v, %d", keeps, honba)
+	}
+}
+
+func TestHandleChombo_DealerPaysAll(t *testing.T) {
+	gs := createTestGameState(nil)
+	HandleChombo(gs, gs.Players[0], "test")
+	if gs.Players[0].Score != InitialScore-12000 || gs.Players[3].Score != InitialScore+4000 {
+		t.Errorf("TestHandleChombo_DealerPaysAll: Expected the dealer to pay 4000 all, got %d and %d", gs.Players[0].Score, gs.Players[3].Score)
+	}
+}
+
+func TestHandleChombo_GameEndPenalty(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.Rules.ChomboPenalty = ChomboGameEnd
+	before := FinalPlacements(gs)
+	HandleChombo(gs, gs.Players[2], "test")
+	if gs.Players[2].Score != InitialScore {
+		t.Errorf("TestHandleChombo_GameEndPenalty: Expected no payment during the game, got score %d", gs.Players[2].Score)
+	}
+	for i, p := range FinalPlacements(gs) {
+		want := before[i].Points
+		if p.Name == gs.Players[2].Name {
+			want -= gs.Rules.ChomboPoints
+		}
+		if p.Points != want {
+			t.Errorf("TestHandleChombo_GameEndPenalty: Expected %s at %d placement points, got %d", p.Name, want, p.Points)
+		}
+	}
+}
+
+// manualRonState gives the human (East, dealer) a closed tenpai hand on 3s-6s with no yaku on
+// Ron, and P2 a tile to discard.
+func manualRonState(t *testing.T, discard string) (*GameState, *Player) {
+	t.Helper()
+	gs := createTestGameState(nil)
+	gs.Rules.ManualDeclarations = true
+	for _, p := range gs.Players {
+		p.Hand = []Tile{}
+	}
+	human := gs.Players[0]
+	human.Hand = mustParseMPSZ(t, "123m456p789s11z45s")
+	gs.Players[1].Hand = mustParseMPSZ(t, discard)
+	gs.Players[1].Hand[0].ID = 120
+	gs.CurrentPlayerIndex = 1
+	return gs, human
+}
+
+func TestDiscardTile_ManualFalseRonIsChombo(t *testing.T) {
+	gs, human := manualRonState(t, "3s")
+	gs.Input = acceptInput{}
+	if _, roundOver := DiscardTile(gs, gs.Players[1], 0); !roundOver {
+		t.Fatalf("TestDiscardTile_ManualFalseRonIsChombo: Expected the Ron to end the round")
+	}
+	if gs.DrawType != DrawChombo || human.Chombos != 1 || len(gs.WinResults) != 0 {
+		t.Errorf("TestDiscardTile_ManualFalseRonIsChombo: Expected a chombo and no win, got draw %q, %d chombos, %d wins", gs.DrawType, human.Chombos, len(gs.WinResults))
+	}
+	found := false
+	for _, entry := range gs.GameLog {
+		found = found || strings.Contains(entry, "CHOMBO by "+human.Name+": Ron on Sou 3 without a yaku")
+	}
+	if !found {
+		t.Errorf("TestDiscardTile_ManualFalseRonIsChombo: Expected the reason in the log, got %v", gs.GameLog)
+	}
+
+	gs, human = manualRonState(t, "3s")
+	gs.Rules.ManualDeclarations = false
+	gs.Input = acceptInput{}
+	if _, roundOver := DiscardTile(gs, gs.Players[1], 0); roundOver || human.Chombos != 0 {
+		t.Errorf("TestDiscardTile_ManualFalseRonIsChombo: Expected no Ron offered without manual declarations, got round over %v, %d chombos", roundOver, human.Chombos)
+	}
+}
+
+func TestDiscardTile_ManualFalseRonKeepsRealWinner(t *testing.T) {
+	gs, human := manualRonState(t, "3s")
+	gs.Input = acceptInput{}
+	discarder, winner := gs.Players[3], gs.Players[1] // The human is asked first, then the winner
+	discarder.Hand, winner.Hand = winner.Hand, mustParseMPSZ(t, "234m567p22s45s678s")
+	for i := range winner.Hand {
+		winner.Hand[i].ID = 50 + i
+	}
+	gs.CurrentPlayerIndex = 3
+	if _, roundOver := DiscardTile(gs, discarder, 0); !roundOver {
+		t.Fatalf("TestDiscardTile_ManualFalseRonKeepsRealWinner: Expected the Ron to end the round")
+	}
+	if gs.DrawType == DrawChombo || human.Chombos != 0 || len(gs.WinResults) != 1 || gs.RoundWinner != winner {
+		t.Errorf("TestDiscardTile_ManualFalseRonKeepsRealWinner: Expected %s's Ron to stand, got draw %q, %d chombos, %d wins", winner.Name, gs.DrawType, human.Chombos, len(gs.WinResults))
+	}
+}
+
+func TestDiscardTile_ManualOfferDeclinedWithoutFuriten(t *testing.T) {
+	gs, human := manualRonState(t, "9m")
+	input := &promptInput{}
+	gs.Input = input
+	DiscardTile(gs, gs.Players[1], 0)
+	if len(input.prompts) != 1 || !strings.Contains(input.prompts[0], "RON on Man 9") {
+		t.Errorf("TestDiscardTile_ManualOfferDeclinedWithoutFuriten: Expected a Ron offer on a tile that does not win, got %v", input.prompts)
+	}
+	if human.IsFuriten {
+		t.Errorf("TestDiscardTile_ManualOfferDeclinedWithoutFuriten: Expected no furiten for passing on a tile that does not win")
+	}
+}
+
+func TestCanDeclareRiichi_ManualAndNotenRiichi(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.Rules.ManualDeclarations = true
+	human := gs.Players[0]
+	human.Hand = mustParseMPSZ(t, "123m456p789s15z45s9m")
+	ok, options := CanDeclareRiichi(human, gs)
+	if !ok || len(options) != len(human.Hand) {
+		t.Fatalf("TestCanDeclareRiichi_ManualAndNotenRiichi: Expected Riichi offered on all %d discards of a noten hand, got %v, %d", len(human.Hand), ok, len(options))
+	}
+	if _, chose := (AutoInput{}).ChooseRiichi(gs, human, options); chose {
+		t.Errorf("TestCanDeclareRiichi_ManualAndNotenRiichi: Expected AutoInput not to declare a noten Riichi")
+	}
+	if ok, _ := CanDeclareRiichi(gs.Players[1], gs); ok {
+		t.Errorf("TestCanDeclareRiichi_ManualAndNotenRiichi: Expected the AI seats' Riichi still checked")
+	}
+
+	human.Hand = mustParseMPSZ(t, "123m456p789s15z45s")
+	human.IsRiichi = true
+	if !CheckNotenRiichi(gs) || human.Chombos != 1 || gs.DrawType != DrawChombo {
+		t.Errorf("TestCanDeclareRiichi_ManualAndNotenRiichi: Expected a noten Riichi at the draw to be a chombo, got %d chombos, draw %q", human.Chombos, gs.DrawType)
+	}
+}
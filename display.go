@@ -75,6 +75,9 @@ func DisplayGameState(gs *GameState) {
 		if player.IsRiichi && player.DeclaredDoubleRiichi {
 			riichiStatus = "[D.Riichi]"
 		}
+		if player.IsRiichi && player.IsOpenRiichi {
+			riichiStatus = "[Open Riichi]"
+		}
 		furitenStatus := If(player.IsFuriten, "[F]", "")
 		if player.IsPermanentRiichiFuriten {
 			furitenStatus = "[Perm.F]"
@@ -90,10 +93,17 @@ func DisplayGameState(gs *GameState) {
 			riichiStatus, furitenStatus, tenpaiStatus,
 		)
 		fmt.Printf("  Melds: %s\n", FormatMeldsForDisplay(player.Melds))
-		if len(player.Discards) > 15 { // Truncate long discard list for display
-			fmt.Printf("  Discards: %v ... (last 5: %v)\n", TilesToNames(player.Discards[:10]), TilesToNames(player.Discards[len(player.Discards)-5:]))
+		if player.IsRiichi && player.IsOpenRiichi {
+			fmt.Printf("  Open Hand: %v\n", TilesToNames(player.Hand))
+		}
+		river := RiverNames(player)
+		if len(river) > 15 { // Truncate long discard list for display
+			fmt.Printf("  Discards: %v ... (last 5: %v)\n", river[:10], river[len(river)-5:])
 		} else {
-			fmt.Printf("  Discards: %v\n", TilesToNames(player.Discards))
+			fmt.Printf("  Discards: %v\n", river)
+		}
+		for _, signal := range TenpaiSignals(player.Discards) {
+			fmt.Printf("  Read: %s (turn %d)\n", signal.Reason, signal.Turn)
 		}
 		if player.PaoSourcePlayerIndex != -1 {
 			fmt.Printf("  (Is Pao for P%d's Yakuman)\n", player.PaoSourcePlayerIndex+1)
@@ -105,9 +115,24 @@ func DisplayGameState(gs *GameState) {
 	if len(gs.GameLog) > 0 {
 		fmt.Printf("Last Log: %s\n", gs.GameLog[len(gs.GameLog)-1])
 	}
+	if isConsoleGame(gs) { // The human's own waits, as the web and GUI clients show them
+		if waits := NewPlayerView(gs, 0).Waits; waits != "" {
+			fmt.Println(waits)
+		}
+	}
 	fmt.Println("=========================================")
 }
 
+// isConsoleGame reports whether seat 0 is played at the terminal (not by AutoInput or a client).
+func isConsoleGame(gs *GameState) bool {
+	input := gs.Input
+	if recording, ok := input.(*RecordingInput); ok {
+		input = recording.Input
+	}
+	_, ok := input.(ConsoleInput)
+	return ok
+}
+
 // DisplayPlayerState shows details for a specific player.
 func DisplayPlayerState(player *Player) {
 	fmt.Printf("--- %s's State ---\n", player.Name)
@@ -130,6 +155,33 @@ func DisplayPlayerState(player *Player) {
 	}
 }
 
+// RiverNames lists a player's discards by name, tsumogiri tiles marked "(tsumogiri)" (tedashi
+// are unmarked), the Riichi declaration tile "(riichi)" (laid sideways) and called tiles "(called)".
+func RiverNames(player *Player) []string {
+	names := TilesToNames(DiscardedTiles(player.Discards))
+	for i, d := range player.Discards {
+		if d.Tsumogiri {
+			names[i] += " (tsumogiri)"
+		}
+		if d.RiichiTile {
+			names[i] += " (riichi)"
+		}
+		if d.Called {
+			names[i] += " (called)"
+		}
+	}
+	return names
+}
+
+// DiscardedTiles returns the tiles of a river, in order.
+func DiscardedTiles(discards []Discard) []Tile {
+	tiles := make([]Tile, len(discards))
+	for i, d := range discards {
+		tiles[i] = d.Tile
+	}
+	return tiles
+}
+
 // TilesToNames converts a slice of Tiles to a slice of their Names.
 func TilesToNames(tiles []Tile) []string {
 	names := make([]string, len(tiles))
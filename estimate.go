@@ -0,0 +1,287 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Hand estimates: a hand is played out many times against random draws from the tiles the
+// player has not seen, to estimate how often it reaches tenpai and wins within a number of
+// draws and what the wins are worth (IdentifyYaku, CalculateWinFu and CalculateWinPayment).
+// Only the player's own draws are simulated: nobody else wins or deals in, and red fives in
+// the unseen pool count as plain fives.
+
+// EstimateOptions set up a hand estimate.
+type EstimateOptions struct {
+	Trials int
+	Draws  int    // Draws per trial
+	Riichi bool   // Declare Riichi as soon as the closed hand is tenpai, then cut every draw
+	Seen   []Tile // Tiles known to be gone besides those visible in the game state
+}
+
+// HandEstimate is the outcome of EstimateHand.
+type HandEstimate struct {
+	Trials        int
+	TenpaiRate    float64        // Share of trials that were tenpai at some point
+	WinRate       float64        // Share of trials won by Tsumo
+	RiichiRate    float64        // Share of trials that declared Riichi
+	AverageWin    float64        // Points received for a win, Honba and Riichi sticks aside
+	ExpectedValue float64        // Points per trial: the wins, less the stick of a Riichi that did not win
+	Yaku          map[string]int // How many wins had each yaku (Dora aside)
+}
+
+// EstimateHand plays player's hand (13 tiles, or 14 about to discard) out opts.Trials times.
+// Each draw that completes the hand with a yaku wins by Tsumo; otherwise the hand cuts the
+// tile leaving the lowest shanten, preferring the least connected one (estimateDiscard).
+// Riichi wins reveal random ura dora indicators from the unseen tiles.
+func EstimateHand(gs *GameState, player *Player, opts EstimateOptions) HandEstimate {
+	visible := visibleTileCounts(newDecision(gs, player, ""))
+	for _, t := range opts.Seen {
+		visible[tileKind(t)]++
+	}
+	pool := []Tile{}
+	for kind := range visible {
+		for n := visible[kind]; n < 4; n++ {
+			tile := kindTile(kind)
+			tile.ID = TotalTiles + len(pool) // Past every wall tile ID
+			pool = append(pool, tile)
+		}
+	}
+
+	estimate := HandEstimate{Trials: opts.Trials, Yaku: make(map[string]int)}
+	if opts.Trials <= 0 {
+		return estimate
+	}
+	tenpai, wins, riichis, winPoints, totalPoints := 0, 0, 0, 0, 0
+	for trial := 0; trial < opts.Trials; trial++ {
+		rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+		ura := pool[len(pool)-len(gs.DoraIndicators):]
+		wall := pool[:len(pool)-len(ura)]
+		sim, p := estimateGameState(gs, player)
+		declared := p.IsRiichi
+
+		result := playEstimateTrial(sim, p, wall, ura, opts)
+		if result.tenpai {
+			tenpai++
+		}
+		if p.IsRiichi && !declared {
+			riichis++
+		}
+		switch {
+		case result.points > 0:
+			wins++
+			winPoints += result.points
+			totalPoints += result.points
+			for _, y := range result.yaku {
+				if !strings.HasPrefix(y.Name, "Dora ") {
+					estimate.Yaku[y.Name]++
+				}
+			}
+		case p.IsRiichi && !declared:
+			totalPoints -= 1000
+		}
+	}
+	estimate.TenpaiRate = ratio(tenpai, opts.Trials)
+	estimate.WinRate = ratio(wins, opts.Trials)
+	estimate.RiichiRate = ratio(riichis, opts.Trials)
+	estimate.AverageWin = ratio(winPoints, wins)
+	estimate.ExpectedValue = ratio(totalPoints, opts.Trials)
+	return estimate
+}
+
+// estimateTrial is how one playout ended.
+type estimateTrial struct {
+	tenpai bool
+	points int // Points received for the win, 0 without one
+	yaku   []YakuResult
+}
+
+// playEstimateTrial draws from wall until the hand wins or opts.Draws run out.
+func playEstimateTrial(sim *GameState, p *Player, wall, ura []Tile, opts EstimateOptions) estimateTrial {
+	result := estimateTrial{}
+	closed := true
+	for _, m := range p.Melds {
+		if !m.IsConcealed {
+			closed = false
+		}
+	}
+	afterDiscard := func() {
+		if Shanten(p.Hand, p.Melds) > 0 {
+			return
+		}
+		result.tenpai = true
+		if opts.Riichi && closed && !p.IsRiichi {
+			p.IsRiichi, p.IsIppatsu, p.RiichiTurn = true, true, sim.TurnNumber
+		}
+	}
+
+	if len(p.Hand)+3*len(p.Melds) > 13 { // A 14-tile hand cuts first
+		p.Hand = removeTileByID(p.Hand, estimateDiscard(p.Hand, p.Melds).ID)
+	}
+	afterDiscard()
+	for draw := 0; draw < opts.Draws && draw < len(wall); draw++ {
+		tile := wall[draw]
+		sim.Wall = wall[draw+1:]
+		sim.TurnNumber += len(sim.Players)
+		p.Hand = append(p.Hand, tile)
+		p.JustDrawnTile = &tile
+
+		if Shanten(p.Hand, p.Melds) < 0 {
+			if p.IsRiichi {
+				sim.UraDoraIndicators = append([]Tile{}, ura...)
+			}
+			if yaku, han := IdentifyYaku(p, tile, true, sim); han > 0 {
+				fu, _ := CalculateWinFu(sim, p, tile, true, yaku)
+				isDealer := sim.Players[sim.DealerIndexThisRound] == p
+				payment := CalculateWinPayment(yaku, han, fu, sim.Rules, isDealer, true, 0)
+				result.points = 2*payment.TsumoNonDealerPay + payment.TsumoDealerPay
+				if isDealer {
+					result.points = 3 * payment.TsumoNonDealerPay
+				}
+				result.tenpai, result.yaku = true, yaku
+				return result
+			}
+			sim.UraDoraIndicators = nil
+		}
+
+		if p.IsRiichi {
+			p.Hand = removeTileByID(p.Hand, tile.ID)
+			p.IsIppatsu = false
+			continue
+		}
+		p.Hand = removeTileByID(p.Hand, estimateDiscard(p.Hand, p.Melds).ID)
+		afterDiscard()
+	}
+	return result
+}
+
+// estimateGameState copies gs for a playout (or any what-if yaku check), with a copy of player
+// that the caller can change.
+// The copy logs quietly and has none of the first-turn, Rinshan or Chankan conditions.
+func estimateGameState(gs *GameState, player *Player) (*GameState, *Player) {
+	sim := *gs
+	sim.QuietLog = true
+	sim.GameLog = nil
+	sim.WinResults = nil
+	sim.UraDoraIndicators = nil
+	sim.IsFirstGoAround = false
+	sim.IsRinshanWin = false
+	sim.IsChankanOpportunity = false
+
+	p := *player
+	p.Hand = append([]Tile{}, player.Hand...)
+	p.Melds = append([]Meld{}, player.Melds...)
+	p.JustDrawnTile = nil
+	p.HasDrawnFirstTileThisRound = true
+	p.HasMadeFirstDiscardThisRound = true
+	sim.Players = append([]*Player{}, gs.Players...)
+	for i, other := range sim.Players {
+		if other == player {
+			sim.Players[i] = &p
+			sim.CurrentPlayerIndex = i
+		}
+	}
+	return &sim, &p
+}
+
+// estimateDiscard is the playout's cut: the tile leaving the lowest shanten and, among those,
+// the one with the fewest tiles of its suit within two steps (honors: the fewest copies).
+func estimateDiscard(hand []Tile, melds []Meld) Tile {
+	best, bestShanten, bestLinks := hand[len(hand)-1], 99, 99
+	seen := make(map[int]bool)
+	for _, tile := range hand {
+		kind := tileKind(tile)
+		if seen[kind] {
+			continue
+		}
+		seen[kind] = true
+		shanten := Shanten(removeTileByID(hand, tile.ID), melds)
+		links := 0
+		for _, other := range hand {
+			distance := tileKind(other) - kind
+			if other.ID == tile.ID || other.Suit != tile.Suit || (IsHonor(tile) && distance != 0) {
+				continue
+			}
+			if distance >= -2 && distance <= 2 {
+				links++
+			}
+		}
+		if shanten < bestShanten || (shanten == bestShanten && links < bestLinks) {
+			best, bestShanten, bestLinks = tile, shanten, links
+		}
+	}
+	return best
+}
+
+// PrintHandEstimate prints one estimate as a labelled line, with its most frequent yaku.
+func PrintHandEstimate(label string, e HandEstimate) {
+	fmt.Printf("%-7s tenpai %5.1f%%  win %5.1f%%  average win %6.0f  expected value %6.0f\n",
+		label, 100*e.TenpaiRate, 100*e.WinRate, e.AverageWin, e.ExpectedValue)
+	parts := []string{}
+	for i, name := range sortedCounts(e.Yaku) {
+		if i >= 5 {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %d", name, e.Yaku[name]))
+	}
+	if len(parts) > 0 {
+		fmt.Printf("        yaku in wins: %s\n", strings.Join(parts, ", "))
+	}
+}
+
+// runEstimateCommand handles "mahjong-go estimate": it estimates a closed hand's chances and
+// value within a number of draws, with Riichi and without (dama).
+func runEstimateCommand(args []string) {
+	flags := flag.NewFlagSet("estimate", flag.ExitOnError)
+	handFlag := flags.String("hand", "", "MPSZ hand of 13 or 14 tiles, e.g. 234m406p78s11z")
+	doraFlag := flags.String("dora", "", "MPSZ dora indicators")
+	seenFlag := flags.String("seen", "", "MPSZ tiles already gone (rivers, other players' melds)")
+	seat := flags.String("seat", "South", "seat wind (East is the dealer)")
+	round := flags.String("round", "East", "prevalent wind")
+	draws := flags.Int("draws", 12, "draws left to the player")
+	trials := flags.Int("trials", 2000, "playouts per estimate")
+	flags.Parse(args)
+
+	hand, err := ParseMPSZ(*handFlag)
+	if err == nil && (len(hand) < 13 || len(hand) > 14) {
+		err = fmt.Errorf("the hand has %d tiles, expected 13 or 14", len(hand))
+	}
+	dora, doraErr := ParseMPSZ(*doraFlag)
+	seen, seenErr := ParseMPSZ(*seenFlag)
+	for _, e := range []error{doraErr, seenErr} {
+		if err == nil {
+			err = e
+		}
+	}
+	winds := []string{"East", "South", "West", "North"}
+	seatIndex := slices.Index(winds, *seat)
+	if err == nil && (seatIndex < 0 || !slices.Contains(winds, *round)) {
+		err = fmt.Errorf("unknown wind %q or %q", *seat, *round)
+	}
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(2)
+	}
+
+	gs := NewGameState(defaultPlayerNames)
+	gs.QuietLog = true
+	gs.PrevalentWind = *round
+	gs.DealerIndexThisRound = (4 - seatIndex) % 4
+	for i, p := range gs.Players {
+		p.SeatWind = winds[(i-gs.DealerIndexThisRound+4)%4]
+	}
+	gs.DoraIndicators = dora
+	player := gs.Players[0]
+	player.Hand = hand
+
+	fmt.Printf("Hand: %s (%s), %s seat, %d draws, %d playouts each\n",
+		FormatHandForDisplay(hand), ShantenLabel(Shanten(hand, nil)), *seat, *draws, *trials)
+	opts := EstimateOptions{Trials: *trials, Draws: *draws, Seen: seen}
+	PrintHandEstimate("Dama", EstimateHand(gs, player, opts))
+	opts.Riichi = true
+	PrintHandEstimate("Riichi", EstimateHand(gs, player, opts))
+}
@@ -0,0 +1,73 @@
+package main
+
+import "testing"
+
+// seenAllBut returns four copies of every tile type except the given ones, so that only those
+// are left to draw.
+func seenAllBut(t *testing.T, left string) []Tile {
+	t.Helper()
+	skip := tileKindCounts(mustParseMPSZ(t, left))
+	seen := []Tile{}
+	for kind := 0; kind < 34; kind++ {
+		for n := 0; n < 4 && skip[kind] == 0; n++ {
+			seen = append(seen, kindTile(kind))
+		}
+	}
+	return seen
+}
+
+func TestEstimateHand_DamaAndRiichi(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.DoraIndicators = mustParseMPSZ(t, "1z")
+	player := gs.Players[1] // Non-dealer
+	player.Hand = mustParseMPSZ(t, "234m45677p34678s")
+	opts := EstimateOptions{Trials: 20, Draws: 1, Seen: seenAllBut(t, "25s")}
+
+	dama := EstimateHand(gs, player, opts)
+	if dama.TenpaiRate != 1 || dama.WinRate != 1 || dama.RiichiRate != 0 {
+		t.Errorf("TestEstimateHand_DamaAndRiichi: Expected every dama trial tenpai and won without Riichi, got %+v", dama)
+	}
+	if dama.AverageWin != 2700 || dama.ExpectedValue != 2700 {
+		t.Errorf("TestEstimateHand_DamaAndRiichi: Expected Tanyao Pinfu Tsumo worth 2700, got %v (expected value %v)", dama.AverageWin, dama.ExpectedValue)
+	}
+	if dama.Yaku["Tanyao"] != opts.Trials || dama.Yaku["Riichi"] != 0 {
+		t.Errorf("TestEstimateHand_DamaAndRiichi: Expected Tanyao in every dama win and no Riichi, got %v", dama.Yaku)
+	}
+
+	opts.Riichi = true
+	riichi := EstimateHand(gs, player, opts)
+	if riichi.WinRate != 1 || riichi.RiichiRate != 1 || riichi.Yaku["Riichi"] != opts.Trials {
+		t.Errorf("TestEstimateHand_DamaAndRiichi: Expected every trial to win with Riichi, got %+v", riichi)
+	}
+	if riichi.AverageWin <= dama.AverageWin {
+		t.Errorf("TestEstimateHand_DamaAndRiichi: Expected Riichi wins worth more than %v, got %v", dama.AverageWin, riichi.AverageWin)
+	}
+	if len(player.Hand) != 13 || player.IsRiichi {
+		t.Errorf("TestEstimateHand_DamaAndRiichi: Expected the player left unchanged, got %d tiles, Riichi %v", len(player.Hand), player.IsRiichi)
+	}
+}
+
+func TestEstimateHand_OpenHandWithoutYaku(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.DoraIndicators = mustParseMPSZ(t, "1z")
+	player := gs.Players[1]
+	player.Hand = mustParseMPSZ(t, "45677p34678s")
+	pon := mustParseMPSZ(t, "999m")
+	for i := range pon {
+		pon[i].ID = 100 + i // Apart from the hand's IDs
+	}
+	player.Melds = []Meld{{Type: "Pon", Tiles: pon, CalledOn: pon[0], FromPlayer: 0}}
+	estimate := EstimateHand(gs, player, EstimateOptions{Trials: 20, Draws: 3, Riichi: true, Seen: seenAllBut(t, "25s")})
+	if estimate.TenpaiRate != 1 || estimate.WinRate != 0 || estimate.RiichiRate != 0 || estimate.ExpectedValue != 0 {
+		t.Errorf("TestEstimateHand_OpenHandWithoutYaku: Expected tenpai with no win and no Riichi, got %+v", estimate)
+	}
+}
+
+func TestEstimateDiscard(t *testing.T) {
+	hand := mustParseMPSZ(t, "1238m456p9p346s117z")
+	got := estimateDiscard(hand, nil)
+	isolated := tileKindCounts(mustParseMPSZ(t, "8m9p7z"))
+	if isolated[tileKind(got)] == 0 {
+		t.Errorf("TestEstimateDiscard: Expected an isolated 8m, 9p or 7z cut, got %s", got.Name)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Helpers shared by the graphical front ends (gui.go, web.go). They turn engine
+// state into button labels and text; the console prompts live in input.go.
+
+// confirmButtonLabel returns the button text for a confirmation, or "" when the
+// call is not legal. Calls on a discard are re-checked with LegalCallsOnDiscard;
+// Tsumo, Chankan and own-turn kans were already validated by the engine.
+func confirmButtonLabel(gs *GameState, player *Player, action string, tile Tile) string {
+	switch action {
+	case ActionRon, ActionKan, ActionPon:
+		// While DiscardTile asks for calls, CurrentPlayerIndex is still the discarder.
+		if !contains(LegalCallsOnDiscard(gs, player, tile, gs.CurrentPlayerIndex), action) {
+			return ""
+		}
+		return action + " " + tile.Name
+	case ActionKyuushuu:
+		return "Kyuushuu Kyuuhai"
+	case ActionYame:
+		return "End Game (Yame)"
+	case ActionOpenRiichi:
+		return "Open Riichi (show hand)"
+	default:
+		return action + " " + tile.Name
+	}
+}
+
+// riichiChoiceLabels labels each riichi option with its discard and resulting waits, plus a final "No Riichi".
+func riichiChoiceLabels(options []RiichiOption) []string {
+	labels := make([]string, 0, len(options)+1)
+	for _, opt := range options {
+		if len(opt.Waits) == 0 { // Manual declarations: the waits are not shown
+			labels = append(labels, fmt.Sprintf("Riichi: cut %s", opt.DiscardTile.Name))
+			continue
+		}
+		waits := append([]Tile{}, opt.Waits...)
+		sort.Sort(BySuitValue(waits))
+		labels = append(labels, fmt.Sprintf("Riichi: cut %s (waits %s)", opt.DiscardTile.Name, strings.Join(TilesToNames(waits), ", ")))
+	}
+	return append(labels, "No Riichi")
+}
+
+// roundResultText describes how the round ended: yaku, fu and points for a win, tenpai status for a draw.
+func roundResultText(gs *GameState) string {
+	lines := []string{}
+	results := gs.WinResults
+	if len(results) == 0 && gs.LastWinResult != nil {
+		results = []*WinResult{gs.LastWinResult}
+	}
+	if len(results) > 0 {
+		for i, r := range results { // Several on a double/triple Ron
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, fmt.Sprintf("%s wins by %s on %s", r.Winner.Name, If(r.IsTsumo, "Tsumo", "Ron"), r.WinningTile.Name), "")
+			for _, y := range r.Yaku {
+				lines = append(lines, fmt.Sprintf("%s: %d han", y.Name, y.Han))
+			}
+			if r.Fu > 0 {
+				lines = append(lines, "", fmt.Sprintf("%d han %d fu", r.Han, r.Fu))
+			} else {
+				lines = append(lines, "", fmt.Sprintf("%d han", r.Han))
+			}
+			lines = append(lines, r.Payment.Description)
+		}
+	} else if gs.RoundWinner != nil {
+		lines = append(lines, fmt.Sprintf("%s wins by Nagashi Mangan", gs.RoundWinner.Name))
+	} else {
+		lines = append(lines, If(gs.DrawType == "" || gs.DrawType == DrawExhaustive, "Draw", "Draw: "+gs.DrawType), "")
+		for _, p := range gs.Players {
+			lines = append(lines, fmt.Sprintf("%s: %s", p.Name, If(p.IsTenpai, "Tenpai", "Noten")))
+		}
+	}
+	lines = append(lines, "")
+	for _, p := range gs.Players {
+		lines = append(lines, fmt.Sprintf("%s: %d", p.Name, p.Score))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// chiChoiceLabels labels each Chi option by the two hand tiles it uses, plus a final "Skip".
+func chiChoiceLabels(discardedTile Tile, handTilePairs [][]Tile) []string {
+	labels := make([]string, 0, len(handTilePairs)+1)
+	for _, pair := range handTilePairs {
+		labels = append(labels, fmt.Sprintf("Chi %s with %s + %s", discardedTile.Name, pair[0].Name, pair[1].Name))
+	}
+	return append(labels, "Skip")
+}
+
+// chiChoiceResult converts a chosen label index into GetChiChoice's return values:
+// the 1-based option and the sorted 3-tile sequence, or 0, nil for "Skip".
+func chiChoiceResult(discardedTile Tile, handTilePairs [][]Tile, choice int) (int, []Tile) {
+	if choice < 0 || choice >= len(handTilePairs) {
+		return 0, nil
+	}
+	sequence := append([]Tile{}, handTilePairs[choice]...)
+	sequence = append(sequence, discardedTile)
+	sort.Sort(BySuitValue(sequence))
+	return choice + 1, sequence
+}
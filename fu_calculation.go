@@ -6,9 +6,64 @@ import (
 	// "strings" // Not used directly in this file after updates
 )
 
+// CalculateWinFu works out the Fu HandleWin scores a win with: 0 for Yakuman, 25 for
+// Chiitoitsu, and otherwise CalculateFu on the hand's decomposition (30 if it cannot be
+// decomposed). The steps are CalculateFu's itemized breakdown (none for Yakuman).
+func CalculateWinFu(gs *GameState, winner *Player, winningTile Tile, isTsumo bool, yaku []YakuResult) (int, []string) {
+	allWinningTiles := getAllTilesInHand(winner, winningTile, isTsumo)
+	isMenzen := isMenzenchin(winner, isTsumo, winningTile)
+
+	var decomposition []DecomposedGroup
+	var fu int
+	var steps []string
+	isYakumanWin := YakumanMultiple(yaku) > 0 // Kazoe (13+ Han of regular Yaku) still needs Fu
+	isChiitoitsu := false
+	for _, y := range yaku {
+		if y.Name == "Chiitoitsu" {
+			isChiitoitsu = true
+			break
+		}
+	}
+
+	if isYakumanWin {
+		fu = 0 // Fu usually not directly used for Yakuman point table lookups
+		gs.AddToGameLog("Yakuman hand - Fu calculation for standard scoring table skipped.")
+		// fmt.Println("Hand is Yakuman - Fu calculation skipped.")
+	} else if isChiitoitsu {
+		// CalculateFu will return 25 for Chiitoitsu
+		fu, steps = CalculateFu(winner, nil, winningTile, isTsumo, isMenzen, yaku, gs)
+		gs.AddToGameLog(fmt.Sprintf("Chiitoitsu hand - Calculated Fu: %d (should be 25)", fu))
+		// fmt.Println("Hand is Chiitoitsu - Using fixed 25 Fu.")
+	} else {
+		var decompSuccess bool
+		// gs.AddToGameLog("Decomposing standard hand...")
+		decomposition, decompSuccess = DecomposeWinningHand(winner, allWinningTiles)
+		if !decompSuccess {
+			gs.AddToGameLog(fmt.Sprintf("!!! ERROR: Failed to decompose %s's standard winning hand! Using fallback Fu 30.", winner.Name))
+			// fmt.Println("!!! ERROR: Failed to decompose standard winning hand! Cannot calculate Fu accurately. !!!")
+			fu = 30 // Fallback Fu value
+			steps = []string{"Hand could not be decomposed; fallback Fu = 30"}
+		} else {
+			// gs.AddToGameLog("Calculating Fu based on decomposition...")
+			fu, steps = CalculateFu(winner, decomposition, winningTile, isTsumo, isMenzen, yaku, gs)
+		}
+	}
+	if !isYakumanWin { // Don't log Fu for Yakuman where it's mostly irrelevant for points
+		gs.AddToGameLog(fmt.Sprintf("Calculated Fu: %d", fu))
+		// fmt.Printf("Fu: %d\n", fu)
+	}
+	return fu, steps
+}
+
 // CalculateFu calculates the Fu for a winning hand.
 // It requires the decomposition of the hand, win conditions, and game state.
-func CalculateFu(player *Player, decomposition []DecomposedGroup, agariHai Tile, isTsumo bool, isMenzen bool, yakus []YakuResult, gs *GameState) int {
+// Along with the Fu it returns the itemized steps (also logged as "Fu Calc: ..."), e.g. for the scoring quiz.
+func CalculateFu(player *Player, decomposition []DecomposedGroup, agariHai Tile, isTsumo bool, isMenzen bool, yakus []YakuResult, gs *GameState) (int, []string) {
+	steps := []string{}
+	note := func(step string) {
+		steps = append(steps, step)
+		gs.AddToGameLog("Fu Calc: " + step)
+	}
 	isPinfu := false
 	isChiitoitsu := false
 	isYakuman := false // Check if any Yakuman is present (fu calc might be skipped or different)
@@ -20,45 +75,45 @@ func CalculateFu(player *Player, decomposition []DecomposedGroup, agariHai Tile,
 		if yaku.Name == "Chiitoitsu" {
 			isChiitoitsu = true
 		}
-		if yaku.Han >= 13 || yaku.Name == "Kokushi Musou" || yaku.Name == "Kokushi Musou Juusanmenmachi" { // Add other Yakuman names if they don't always give >=13 Han
+		if yaku.Yakuman > 0 || yaku.Han >= 13 || yaku.Name == "Kokushi Musou" || yaku.Name == "Kokushi Musou Juusanmenmachi" { // Add other Yakuman names if they don't always give >=13 Han
 			isYakuman = true
 		}
 	}
 
 	if isYakuman {
-		gs.AddToGameLog("Fu Calc: Yakuman hand, Fu calculation typically skipped for points. Returning 0 for Fu value.")
-		return 0 // Or a conventional value if your scoring system uses it for Yakuman, e.g. some might treat as Mangan base
+		note("Yakuman hand, Fu calculation typically skipped for points. Returning 0 for Fu value.")
+		return 0, steps // Or a conventional value if your scoring system uses it for Yakuman, e.g. some might treat as Mangan base
 	}
 
 	if isChiitoitsu {
-		gs.AddToGameLog("Fu Calc: Chiitoitsu, Fu fixed at 25.")
-		return 25 // Chiitoitsu always 25 Fu, no rounding needed beyond this fixed value.
+		note("Chiitoitsu, Fu fixed at 25.")
+		return 25, steps // Chiitoitsu always 25 Fu, no rounding needed beyond this fixed value.
 	}
 
 	// --- Standard Hand Fu Calculation ---
 	currentFu := 20 // Base Fu (Foutei)
-	gs.AddToGameLog(fmt.Sprintf("Fu Calc: Base Fu = %d", currentFu))
+	note(fmt.Sprintf("Base Fu = %d", currentFu))
 
 	// 1. Win Method Bonus
 	// Pinfu Tsumo is a special case: it's 20 Fu total, no +2 Tsumo bonus. This is handled later.
 	if isTsumo && !isPinfu {
 		currentFu += 2 // Tsumo bonus (non-Pinfu)
-		gs.AddToGameLog(fmt.Sprintf("Fu Calc: +2 (Tsumo, not Pinfu). Total: %d", currentFu))
+		note(fmt.Sprintf("+2 (Tsumo, not Pinfu). Total: %d", currentFu))
 	}
 	if isMenzen && !isTsumo { // Menzen Ron bonus
 		currentFu += 10
-		gs.AddToGameLog(fmt.Sprintf("Fu Calc: +10 (Menzen Ron). Total: %d", currentFu))
+		note(fmt.Sprintf("+10 (Menzen Ron). Total: %d", currentFu))
 	}
 
 	// If Pinfu, all other Fu components (waits, pair values, group values) are ignored.
 	// Pinfu Ron is 30 Fu total. Pinfu Tsumo is 20 Fu total.
 	if isPinfu {
 		if isTsumo {
-			gs.AddToGameLog(fmt.Sprintf("Fu Calc: Pinfu Tsumo. Fu fixed at 20."))
-			return 20
+			note("Pinfu Tsumo. Fu fixed at 20.")
+			return 20, steps
 		} else { // Pinfu Ron
-			gs.AddToGameLog(fmt.Sprintf("Fu Calc: Pinfu Ron. Fu fixed at 30."))
-			return 30
+			note("Pinfu Ron. Fu fixed at 30.")
+			return 30, steps
 		}
 	}
 
@@ -76,7 +131,7 @@ func CalculateFu(player *Player, decomposition []DecomposedGroup, agariHai Tile,
 			switch group.Type {
 			case TypePair: // Tanki (Pair) Wait
 				waitFuAdded = 2
-				gs.AddToGameLog(fmt.Sprintf("Fu Calc: +2 (Tanki Wait on Pair %s).", agariHai.Name))
+				note(fmt.Sprintf("+2 (Tanki Wait on Pair %s).", agariHai.Name))
 			case TypeSequence:
 				// Tiles t1, t2, t3 are the sorted tiles of the sequence in the decomposition.
 				// agariHai is the tile that completed this sequence.
@@ -86,19 +141,19 @@ func CalculateFu(player *Player, decomposition []DecomposedGroup, agariHai Tile,
 				// If agariHai is the '3' of a 1-2-3 sequence:
 				if agariHai.ID == t3.ID && t1.Value == 1 && t2.Value == 2 && t3.Value == 3 {
 					waitFuAdded = 2
-					gs.AddToGameLog(fmt.Sprintf("Fu Calc: +2 (Penchan Wait %s-%s on %s).", t1.Name, t2.Name, agariHai.Name))
+					note(fmt.Sprintf("+2 (Penchan Wait %s-%s on %s).", t1.Name, t2.Name, agariHai.Name))
 				}
 				// If agariHai is the '7' of a 7-8-9 sequence:
 				if agariHai.ID == t1.ID && t1.Value == 7 && t2.Value == 8 && t3.Value == 9 {
 					waitFuAdded = 2
-					gs.AddToGameLog(fmt.Sprintf("Fu Calc: +2 (Penchan Wait %s-%s on %s).", t2.Name, t3.Name, agariHai.Name))
+					note(fmt.Sprintf("+2 (Penchan Wait %s-%s on %s).", t2.Name, t3.Name, agariHai.Name))
 				}
 
 				// Kanchan (Middle wait): e.g., 4-6 waiting on 5.
 				// AgariHai is the middle tile (t2) of the formed sequence.
 				if agariHai.ID == t2.ID && t1.Value+1 == t2.Value && t2.Value+1 == t3.Value {
 					waitFuAdded = 2
-					gs.AddToGameLog(fmt.Sprintf("Fu Calc: +2 (Kanchan Wait %s_%s on %s).", t1.Name, t3.Name, agariHai.Name))
+					note(fmt.Sprintf("+2 (Kanchan Wait %s_%s on %s).", t1.Name, t3.Name, agariHai.Name))
 				}
 				// Ryanmen (Open wait) and Shanpon (two-pair wait where one becomes Pung) get no *wait* Fu here.
 				// Shanpon Pung Fu is handled by Group Bonus.
@@ -113,7 +168,7 @@ func CalculateFu(player *Player, decomposition []DecomposedGroup, agariHai Tile,
 		}
 		currentFu += waitFuAdded
 		if waitFuAdded > 0 {
-			gs.AddToGameLog(fmt.Sprintf("Fu Calc: Wait Fu added. Total: %d", currentFu))
+			note(fmt.Sprintf("Wait Fu added. Total: %d", currentFu))
 		}
 	}
 
@@ -140,7 +195,7 @@ func CalculateFu(player *Player, decomposition []DecomposedGroup, agariHai Tile,
 					reason += fmt.Sprintf("Prevalent Wind (%s); ", gs.PrevalentWind)
 				}
 				if tempPairFu > 0 {
-					gs.AddToGameLog(fmt.Sprintf("Fu Calc: +%d (Pair Bonus: %s).", tempPairFu, reason))
+					note(fmt.Sprintf("+%d (Pair Bonus: %s).", tempPairFu, reason))
 					pairFuAdded = tempPairFu
 				}
 				break // Found the pair
@@ -148,7 +203,7 @@ func CalculateFu(player *Player, decomposition []DecomposedGroup, agariHai Tile,
 		}
 		currentFu += pairFuAdded
 		if pairFuAdded > 0 {
-			gs.AddToGameLog(fmt.Sprintf("Fu Calc: Pair Fu added. Total: %d", currentFu))
+			note(fmt.Sprintf("Pair Fu added. Total: %d", currentFu))
 		}
 	}
 
@@ -180,14 +235,14 @@ func CalculateFu(player *Player, decomposition []DecomposedGroup, agariHai Tile,
 						meldTypeStr = "Open Kan" // Generic for Daimin/Shoumin
 					}
 				}
-				gs.AddToGameLog(fmt.Sprintf("Fu Calc: +%d (%s of %s %s).",
+				note(fmt.Sprintf("+%d (%s of %s %s).",
 					base, meldTypeStr, tile.Name, If(isTermOrHonor, "(Term/Honor)", "(Simple)")))
 				groupFuAdded += base
 			}
 		}
 		currentFu += groupFuAdded
 		if groupFuAdded > 0 {
-			gs.AddToGameLog(fmt.Sprintf("Fu Calc: Group Fu added. Total: %d", currentFu))
+			note(fmt.Sprintf("Group Fu added. Total: %d", currentFu))
 		}
 	}
 
@@ -203,17 +258,17 @@ func CalculateFu(player *Player, decomposition []DecomposedGroup, agariHai Tile,
 	originalFuBeforeRounding := currentFu
 	if currentFu%10 != 0 {
 		currentFu = int(math.Ceil(float64(currentFu)/10.0) * 10)
-		gs.AddToGameLog(fmt.Sprintf("Fu Calc: Rounded %d -> %d.", originalFuBeforeRounding, currentFu))
+		note(fmt.Sprintf("Rounded %d -> %d.", originalFuBeforeRounding, currentFu))
 	}
 
 	// Minimum Fu (usually 30 for non-Pinfu/non-Chiitoitsu hands after all calculations and rounding)
 	if currentFu < 30 {
-		gs.AddToGameLog(fmt.Sprintf("Fu Calc: Adjusted to minimum 30 from %d (non-Pinfu/Chiitoitsu).", currentFu))
+		note(fmt.Sprintf("Adjusted to minimum 30 from %d (non-Pinfu/Chiitoitsu).", currentFu))
 		currentFu = 30
 	}
 
-	gs.AddToGameLog(fmt.Sprintf("Fu Calc: Final Fu = %d", currentFu))
-	return currentFu
+	note(fmt.Sprintf("Final Fu = %d", currentFu))
+	return currentFu, steps
 }
 
 // isWindMatch helper: checks if a wind tile matches a specific wind name (e.g., player's seat wind string)
@@ -9,8 +9,13 @@ import (
 	"time" // Used by rand.Seed, but seed is called in main.go
 )
 
-// NewGameState initializes a new game state for the given player names.
+// NewGameState initializes a new game state for the given player names with the default rules.
 func NewGameState(playerNames []string) *GameState {
+	return NewGameStateWithRules(playerNames, DefaultRuleSet())
+}
+
+// NewGameStateWithRules initializes a new game state for the given player names and rules.
+func NewGameStateWithRules(playerNames []string, rules RuleSet) *GameState {
 	if len(playerNames) != 4 {
 		panic("Must initialize game with exactly 4 players")
 	}
@@ -27,9 +32,9 @@ func NewGameState(playerNames []string) *GameState {
 		players[i] = &Player{
 			Name:                         name,
 			Hand:                         []Tile{},
-			Discards:                     []Tile{},
+			Discards:                     []Discard{},
 			Melds:                        []Meld{},
-			Score:                        InitialScore, // from types.go
+			Score:                        rules.StartingPoints,
 			SeatWind:                     winds[seatWindIndex],
 			IsRiichi:                     false,
 			RiichiTurn:                   -1,
@@ -47,6 +52,7 @@ func NewGameState(playerNames []string) *GameState {
 			IsTenpai:                     false,
 			PaoTargetFor:                 nil,
 			PaoSourcePlayerIndex:         -1,
+			PaoYakuman:                   "",
 			InitialTurnOrder:             i, // Store initial fixed order (0-3) for rules like Ssuufon Renda
 		}
 	}
@@ -55,22 +61,24 @@ func NewGameState(playerNames []string) *GameState {
 		Players:              players,
 		CurrentPlayerIndex:   initialDealerIndex, // Current player to act; dealer starts the first round.
 		DealerIndexThisRound: initialDealerIndex, // Tracks who is dealer for this specific round.
+		InitialDealerIndex:   initialDealerIndex, // First East seat; breaks ties in the final standings.
+		Rules:                rules,
 		// DiscardPile is not strictly necessary if each player tracks their own discards for furiten.
 		// DoraIndicators and UraDoraIndicators initialized by setupNewRoundDeck.
 		PrevalentWind:               "East", // Game starts with East wind
 		RoundNumber:                 1,      // Round number within the current Prevalent Wind (e.g., East 1, East 2)
 		DealerRoundCount:            1,      // How many consecutive rounds the current dealer has been dealer
 		Honba:                       0,
+		RinshanPaoSourceIndex:       -1, // Set by each Kan before its Rinshan draw
 		RiichiSticks:                0,
 		TurnNumber:                  0, // Overall turn number in the round (increments on each discard)
 		GamePhase:                   PhaseDealing,
 		InputReader:                 bufio.NewReader(os.Stdin),
+		Input:                       ConsoleInput{},
 		LastDiscard:                 nil,
 		AnyCallMadeThisRound:        false,
 		IsFirstGoAround:             true,
 		RoundWinner:                 nil,
-		FirstTurnDiscards:           [4]Tile{}, // For Ssuufon Renda, indexed by player.InitialTurnOrder
-		FirstTurnDiscardCount:       0,
 		DeclaredRiichiPlayerIndices: make(map[int]bool),
 		TotalKansDeclaredThisRound:  0,
 		MaxWindRounds:               2, // Default to East and South rounds (Tonpuusen + Nanbausen)
@@ -79,12 +87,6 @@ func NewGameState(playerNames []string) *GameState {
 		GameLog:                     []string{fmt.Sprintf("Game Started. Initial Dealer: P%d %s", initialDealerIndex+1, players[initialDealerIndex].Name)},
 	}
 
-	// Initialize player-specific flags not covered by the Player struct zero values if needed
-	for _, p := range gs.Players {
-		// p.JustDrawnTile = nil // Already nil by default
-		// p.RiichiDeclaredWaits = []Tile{} // Already empty slice by default
-	}
-
 	gs.setupNewRoundDeck() // Sets up Wall, DeadWall, and reveals initial Dora
 	return gs
 }
@@ -96,6 +98,7 @@ func (gs *GameState) setupNewRoundDeck() {
 	gs.DeadWall = deck[TotalTiles-DeadWallSize:] // Last 14 tiles
 	gs.DoraIndicators = []Tile{}                 // Clear previous Dora
 	gs.UraDoraIndicators = []Tile{}              // Clear previous Ura Dora
+	gs.PendingKanDora = 0
 	gs.RevealInitialDoraIndicator()
 	if len(gs.DoraIndicators) > 0 {
 		gs.AddToGameLog(fmt.Sprintf("New round deck setup. Initial Dora Indicator: %s", gs.DoraIndicators[0].Name))
@@ -235,13 +238,30 @@ func (gs *GameState) DrawRinshanTile() (Tile, bool) {
 		}
 	}
 
-	// Reveal Kan Dora Indicator *after* drawing Rinshan tile.
-	gs.RevealKanDoraIndicator() // This function uses the number of *already revealed* Doras.
-
 	gs.AddToGameLog(fmt.Sprintf("%s drew Rinshan tile: %s (from Dead Wall pos %d)", player.Name, rinshanTile.Name, rinshanTileIndex))
 	return rinshanTile, false
 }
 
+// RevealKanDora turns the dora indicator for a Kan just completed, or leaves it pending when
+// RuleSet.KanDoraTiming delays open Kans (see FlipPendingKanDora).
+func (gs *GameState) RevealKanDora(kanType string) {
+	timing := gs.Rules.KanDoraTiming
+	if kanType == "Ankan" || timing == "" || timing == KanDoraImmediate {
+		gs.RevealKanDoraIndicator()
+		return
+	}
+	gs.PendingKanDora++
+	gs.AddToGameLog(fmt.Sprintf("Kan Dora for the %s will be revealed after the discard.", kanType))
+}
+
+// FlipPendingKanDora turns the indicators left pending by open Kans: on the discard after the
+// Rinshan draw, on a further Kan, or (KanDoraDelayed) before a Rinshan Kaihou is scored.
+func (gs *GameState) FlipPendingKanDora() {
+	for ; gs.PendingKanDora > 0; gs.PendingKanDora-- {
+		gs.RevealKanDoraIndicator()
+	}
+}
+
 // RevealKanDoraIndicator reveals a new dora indicator after a Kan.
 func (gs *GameState) RevealKanDoraIndicator() {
 	// Number of Dora indicators already revealed (initial + previous Kan Doras)
@@ -284,6 +304,9 @@ func (gs *GameState) RevealKanDoraIndicator() {
 func (gs *GameState) RevealUraDoraIndicators() {
 	gs.UraDoraIndicators = []Tile{}           // Clear previous Ura Dora, if any
 	numDoraRevealed := len(gs.DoraIndicators) // How many Dora/KanDora were flipped in total
+	if !gs.Rules.KanUra && numDoraRevealed > 1 {
+		numDoraRevealed = 1 // No kan ura: only the indicator under the initial Dora
+	}
 
 	if numDoraRevealed == 0 {
 		return
@@ -342,7 +365,9 @@ func (gs *GameState) GetPlayerIndex(player *Player) int {
 
 // AddToGameLog adds a message to the game log, with a limit on log size.
 func (gs *GameState) AddToGameLog(message string) {
-	fmt.Println("LOG: " + message) // Print to console for immediate visibility during CLI play
+	if !gs.QuietLog {
+		fmt.Println("LOG: " + message) // Print to console for immediate visibility during CLI play
+	}
 	gs.GameLog = append(gs.GameLog, time.Now().Format("15:04:05")+" | "+message)
 	if len(gs.GameLog) > 200 { // Keep log from growing indefinitely
 		gs.GameLog = gs.GameLog[len(gs.GameLog)-100:] // Keep last 100 entries
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// GameView is the Fyne table for the human seat (player index 0).
+// It only renders the game state and turns clicks into answers; the game itself still runs in RunGame.
+// The engine goroutine and the UI goroutine both change the hand and action bar, so they do it under mu.
+type GameView struct {
+	window  fyne.Window
+	status  *widget.Label   // Round, honba, riichi sticks, dora indicators
+	table   *widget.Label   // Every player's score, melds and river
+	prompt  *widget.Label   // What the engine is currently asking for
+	actions *fyne.Container // Call, riichi and confirmation buttons
+	hand    *fyne.Container // One button per tile in the human's hand
+
+	mu      sync.Mutex
+	offered []*widget.Button // The buttons in the action bar, guarded by mu
+}
+
+// NewGameView builds the table layout inside w.
+func NewGameView(w fyne.Window) *GameView {
+	v := &GameView{
+		window:  w,
+		status:  widget.NewLabel(""),
+		table:   widget.NewLabel(""),
+		prompt:  widget.NewLabel(""),
+		actions: container.NewHBox(),
+		hand:    container.NewHBox(),
+	}
+	v.table.Wrapping = fyne.TextWrapWord
+	bottom := container.NewVBox(v.prompt, v.actions, container.NewHScroll(v.hand))
+	w.SetContent(container.NewBorder(v.status, bottom, nil, nil, container.NewVScroll(v.table)))
+	return v
+}
+
+// Refresh redraws the table from gs. The hand is shown but not clickable.
+func (v *GameView) Refresh(gs *GameState) {
+	v.status.SetText(fmt.Sprintf("%s %d | Honba: %d | Riichi Sticks: %d | Wall: %d | Dora Indicators: %s",
+		gs.PrevalentWind, gs.RoundNumber, gs.Honba, gs.RiichiSticks, len(gs.Wall), strings.Join(TilesToNames(gs.DoraIndicators), ", ")))
+
+	lines := []string{}
+	for i, p := range gs.Players {
+		marker := If(i == gs.CurrentPlayerIndex, ">", " ")
+		lines = append(lines, fmt.Sprintf("%s P%d %s (%s) %d %s", marker, i+1, p.Name, p.SeatWind, p.Score, If(p.IsRiichi, "[Riichi]", "")))
+		lines = append(lines, "    Melds: "+FormatMeldsForDisplay(p.Melds))
+		lines = append(lines, "    River: "+strings.Join(RiverNames(p), ", "))
+		if p.IsRiichi && p.IsOpenRiichi {
+			lines = append(lines, "    Open Hand: "+strings.Join(TilesToNames(p.Hand), ", "))
+		}
+	}
+	if waits := NewPlayerView(gs, 0).Waits; waits != "" {
+		lines = append(lines, "", waits)
+	}
+	v.table.SetText(strings.Join(lines, "\n"))
+	v.showHand(gs.Players[0], nil)
+}
+
+// showHand lays out one button per hand tile. With a nil onTap the buttons are disabled.
+func (v *GameView) showHand(player *Player, onTap func(int)) {
+	buttons := make([]fyne.CanvasObject, 0, len(player.Hand))
+	for i, tile := range player.Hand {
+		label := tile.Name
+		if player.JustDrawnTile != nil && player.JustDrawnTile.ID == tile.ID {
+			label += " (drawn)"
+		}
+		index := i
+		button := widget.NewButton(label, func() { onTap(index) })
+		if onTap == nil {
+			button.Disable()
+		}
+		buttons = append(buttons, button)
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.hand.Objects = buttons
+	v.hand.Refresh()
+}
+
+// setActions replaces the action bar.
+func (v *GameView) setActions(buttons []*widget.Button) {
+	objects := make([]fyne.CanvasObject, len(buttons))
+	for i, b := range buttons {
+		objects[i] = b
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.offered = buttons
+	v.actions.Objects = objects
+	v.actions.Refresh()
+}
+
+// Offered returns the buttons currently in the action bar. It is safe to call from any goroutine.
+func (v *GameView) Offered() []*widget.Button {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]*widget.Button{}, v.offered...)
+}
+
+// offerDiscard makes the hand clickable; reply receives the tapped tile's index once.
+func (v *GameView) offerDiscard(player *Player, reply func(int)) {
+	var once sync.Once
+	v.prompt.SetText("Choose a tile to discard.")
+	v.setActions(nil)
+	v.showHand(player, func(index int) {
+		once.Do(func() {
+			v.showHand(player, nil)
+			v.prompt.SetText("")
+			reply(index)
+		})
+	})
+}
+
+// offerChoices shows one button per label; reply receives the tapped label's index once.
+func (v *GameView) offerChoices(prompt string, labels []string, reply func(int)) {
+	var once sync.Once
+	v.prompt.SetText(prompt)
+	buttons := make([]*widget.Button, 0, len(labels))
+	for i, label := range labels {
+		choice := i
+		buttons = append(buttons, widget.NewButton(label, func() {
+			once.Do(func() {
+				v.prompt.SetText("")
+				v.setActions(nil)
+				reply(choice)
+			})
+		}))
+	}
+	v.setActions(buttons)
+}
+
+// showRoundResult opens the end-of-hand dialog; onClosed runs when it is dismissed.
+func (v *GameView) showRoundResult(gs *GameState, onClosed func()) {
+	d := dialog.NewInformation("Hand Result", roundResultText(gs), v.window)
+	d.SetOnClosed(onClosed)
+	d.Show()
+}
+
+// showFinalResults opens the game-over dialog with the final standings.
+func (v *GameView) showFinalResults(gs *GameState) {
+	lines := []string{}
+	for i, p := range FinalStandings(gs) {
+		lines = append(lines, fmt.Sprintf("%d. %s: %d points", i+1, p.Name, p.Score))
+	}
+	dialog.ShowInformation("Game Over", strings.Join(lines, "\n"), v.window)
+}
+
+// GUIInput is the PlayerInput for the Fyne client. Each call blocks the engine
+// goroutine until the player answers in the window.
+type GUIInput struct {
+	view *GameView
+}
+
+func (in *GUIInput) ChooseDiscard(gs *GameState, player *Player) int {
+	in.view.Refresh(gs)
+	answer := make(chan int, 1)
+	in.view.offerDiscard(player, func(index int) { answer <- index })
+	return <-answer
+}
+
+func (in *GUIInput) ChooseRiichi(gs *GameState, player *Player, options []RiichiOption) (int, bool) {
+	in.view.Refresh(gs)
+	answer := make(chan int, 1)
+	in.view.offerChoices("Declare Riichi?", riichiChoiceLabels(options), func(choice int) { answer <- choice })
+	choice := <-answer
+	if choice >= len(options) { // "No Riichi"
+		return -1, false
+	}
+	return choice, true
+}
+
+// ChooseChi returns the 1-based option and the sorted 3-tile sequence, or 0, nil if skipped (like GetChiChoice).
+func (in *GUIInput) ChooseChi(gs *GameState, player *Player, discardedTile Tile) (int, []Tile) {
+	if !contains(LegalCallsOnDiscard(gs, player, discardedTile, gs.CurrentPlayerIndex), ActionChi) {
+		return 0, nil
+	}
+	handTilePairs := FindPossibleChiSequences(player, discardedTile, gs)
+
+	in.view.Refresh(gs)
+	answer := make(chan int, 1)
+	in.view.offerChoices(chiPrompt(gs, player, discardedTile), chiChoiceLabels(discardedTile, handTilePairs), func(choice int) { answer <- choice })
+	return chiChoiceResult(discardedTile, handTilePairs, <-answer)
+}
+
+func (in *GUIInput) Confirm(gs *GameState, player *Player, action string, tile Tile, prompt string) bool {
+	label := confirmButtonLabel(gs, player, action, tile)
+	if label == "" {
+		gs.AddToGameLog(fmt.Sprintf("GUI: %s is not legal for %s on %s, skipping.", action, player.Name, tile.Name))
+		return false
+	}
+	in.view.Refresh(gs)
+	answer := make(chan int, 1)
+	in.view.offerChoices(strings.TrimSuffix(prompt, " (y/n): "), []string{label, "Skip"}, func(choice int) { answer <- choice })
+	return <-answer == 0
+}
+
+// RoundEnded shows the end-of-hand dialog and waits for it to be dismissed.
+func (in *GUIInput) RoundEnded(gs *GameState) {
+	in.view.Refresh(gs)
+	closed := make(chan struct{})
+	in.view.showRoundResult(gs, func() { close(closed) })
+	<-closed
+}
+
+// RunGUI opens the desktop client on a and plays one game under rules with the human in seat 0,
+// adding it to the statistics in statsFile if set.
+func RunGUI(a fyne.App, rules RuleSet, statsFile string) {
+	w := a.NewWindow("Riichi Mahjong")
+	view := NewGameView(w)
+	gs := NewGameStateWithRules(defaultPlayerNames, rules)
+	gs.Input = &GUIInput{view: view}
+
+	go func() {
+		PlayRecordedGame(gs, statsFile)
+		view.Refresh(gs)
+		view.showFinalResults(gs)
+	}()
+
+	w.Resize(fyne.NewSize(1100, 700))
+	w.ShowAndRun()
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"flag"
+
+	"fyne.io/fyne/v2/app"
+)
+
+// runGUICommand starts the Fyne desktop client ("gui" command).
+// Kept apart from gui.go so the view logic can be tested with fyne's test driver.
+func runGUICommand(args []string) {
+	flags := flag.NewFlagSet("gui", flag.ExitOnError)
+	rulesFile := addRulesFlag(flags)
+	statsFile := flags.String("stats", "", "add the finished game to the statistics in this file")
+	flags.Parse(args)
+
+	RunGUI(app.New(), mustLoadRuleSet(*rulesFile), *statsFile)
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newTestGameView builds a GameView on fyne's in-memory test driver.
+func newTestGameView(t *testing.T) (*GameView, fyne.Window) {
+	test.NewTempApp(t)
+	w := test.NewTempWindow(t, nil)
+	return NewGameView(w), w
+}
+
+// buttonLabels lists the text of every button.
+func buttonLabels(buttons []*widget.Button) []string {
+	labels := []string{}
+	for _, b := range buttons {
+		labels = append(labels, b.Text)
+	}
+	return labels
+}
+
+// waitForActions polls until the view offers n action buttons and returns them (the engine side
+// runs in its own goroutine).
+func waitForActions(t *testing.T, v *GameView, n int) []*widget.Button {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		buttons := v.Offered()
+		if len(buttons) == n {
+			return buttons
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %d action buttons, have %v", n, buttonLabels(buttons))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestGameViewOfferDiscard_TapReturnsIndex(t *testing.T) {
+	v, _ := newTestGameView(t)
+	player := createTestPlayer()
+	player.Hand = TilesFromString("1m 2m 3m 4p 5p 6p 7s 8s 9s E E S S W")
+
+	got, calls := -1, 0
+	v.offerDiscard(player, func(index int) { got = index; calls++ })
+	if len(v.hand.Objects) != 14 {
+		t.Fatalf("TestGameViewOfferDiscard_TapReturnsIndex: Expected 14 hand buttons, got %d", len(v.hand.Objects))
+	}
+	test.Tap(v.hand.Objects[4].(*widget.Button))
+	test.Tap(v.hand.Objects[5].(*widget.Button)) // Hand is locked after the first choice
+
+	if got != 4 || calls != 1 {
+		t.Errorf("TestGameViewOfferDiscard_TapReturnsIndex: Expected one reply with index 4, got %d replies, last %d", calls, got)
+	}
+	if !v.hand.Objects[0].(*widget.Button).Disabled() {
+		t.Errorf("TestGameViewOfferDiscard_TapReturnsIndex: Expected hand buttons to be disabled after discarding")
+	}
+}
+
+func TestConfirmButtonLabel_PonOnlyWhenLegal(t *testing.T) {
+	gs := createTestGameState(nil)
+	caller := gs.Players[2]
+	caller.Hand = TilesFromString("5p 5p 1m 2m 3m 7s 8s 9s E S W N")
+	gs.CurrentPlayerIndex = 0 // Discarder
+
+	fivePin := TilesFromString("5p")[0]
+	if label := confirmButtonLabel(gs, caller, ActionPon, fivePin); label != "Pon Pin 5" {
+		t.Errorf("TestConfirmButtonLabel_PonOnlyWhenLegal: Expected 'Pon Pin 5', got %q", label)
+	}
+	if label := confirmButtonLabel(gs, caller, ActionPon, TilesFromString("9m")[0]); label != "" {
+		t.Errorf("TestConfirmButtonLabel_PonOnlyWhenLegal: Expected no Pon button on Man 9, got %q", label)
+	}
+	caller.IsRiichi = true
+	if label := confirmButtonLabel(gs, caller, ActionPon, fivePin); label != "" {
+		t.Errorf("TestConfirmButtonLabel_PonOnlyWhenLegal: Expected no Pon button while in Riichi, got %q", label)
+	}
+}
+
+func TestConfirmButtonLabel_ChiOnlyForNextPlayer(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.CurrentPlayerIndex = 0
+	threeMan := TilesFromString("3m")[0]
+	for i := 1; i < 4; i++ {
+		gs.Players[i].Hand = TilesFromString("1m 2m 4p 5p 6p 7s 8s 9s E S W N")
+	}
+
+	if calls := LegalCallsOnDiscard(gs, gs.Players[1], threeMan, 0); !contains(calls, ActionChi) {
+		t.Errorf("TestConfirmButtonLabel_ChiOnlyForNextPlayer: Expected Chi for the player left of the discarder, got %v", calls)
+	}
+	if calls := LegalCallsOnDiscard(gs, gs.Players[2], threeMan, 0); contains(calls, ActionChi) {
+		t.Errorf("TestConfirmButtonLabel_ChiOnlyForNextPlayer: Expected no Chi across the table, got %v", calls)
+	}
+
+	v, _ := newTestGameView(t)
+	in := &GUIInput{view: v}
+	if choice, seq := in.ChooseChi(gs, gs.Players[2], threeMan); choice != 0 || seq != nil {
+		t.Errorf("TestConfirmButtonLabel_ChiOnlyForNextPlayer: Expected illegal Chi to be skipped, got %d %v", choice, seq)
+	}
+	if offered := v.Offered(); len(offered) != 0 {
+		t.Errorf("TestConfirmButtonLabel_ChiOnlyForNextPlayer: Expected no buttons for an illegal Chi, got %v", buttonLabels(offered))
+	}
+}
+
+func TestGUIInputConfirm_TapCallButton(t *testing.T) {
+	v, _ := newTestGameView(t)
+	in := &GUIInput{view: v}
+	gs := createTestGameState(nil)
+	caller := gs.Players[0]
+	caller.Hand = TilesFromString("5p 5p 1m 2m 3m 7s 8s 9s E S W N")
+	gs.CurrentPlayerIndex = 3
+
+	answer := make(chan bool, 1)
+	go func() {
+		answer <- in.Confirm(gs, caller, ActionPon, TilesFromString("5p")[0], "P1, declare PON on Pin 5? (y/n): ")
+	}()
+	buttons := waitForActions(t, v, 2)
+
+	labels := buttonLabels(buttons)
+	if labels[0] != "Pon Pin 5" || labels[1] != "Skip" {
+		t.Fatalf("TestGUIInputConfirm_TapCallButton: Expected [Pon Pin 5, Skip], got %v", labels)
+	}
+	if v.prompt.Text != "P1, declare PON on Pin 5?" {
+		t.Errorf("TestGUIInputConfirm_TapCallButton: Expected prompt without (y/n), got %q", v.prompt.Text)
+	}
+	test.Tap(buttons[0])
+
+	select {
+	case ok := <-answer:
+		if !ok {
+			t.Errorf("TestGUIInputConfirm_TapCallButton: Expected Confirm to return true after tapping Pon")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("TestGUIInputConfirm_TapCallButton: Confirm did not return after tap")
+	}
+}
+
+func TestGUIInputChooseRiichi_ShowsWaits(t *testing.T) {
+	v, _ := newTestGameView(t)
+	in := &GUIInput{view: v}
+	gs := createTestGameState(nil)
+	player := gs.Players[0]
+	player.Hand = TilesFromString("1m 2m 3m 4p 5p 6p 7s 8s 9s E E S S W")
+	options := FindRiichiOptions(player.Hand, player.Melds)
+	if len(options) != 1 {
+		t.Fatalf("TestGUIInputChooseRiichi_ShowsWaits: Expected 1 riichi option, got %d", len(options))
+	}
+
+	type result struct {
+		index int
+		ok    bool
+	}
+	answer := make(chan result, 1)
+	go func() {
+		index, ok := in.ChooseRiichi(gs, player, options)
+		answer <- result{index, ok}
+	}()
+	buttons := waitForActions(t, v, 2)
+
+	labels := buttonLabels(buttons)
+	if !strings.Contains(labels[0], "cut West") || !strings.Contains(labels[0], "East") || !strings.Contains(labels[0], "South") {
+		t.Errorf("TestGUIInputChooseRiichi_ShowsWaits: Expected riichi button to show the discard and East/South waits, got %q", labels[0])
+	}
+	test.Tap(buttons[1]) // "No Riichi"
+
+	r := <-answer
+	if r.ok || r.index != -1 {
+		t.Errorf("TestGUIInputChooseRiichi_ShowsWaits: Expected cancelled riichi (-1, false), got (%d, %v)", r.index, r.ok)
+	}
+}
+
+func TestRoundResultText_Win(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.RoundWinner = gs.Players[1]
+	gs.LastWinResult = &WinResult{
+		Winner:      gs.Players[1],
+		WinningTile: TilesFromString("5p")[0],
+		IsTsumo:     false,
+		Yaku:        []YakuResult{{Name: "Riichi", Han: 1}, {Name: "Pinfu", Han: 1}},
+		Han:         2,
+		Fu:          30,
+		Payment:     Payment{Description: "2000 points", RonValue: 2000},
+	}
+
+	text := roundResultText(gs)
+	for _, want := range []string{"P2 wins by Ron on Pin 5", "Riichi: 1 han", "Pinfu: 1 han", "2 han 30 fu", "2000 points"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("TestRoundResultText_Win: Expected result text to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestGameViewShowRoundResult_Draw(t *testing.T) {
+	v, w := newTestGameView(t)
+	gs := createTestGameState(nil)
+	gs.Players[0].IsTenpai = true
+
+	text := roundResultText(gs)
+	if !strings.Contains(text, "Draw") || !strings.Contains(text, "P1: Tenpai") || !strings.Contains(text, "P2: Noten") {
+		t.Errorf("TestGameViewShowRoundResult_Draw: Expected draw text with tenpai status, got:\n%s", text)
+	}
+
+	v.showRoundResult(gs, func() {})
+	if w.Canvas().Overlays().Top() == nil {
+		t.Errorf("TestGameViewShowRoundResult_Draw: Expected the hand result dialog to be shown")
+	}
+}
+
+func TestGameViewRefresh_ShowsHumanWaits(t *testing.T) {
+	v, _ := newTestGameView(t)
+	gs := createTestGameState(nil)
+	gs.Players[0].Hand = TilesFromString("1m 2m 3m 4p 5p 6p 7s 8s 9s E E 4s 5s")
+
+	v.Refresh(gs)
+	if !strings.Contains(v.table.Text, "Waits: Sou 3") {
+		t.Errorf("TestGameViewRefresh_ShowsHumanWaits: Expected the human's waits on the table, got:\n%s", v.table.Text)
+	}
+}
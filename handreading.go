@@ -0,0 +1,92 @@
+package main
+
+import "fmt"
+
+// Hand-reading aids: hints a player (or bot) can take from another player's river. They only
+// use public information; NewPlayerView lists them for each seat.
+
+// LongTsumogiriRun is how many tsumogiri in a row make the next tedashi a tenpai signal.
+const LongTsumogiriRun = 3
+
+// LateRiverDiscards is the river length from which a tedashi of a middle tile reads as a
+// player breaking a useful shape to reach tenpai (the third row of the river).
+const LateRiverDiscards = 12
+
+// TenpaiSignal is a discard that suggests its player may have reached tenpai.
+type TenpaiSignal struct {
+	Index  int    // Position in the river
+	Turn   int    // Turn number of the discard
+	Reason string // Why it reads as a tenpai signal
+}
+
+// TenpaiSignals flags the typical tenpai signals in a river: a tedashi after a long run of
+// tsumogiri (the hand stopped improving, then changed shape), and a late tedashi of a middle
+// tile (3-7), which players usually only give up once the hand is ready. Riichi declarations
+// are not listed; they are announced.
+func TenpaiSignals(river []Discard) []TenpaiSignal {
+	signals := []TenpaiSignal{}
+	tsumogiriRun := 0
+	for i, d := range river {
+		if d.Tsumogiri {
+			tsumogiriRun++
+			continue
+		}
+		switch {
+		case d.RiichiTile:
+			// Announced
+		case tsumogiriRun >= LongTsumogiriRun:
+			signals = append(signals, TenpaiSignal{Index: i, Turn: d.Turn,
+				Reason: fmt.Sprintf("tedashi of %s after %d tsumogiri in a row", d.Tile.Name, tsumogiriRun)})
+		case i >= LateRiverDiscards && !IsHonor(d.Tile) && d.Tile.Value >= 3 && d.Tile.Value <= 7:
+			signals = append(signals, TenpaiSignal{Index: i, Turn: d.Turn,
+				Reason: fmt.Sprintf("late tedashi of middle tile %s", d.Tile.Name)})
+		}
+		tsumogiriRun = 0
+	}
+	return signals
+}
+
+// Rough deal-in rates against a Riichi for each kind of discard, by the tile's number (1-9)
+// or for honors by how many copies are still unseen. Genbutsu is always 0.
+var (
+	sujiDealInRate     = [10]float64{0, 0.02, 0.03, 0.05, 0.05, 0.05, 0.05, 0.05, 0.03, 0.02}
+	nonSujiDealInRate  = [10]float64{0, 0.06, 0.08, 0.10, 0.13, 0.13, 0.13, 0.10, 0.08, 0.06}
+	halfSujiDealInRate = 0.08                                  // A 4, 5 or 6 covered by suji on one side only
+	honorDealInRate    = [5]float64{0, 0.01, 0.03, 0.06, 0.08} // By copies unseen (1-4)
+)
+
+// DealInRisk estimates the chance that discarding tile deals into a player in Riichi with the
+// given river, using genbutsu (the tile is in their river, or in passed: tiles others
+// discarded since the Riichi without a Ron), suji from their river, and for honors the copies
+// still unseen (visible counts every copy the discarder can see, by tileKind).
+func DealInRisk(tile Tile, river []Discard, passed []Tile, visible [34]int) float64 {
+	kind := tileKind(tile)
+	inRiver := make(map[int]bool)
+	for _, d := range river {
+		inRiver[tileKind(d.Tile)] = true
+	}
+	for _, t := range passed {
+		inRiver[tileKind(t)] = true
+	}
+	if inRiver[kind] {
+		return 0
+	}
+	if IsHonor(tile) {
+		unseen := 4 - visible[kind]
+		if unseen < 1 {
+			unseen = 1
+		}
+		return honorDealInRate[unseen]
+	}
+
+	v := tile.Value
+	low := v > 3 && inRiver[kind-3]  // Suji below: 1 covers 4, 4 covers 7
+	high := v < 7 && inRiver[kind+3] // Suji above: 9 covers 6, 6 covers 3
+	switch {
+	case v <= 3 && high, v >= 7 && low, low && high:
+		return sujiDealInRate[v]
+	case low || high:
+		return halfSujiDealInRate
+	}
+	return nonSujiDealInRate[v]
+}
@@ -0,0 +1,68 @@
+package main
+
+import "testing"
+
+func TestTenpaiSignals(t *testing.T) {
+	river := riverFromString("E 1m 9p 2s 7m 8p S 4m") // 7m tedashi after three tsumogiri
+	for _, i := range []int{1, 2, 3} {
+		river[i].Tsumogiri = true
+	}
+	signals := TenpaiSignals(river)
+	if len(signals) != 1 || signals[0].Index != 4 {
+		t.Fatalf("TestTenpaiSignals: Expected one signal on the 7m tedashi, got %v", signals)
+	}
+
+	river = riverFromString("E 1m 9p 2s 7m") // Two tsumogiri are not a long run
+	river[2].Tsumogiri, river[3].Tsumogiri = true, true
+	if signals := TenpaiSignals(river); len(signals) != 0 {
+		t.Errorf("TestTenpaiSignals: Expected no signal after two tsumogiri, got %v", signals)
+	}
+
+	river = riverFromString("E S W N w g r 1m 9m 1p 9p 1s 5s") // Middle tile from the hand in the third row
+	if signals := TenpaiSignals(river); len(signals) != 1 || signals[0].Index != 12 {
+		t.Errorf("TestTenpaiSignals: Expected a late middle-tile tedashi signal, got %v", signals)
+	}
+}
+
+func TestNewPlayerView_River(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.Players[2].Discards = riverFromString("E 1m 9p 2s 7m")
+	gs.Players[2].Discards[1].Tsumogiri = true
+	gs.Players[2].Discards[2].Tsumogiri = true
+	gs.Players[2].Discards[3].Tsumogiri = true
+	gs.Players[2].Discards[4].Called = true
+
+	seat := NewPlayerView(gs, 0).Players[2]
+	if len(seat.River) != 5 || !seat.River[1].Tsumogiri || seat.River[0].Tsumogiri || !seat.River[4].Called {
+		t.Errorf("TestNewPlayerView_River: Expected the river with its tsumogiri and called flags, got %+v", seat.River)
+	}
+	if len(seat.Signals) != 1 {
+		t.Errorf("TestNewPlayerView_River: Expected the 7m tedashi signal, got %v", seat.Signals)
+	}
+}
+
+func TestDealInRisk(t *testing.T) {
+	river := riverFromString("1m 5p E")
+	passed := TilesFromString("9s")
+	var visible [34]int
+	visible[tileKind(TilesFromString("S")[0])] = 3
+
+	cases := []struct {
+		tile     string
+		expected float64
+	}{
+		{"1m", 0},    // Genbutsu
+		{"9s", 0},    // Passed after the Riichi
+		{"4m", 0.08}, // Half suji (1m only)
+		{"2p", 0.03}, // Suji of 5p
+		{"8p", 0.03}, // Suji of 5p
+		{"5s", 0.13}, // Nothing
+		{"S", 0.01},  // One copy unseen
+		{"W", 0.08},  // Four copies unseen
+	}
+	for _, c := range cases {
+		if got := DealInRisk(TilesFromString(c.tile)[0], river, passed, visible); got != c.expected {
+			t.Errorf("TestDealInRisk: Expected %.2f for %s, got %.2f", c.expected, c.tile, got)
+		}
+	}
+}
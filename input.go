@@ -59,6 +59,10 @@ func GetPlayerRiichiChoice(reader *bufio.Reader, options []RiichiOption) (int, b
 	fmt.Println("\n--- Declare Riichi ---")
 	fmt.Println("Choose discard to declare Riichi:")
 	for i, opt := range options {
+		if len(opt.Waits) == 0 { // Manual declarations: the player judges the wait
+			fmt.Printf("[%d] Discard %s\n", i+1, opt.DiscardTile.Name)
+			continue
+		}
 		// Sort waits for display consistency
 		sort.Sort(BySuitValue(opt.Waits))
 		fmt.Printf("[%d] Discard %s -> Waits: %v\n",
@@ -94,7 +98,7 @@ func GetPlayerRiichiChoice(reader *bufio.Reader, options []RiichiOption) (int, b
 // Returns choice number (1-based) and the 3 tiles for the chosen sequence, or 0, nil if cancelled/invalid.
 func GetChiChoice(gs *GameState, player *Player, discardedTile Tile) (int, []Tile) {
 	// Find the pairs of hand tiles that enable Chi
-	possibleHandTilePairs := FindPossibleChiSequences(player, discardedTile)
+	possibleHandTilePairs := FindPossibleChiSequences(player, discardedTile, gs)
 
 	if len(possibleHandTilePairs) == 0 {
 		fmt.Println("Error: GetChiChoice called but no Chi sequences found.") // Should not happen
@@ -134,3 +138,88 @@ func GetChiChoice(gs *GameState, player *Player, discardedTile Tile) (int, []Til
 	chosenSequence := fullSequences[choice-1]
 	return choice, chosenSequence
 }
+
+// Decision kinds passed to PlayerInput.Confirm. Own-turn kans pass the kan type
+// ("Ankan", "Shouminkan") instead of ActionKan, which is the Daiminkan call on a discard.
+const (
+	ActionTsumo    = "Tsumo"
+	ActionRon      = "Ron"
+	ActionChankan  = "Chankan"
+	ActionKan      = "Kan"
+	ActionPon      = "Pon"
+	ActionChi      = "Chi"
+	ActionKyuushuu = "Kyuushuu"
+	ActionYame     = "Yame"
+
+	ActionOpenRiichi = "OpenRiichi" // Show the hand when declaring Riichi (Open Riichi local yaku)
+)
+
+// PlayerInput supplies the decisions for the human seat. The engine only asks
+// about actions it has already checked are legal.
+type PlayerInput interface {
+	ChooseDiscard(gs *GameState, player *Player) int
+	ChooseRiichi(gs *GameState, player *Player, options []RiichiOption) (int, bool)
+	ChooseChi(gs *GameState, player *Player, discardedTile Tile) (int, []Tile)
+	Confirm(gs *GameState, player *Player, action string, tile Tile, prompt string) bool
+	RoundEnded(gs *GameState)
+}
+
+// ConsoleInput is the terminal PlayerInput, reading answers from gs.InputReader.
+type ConsoleInput struct{}
+
+func (ConsoleInput) ChooseDiscard(gs *GameState, player *Player) int {
+	return GetPlayerDiscardChoice(gs.InputReader, player)
+}
+
+func (ConsoleInput) ChooseRiichi(gs *GameState, player *Player, options []RiichiOption) (int, bool) {
+	return GetPlayerRiichiChoice(gs.InputReader, options)
+}
+
+func (ConsoleInput) ChooseChi(gs *GameState, player *Player, discardedTile Tile) (int, []Tile) {
+	return GetChiChoice(gs, player, discardedTile)
+}
+
+func (ConsoleInput) Confirm(gs *GameState, player *Player, action string, tile Tile, prompt string) bool {
+	return GetPlayerChoice(gs.InputReader, prompt)
+}
+
+// RoundEnded is a no-op on the console; DisplayGameState already shows the result.
+func (ConsoleInput) RoundEnded(gs *GameState) {}
+
+// AutoInput plays the human seat with the same simple choices the AI seats make:
+// discard the drawn tile, take the first Riichi option, accept every win and call
+// except Chi. League games use it to run tables with no one at the keyboard.
+type AutoInput struct{}
+
+func (AutoInput) ChooseDiscard(gs *GameState, player *Player) int {
+	return DefaultDiscardIndex(player)
+}
+
+// ChooseRiichi takes the first option that leaves the hand tenpai (with manual declarations the
+// options are not checked).
+func (AutoInput) ChooseRiichi(gs *GameState, player *Player, options []RiichiOption) (int, bool) {
+	for i, opt := range options {
+		if IsTenpai(removeTileByID(player.Hand, opt.DiscardTile.ID), player.Melds) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (AutoInput) ChooseChi(gs *GameState, player *Player, discardedTile Tile) (int, []Tile) {
+	return 0, nil
+}
+
+// Confirm accepts everything but Open Riichi (the AI seats never open their Riichi) and, with
+// manual declarations, a Ron or Tsumo that would be a chombo.
+func (AutoInput) Confirm(gs *GameState, player *Player, action string, tile Tile, prompt string) bool {
+	switch action {
+	case ActionOpenRiichi:
+		return false
+	case ActionRon, ActionTsumo:
+		return falseWinReason(gs, player, tile, action == ActionTsumo) == ""
+	}
+	return true
+}
+
+func (AutoInput) RoundEnded(gs *GameState) {}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+)
+
+// League is a roster playing a fixed schedule of hanchan. It is saved as JSON after
+// every game, so a league can be stopped and resumed.
+type League struct {
+	Roster   []string      `json:"roster"`
+	Rules    RuleSet       `json:"rules"`
+	Schedule [][]string    `json:"schedule"` // Seating for each hanchan; the first name takes the human seat
+	Results  [][]Placement `json:"results"`  // Placements of each completed hanchan, in schedule order
+}
+
+// LeagueStanding is one roster player's totals across the completed hanchan.
+type LeagueStanding struct {
+	Name      string
+	Games     int
+	Points    int    // Sum of placement points (uma and oka included)
+	Places    [4]int // How many times the player finished 1st to 4th
+	RawPoints int    // Sum of raw end-of-game scores, used to break ties
+}
+
+// NewLeague schedules games hanchan for roster. Each table takes the four players with the
+// fewest games scheduled so far (ties drawn at random), so appearances stay within one of
+// each other, and seats are shuffled.
+func NewLeague(roster []string, games int, rules RuleSet) (*League, error) {
+	if len(roster) < 4 {
+		return nil, fmt.Errorf("a league needs at least 4 players, got %d", len(roster))
+	}
+	seen := make(map[string]bool)
+	for _, name := range roster {
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate player %q in roster", name)
+		}
+		seen[name] = true
+	}
+
+	league := &League{Roster: roster, Rules: rules}
+	appearances := make(map[string]int)
+	for g := 0; g < games; g++ {
+		candidates := make([]string, len(roster))
+		copy(candidates, roster)
+		rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+		sort.SliceStable(candidates, func(i, j int) bool { return appearances[candidates[i]] < appearances[candidates[j]] })
+
+		table := candidates[:4]
+		rand.Shuffle(len(table), func(i, j int) { table[i], table[j] = table[j], table[i] })
+		for _, name := range table {
+			appearances[name]++
+		}
+		league.Schedule = append(league.Schedule, table)
+	}
+	return league, nil
+}
+
+// LoadLeague reads a league saved by Save.
+func LoadLeague(path string) (*League, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	league := &League{Rules: DefaultRuleSet()} // Rules missing from older files keep their defaults
+	if err := json.Unmarshal(data, league); err != nil {
+		return nil, fmt.Errorf("reading league file %s: %w", path, err)
+	}
+	return league, nil
+}
+
+// Save writes the league to path as indented JSON.
+func (l *League) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// NextGame returns the seating of the first hanchan without results, or nil when the schedule is done.
+func (l *League) NextGame() []string {
+	if len(l.Results) >= len(l.Schedule) {
+		return nil
+	}
+	return l.Schedule[len(l.Results)]
+}
+
+// RecordResult stores the placements of the next scheduled hanchan.
+func (l *League) RecordResult(placements []Placement) {
+	l.Results = append(l.Results, placements)
+}
+
+// Standings totals the completed hanchan for every roster player, best first.
+// Ties on points go to the higher raw score total, then to roster order.
+func (l *League) Standings() []LeagueStanding {
+	standings := make([]LeagueStanding, len(l.Roster))
+	index := make(map[string]int)
+	for i, name := range l.Roster {
+		standings[i].Name = name
+		index[name] = i
+	}
+	for _, game := range l.Results {
+		for _, p := range game {
+			i, ok := index[p.Name]
+			if !ok {
+				continue
+			}
+			standings[i].Games++
+			standings[i].Points += p.Points
+			standings[i].RawPoints += p.Score
+			if p.Place >= 1 && p.Place <= 4 {
+				standings[i].Places[p.Place-1]++
+			}
+		}
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		return standings[i].RawPoints > standings[j].RawPoints
+	})
+	return standings
+}
+
+// PrintLeagueStandings prints the league table.
+func PrintLeagueStandings(l *League) {
+	fmt.Printf("\n--- LEAGUE STANDINGS (%d/%d hanchan played) ---\n", len(l.Results), len(l.Schedule))
+	for i, s := range l.Standings() {
+		fmt.Printf("%d. %-20s %8s  games: %d  places (1-2-3-4): %d-%d-%d-%d\n",
+			i+1, s.Name, FormatPlacementPoints(s.Points), s.Games, s.Places[0], s.Places[1], s.Places[2], s.Places[3])
+	}
+}
+
+// PlayLeagueGame plays one hanchan with the given seating and returns its placements, adding
+// the game to the player statistics in statsFile if set. With auto set, the first seat is played
+// by AutoInput instead of the console.
+func PlayLeagueGame(seating []string, rules RuleSet, auto bool, statsFile string) []Placement {
+	gameState := NewGameStateWithRules(seating, rules)
+	if auto {
+		gameState.Input = AutoInput{}
+	}
+	record := PlayRecordedGame(gameState, statsFile)
+	PrintFinalResults(gameState)
+	return record.Placements
+}
+
+// runLeagueCommand creates or resumes a league and plays its remaining hanchan ("league" command).
+func runLeagueCommand(args []string) {
+	flags := flag.NewFlagSet("league", flag.ExitOnError)
+	file := flags.String("file", "league.json", "league file; resumed if it exists")
+	roster := flags.String("roster", "", "comma-separated player names for a new league (at least 4)")
+	games := flags.Int("games", 4, "number of hanchan to schedule for a new league")
+	rulesFile := addRulesFlag(flags)
+	auto := flags.Bool("auto", false, "let the engine play the first seat too (no console input)")
+	standingsOnly := flags.Bool("standings", false, "print the standings and exit")
+	statsFile := flags.String("stats", "", "add each finished game to the statistics in this file")
+	flags.Parse(args)
+
+	league, err := LoadLeague(*file)
+	if errors.Is(err, fs.ErrNotExist) {
+		if *roster == "" {
+			fmt.Printf("No league at %s. Start one with -roster \"A,B,C,D,...\".\n", *file)
+			os.Exit(2)
+		}
+		rules := mustLoadRuleSet(*rulesFile)
+		names := strings.Split(*roster, ",")
+		for i := range names {
+			names[i] = strings.TrimSpace(names[i])
+		}
+		league, err = NewLeague(names, *games, rules)
+		if err == nil {
+			err = league.Save(*file)
+		}
+		if err == nil {
+			fmt.Printf("Scheduled %d hanchan for %d players in %s.\n", len(league.Schedule), len(league.Roster), *file)
+		}
+	} else if err == nil && *roster != "" {
+		fmt.Printf("Resuming the league in %s; -roster and -rules are ignored.\n", *file)
+	}
+	if err != nil {
+		fmt.Println("League error:", err)
+		os.Exit(1)
+	}
+
+	for !*standingsOnly {
+		seating := league.NextGame()
+		if seating == nil {
+			break
+		}
+		fmt.Printf("\n=== Hanchan %d/%d: %s ===\n", len(league.Results)+1, len(league.Schedule), strings.Join(seating, ", "))
+		league.RecordResult(PlayLeagueGame(seating, league.Rules, *auto, *statsFile))
+		if err := league.Save(*file); err != nil {
+			fmt.Println("League error: could not save results:", err)
+			os.Exit(1)
+		}
+	}
+	PrintLeagueStandings(league)
+}
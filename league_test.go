@@ -0,0 +1,112 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFinalPlacements_UmaAndOka(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.Rules.Uma = [4]int{15000, 5000, -5000, -15000}
+	scores := []int{42300, 31700, 18000, 8000}
+	for i, p := range gs.Players {
+		p.Score = scores[i]
+	}
+
+	placements := FinalPlacements(gs)
+	expected := []int{42300 - 30000 + 15000 + 20000, 31700 - 30000 + 5000, 18000 - 30000 - 5000, 8000 - 30000 - 15000}
+	total := 0
+	for i, p := range placements {
+		if p.Place != i+1 || p.Points != expected[i] {
+			t.Errorf("TestFinalPlacements_UmaAndOka: Expected place %d with %d points, got place %d with %d points (%s)", i+1, expected[i], p.Place, p.Points, p.Name)
+		}
+		total += p.Points
+	}
+	if total != 0 {
+		t.Errorf("TestFinalPlacements_UmaAndOka: Expected placement points to sum to 0, got %d", total)
+	}
+	if got := FormatPlacementPoints(placements[0].Points); got != "+47.3" {
+		t.Errorf("TestFinalPlacements_UmaAndOka: Expected first place shown as +47.3, got %s", got)
+	}
+	if got := FormatPlacementPoints(placements[3].Points); got != "-37.0" {
+		t.Errorf("TestFinalPlacements_UmaAndOka: Expected last place shown as -37.0, got %s", got)
+	}
+}
+
+func TestFinalPlacements_TieBrokenBySeat(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.InitialDealerIndex = 2 // P3 was East, P4 South, P1 West, P2 North
+	for _, p := range gs.Players {
+		p.Score = 25000
+	}
+
+	placements := FinalPlacements(gs)
+	expectedOrder := []string{"P3", "P4", "P1", "P2"}
+	for i, p := range placements {
+		if p.Name != expectedOrder[i] {
+			t.Errorf("TestFinalPlacements_TieBrokenBySeat: Expected %s in place %d, got %s", expectedOrder[i], i+1, p.Name)
+		}
+	}
+}
+
+func TestNewLeague_BalancesAppearances(t *testing.T) {
+	roster := []string{"A", "B", "C", "D", "E", "F"}
+	league, err := NewLeague(roster, 9, DefaultRuleSet())
+	if err != nil {
+		t.Fatalf("TestNewLeague_BalancesAppearances: Expected no error, got %v", err)
+	}
+	if len(league.Schedule) != 9 {
+		t.Fatalf("TestNewLeague_BalancesAppearances: Expected 9 hanchan, got %d", len(league.Schedule))
+	}
+	appearances := make(map[string]int)
+	for _, table := range league.Schedule {
+		seated := make(map[string]bool)
+		for _, name := range table {
+			if seated[name] {
+				t.Errorf("TestNewLeague_BalancesAppearances: Expected distinct players at a table, got %v", table)
+			}
+			seated[name] = true
+			appearances[name]++
+		}
+	}
+	for _, name := range roster {
+		if appearances[name] != 6 { // 9 tables * 4 seats / 6 players
+			t.Errorf("TestNewLeague_BalancesAppearances: Expected %s to play 6 hanchan, got %d", name, appearances[name])
+		}
+	}
+
+	if _, err := NewLeague([]string{"A", "B", "C"}, 1, DefaultRuleSet()); err == nil {
+		t.Errorf("TestNewLeague_BalancesAppearances: Expected an error for a 3-player roster")
+	}
+}
+
+func TestLeague_StandingsSurviveSaveAndLoad(t *testing.T) {
+	league, _ := NewLeague([]string{"A", "B", "C", "D"}, 2, DefaultRuleSet())
+	league.RecordResult([]Placement{
+		{Name: "B", Place: 1, Score: 40000, Points: 50000},
+		{Name: "A", Place: 2, Score: 30000, Points: 10000},
+		{Name: "D", Place: 3, Score: 20000, Points: -20000},
+		{Name: "C", Place: 4, Score: 10000, Points: -40000},
+	})
+
+	path := filepath.Join(t.TempDir(), "league.json")
+	if err := league.Save(path); err != nil {
+		t.Fatalf("TestLeague_StandingsSurviveSaveAndLoad: Save failed: %v", err)
+	}
+	loaded, err := LoadLeague(path)
+	if err != nil {
+		t.Fatalf("TestLeague_StandingsSurviveSaveAndLoad: LoadLeague failed: %v", err)
+	}
+	if loaded.NextGame() == nil || len(loaded.Results) != 1 || !reflect.DeepEqual(loaded.Rules, league.Rules) {
+		t.Errorf("TestLeague_StandingsSurviveSaveAndLoad: Expected 1 result, rules and a second game to remain after reload")
+	}
+
+	standings := loaded.Standings()
+	if standings[0].Name != "B" || standings[0].Points != 50000 || standings[0].Places[0] != 1 {
+		t.Errorf("TestLeague_StandingsSurviveSaveAndLoad: Expected B to lead with +50.0 and one win, got %+v", standings[0])
+	}
+	if standings[3].Name != "C" || standings[3].Games != 1 {
+		t.Errorf("TestLeague_StandingsSurviveSaveAndLoad: Expected C last after 1 game, got %+v", standings[3])
+	}
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Local yaku names, as listed in RuleSet.LocalYaku. None of them are part of the
+// standard rules, so each one has to be switched on explicitly.
+const (
+	LocalYakuRenhouMangan       = "Renhou Mangan"        // Renhou scores mangan (5 han, stacking with other yaku) instead of yakuman
+	LocalYakuDaisharin          = "Daisharin"            // Closed 22334455667788 in Pin: yakuman
+	LocalYakuShiisanpuutaa      = "Shiisanpuutaa"        // First draw with one pair and no other useful shape: yakuman
+	LocalYakuSanrenkou          = "Sanrenkou"            // Three triplets of consecutive numbers in one suit: 2 han
+	LocalYakuIishokuSanjun      = "Iishoku Sanjun"       // Three identical sequences: 3 han closed, 2 open
+	LocalYakuUumensai           = "Uumensai"             // Groups and pair from all five tile types: 2 han
+	LocalYakuIshinoUenimoSannen = "Ishino Uenimo Sannen" // Double Riichi won on the last tile: yakuman
+	LocalYakuTsubameGaeshi      = "Tsubame Gaeshi"       // Ron on another player's Riichi declaration tile: 1 han
+	LocalYakuKanburi            = "Kanburi"              // Ron on the discard made right after a Kan: 1 han
+	LocalYakuOpenRiichi         = "Open Riichi"          // Riichi declared with the hand shown: 1 han on top of Riichi
+	LocalYakuOpenRiichiDealIn   = "Open Riichi Deal-in"  // Ron on an Open Riichi by a player who is not in Riichi: yakuman
+	LocalYakuPaarenchan         = "Paarenchan"           // A dealer's eighth consecutive win: yakuman
+)
+
+// AllLocalYaku lists every supported local yaku.
+var AllLocalYaku = []string{
+	LocalYakuRenhouMangan, LocalYakuDaisharin, LocalYakuShiisanpuutaa, LocalYakuSanrenkou,
+	LocalYakuIishokuSanjun, LocalYakuUumensai, LocalYakuIshinoUenimoSannen, LocalYakuTsubameGaeshi,
+	LocalYakuKanburi, LocalYakuOpenRiichi, LocalYakuOpenRiichiDealIn, LocalYakuPaarenchan,
+}
+
+// localYakuDefinitions registers the local yaku with the yaku registry. Each one is only
+// evaluated when its name is listed in RuleSet.LocalYaku.
+func localYakuDefinitions() []YakuDefinition {
+	return []YakuDefinition{
+		// == LOCAL YAKUMAN ==
+		{Name: LocalYakuDaisharin, Value: yakumanClosed, Yakuman: 1, Category: YakuCategoryYakuman, LocalYaku: LocalYakuDaisharin,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkDaisharin(c.IsMenzen, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: LocalYakuShiisanpuutaa, Value: yakumanClosed, Yakuman: 1, Category: YakuCategoryYakuman, LocalYaku: LocalYakuShiisanpuutaa,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkShiisanpuutaa(c.Player, c.GS, c.IsTsumo, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: LocalYakuIshinoUenimoSannen, Value: yakumanClosed, Yakuman: 1, Category: YakuCategoryYakuman, LocalYaku: LocalYakuIshinoUenimoSannen,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkIshinoUenimoSannen(c.Player, c.GS, c.IsTsumo)
+				return matchIf(ok)
+			}},
+		{Name: LocalYakuPaarenchan, Value: yakuman, Yakuman: 1, Category: YakuCategoryYakuman, LocalYaku: LocalYakuPaarenchan,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkPaarenchan(c.Player, c.GS); return matchIf(ok) }},
+		{Name: LocalYakuOpenRiichiDealIn, Value: yakumanClosed, Yakuman: 1, Category: YakuCategoryYakuman, LocalYaku: LocalYakuOpenRiichiDealIn,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkOpenRiichiDealIn(c.Player, c.GS, c.IsTsumo)
+				return matchIf(ok)
+			}},
+
+		// == LOCAL YAKU ==
+		{Name: LocalYakuIishokuSanjun, Value: YakuValue{Closed: 3, Open: 2}, Category: YakuCategoryRegular, LocalYaku: LocalYakuIishokuSanjun,
+			Supersedes: []string{"Iipeikou", LocalYakuSanrenkou}, // Sanrenkou: the same tiles, counted once
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkIishokuSanjun(c.Player, c.IsMenzen, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: LocalYakuSanrenkou, Value: twoHan, Category: YakuCategoryRegular, LocalYaku: LocalYakuSanrenkou,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkSanrenkou(c.Player, c.AllTiles); return matchIf(ok) }},
+		{Name: LocalYakuUumensai, Value: twoHan, Category: YakuCategoryRegular, LocalYaku: LocalYakuUumensai,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkUumensai(c.Player, c.AllTiles); return matchIf(ok) }},
+		{Name: LocalYakuTsubameGaeshi, Value: oneHan, Category: YakuCategoryRegular, LocalYaku: LocalYakuTsubameGaeshi,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkTsubameGaeshi(c.Player, c.GS, c.IsTsumo)
+				return matchIf(ok)
+			}},
+		{Name: LocalYakuKanburi, Value: oneHan, Category: YakuCategoryRegular, LocalYaku: LocalYakuKanburi,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkKanburi(c.GS, c.IsTsumo); return matchIf(ok) }},
+		{Name: LocalYakuOpenRiichi, Value: oneHanClosed, Category: YakuCategoryRegular, LocalYaku: LocalYakuOpenRiichi,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkOpenRiichi(c.Player); return matchIf(ok) }},
+		// Renhou valued as mangan is scored with the regular yaku, and switches off the yakuman.
+		{Name: LocalYakuRenhouMangan, Value: YakuValue{Closed: 5}, Category: YakuCategoryRegular, LocalYaku: LocalYakuRenhouMangan,
+			Replaces: []string{"Renhou"},
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, name, _ := checkRenhou(c.Player, c.GS, c.IsTsumo)
+				if ok && !c.IsTsumo {
+					return []YakuMatch{{Name: name, Count: 1}}
+				}
+				return nil
+			}},
+	}
+}
+
+// == LOCAL YAKUMAN ==
+func checkDaisharin(isMenzen bool, allTiles []Tile) (bool, string, int) {
+	if !isMenzen || len(allTiles) != 14 {
+		return false, "", 0
+	}
+	counts := make(map[int]int)
+	for _, tile := range allTiles {
+		if tile.Suit != "Pin" || tile.Value < 2 || tile.Value > 8 {
+			return false, "", 0
+		}
+		counts[tile.Value]++
+	}
+	for value := 2; value <= 8; value++ {
+		if counts[value] != 2 {
+			return false, "", 0
+		}
+	}
+	return true, "Daisharin", 13
+}
+
+// checkShiisanpuutaa accepts a first-draw Tsumo that is not a complete hand: exactly one
+// pair, and every other tile unable to form a sequence or triplet shape with the rest.
+func checkShiisanpuutaa(player *Player, gs *GameState, isTsumo bool, allTiles []Tile) (bool, string, int) {
+	if !isTsumo || player.HasMadeFirstDiscardThisRound || gs.AnyCallMadeThisRound || len(player.Melds) > 0 || len(allTiles) != 14 {
+		return false, "", 0
+	}
+	if IsCompleteHand(allTiles, nil) || IsChiitoitsu(allTiles) || IsKokushiMusou(allTiles) {
+		return false, "", 0
+	}
+	counts := make(map[string]int)
+	for _, tile := range allTiles {
+		counts[fmt.Sprintf("%s-%d", tile.Suit, tile.Value)]++
+	}
+	pairs := 0
+	for _, c := range counts {
+		if c > 2 {
+			return false, "", 0
+		}
+		if c == 2 {
+			pairs++
+		}
+	}
+	if pairs != 1 {
+		return false, "", 0
+	}
+	for _, tile := range allTiles {
+		if IsHonor(tile) {
+			continue
+		}
+		for delta := 1; delta <= 2; delta++ { // Neighbours within two make a ryanmen, penchan or kanchan shape
+			if counts[fmt.Sprintf("%s-%d", tile.Suit, tile.Value+delta)] > 0 {
+				return false, "", 0
+			}
+		}
+	}
+	return true, "Shiisanpuutaa", 13
+}
+
+func checkIshinoUenimoSannen(player *Player, gs *GameState, isTsumo bool) (bool, string, int) {
+	if !player.IsRiichi || !player.DeclaredDoubleRiichi {
+		return false, "", 0
+	}
+	if ok, _, _ := checkHaiteiHoutei(gs, isTsumo); ok {
+		return true, "Ishino Uenimo Sannen", 13
+	}
+	return false, "", 0
+}
+
+// checkPaarenchan: gs.DealerWinStreak counts the dealer's wins before this one.
+func checkPaarenchan(player *Player, gs *GameState) (bool, string, int) {
+	if gs.Players[gs.DealerIndexThisRound] == player && gs.DealerWinStreak >= 7 {
+		return true, "Paarenchan", 13
+	}
+	return false, "", 0
+}
+
+// == LOCAL YAKU ==
+func checkSanrenkou(player *Player, allTiles []Tile) (bool, string, int) {
+	decomp, success := DecomposeWinningHand(player, allTiles)
+	if !success || decomp == nil {
+		return false, "", 0
+	}
+	if hasConsecutiveTriplets(decomp, false) {
+		return true, "Sanrenkou", 2
+	}
+	return false, "", 0
+}
+
+// checkIishokuSanjun also accepts three consecutive concealed triplets in one suit (e.g.
+// 111222333), which are the same tiles as three identical sequences. Called Pons and quads cannot
+// be read as sequences, so they stay Sanrenkou.
+func checkIishokuSanjun(player *Player, isMenzen bool, allTiles []Tile) (bool, string, int) {
+	decomp, success := DecomposeWinningHand(player, allTiles)
+	if !success || decomp == nil {
+		return false, "", 0
+	}
+	han := 2
+	if isMenzen {
+		han = 3
+	}
+	sequences := []DecomposedGroup{}
+	for _, grp := range decomp {
+		if grp.Type == TypeSequence {
+			sequences = append(sequences, grp)
+		}
+	}
+	for i := 0; i < len(sequences); i++ {
+		identical := 1
+		for j := i + 1; j < len(sequences); j++ {
+			if sequencesAreEqual(sequences[i].Tiles, sequences[j].Tiles) {
+				identical++
+			}
+		}
+		if identical >= 3 {
+			return true, "Iishoku Sanjun", han
+		}
+	}
+	if hasConsecutiveTriplets(decomp, true) {
+		return true, "Iishoku Sanjun", han
+	}
+	return false, "", 0
+}
+
+// hasConsecutiveTriplets reports whether the decomposition has triplets or quads of three
+// consecutive numbers in the same suit. With concealedOnly, only triplets in the hand count.
+func hasConsecutiveTriplets(decomp []DecomposedGroup, concealedOnly bool) bool {
+	valuesBySuit := make(map[string][]int)
+	for _, grp := range decomp {
+		if concealedOnly && (grp.Type != TypeTriplet || !grp.IsConcealed) {
+			continue
+		}
+		if (grp.Type == TypeTriplet || grp.Type == TypeQuad) && len(grp.Tiles) > 0 && !IsHonor(grp.Tiles[0]) {
+			valuesBySuit[grp.Tiles[0].Suit] = append(valuesBySuit[grp.Tiles[0].Suit], grp.Tiles[0].Value)
+		}
+	}
+	for _, values := range valuesBySuit {
+		sort.Ints(values)
+		for i := 0; i+2 < len(values); i++ {
+			if values[i+1] == values[i]+1 && values[i+2] == values[i]+2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func checkUumensai(player *Player, allTiles []Tile) (bool, string, int) {
+	decomp, success := DecomposeWinningHand(player, allTiles)
+	if !success || decomp == nil {
+		return false, "", 0
+	}
+	suits := make(map[string]bool)
+	for _, grp := range decomp {
+		if len(grp.Tiles) == 0 {
+			return false, "", 0
+		}
+		suits[grp.Tiles[0].Suit] = true
+	}
+	if len(suits) == 5 { // Man, Pin, Sou, Wind and Dragon, one component each
+		return true, "Uumensai", 2
+	}
+	return false, "", 0
+}
+
+// checkTsubameGaeshi: during a Ron check gs.CurrentPlayerIndex is the discarder, and a
+// Riichi declaration tile was discarded on the turn stored in RiichiTurn.
+func checkTsubameGaeshi(player *Player, gs *GameState, isTsumo bool) (bool, string, int) {
+	if isTsumo || gs.IsChankanOpportunity {
+		return false, "", 0
+	}
+	discarder := gs.Players[gs.CurrentPlayerIndex]
+	if discarder != player && discarder.IsRiichi && discarder.RiichiTurn == gs.TurnNumber-1 {
+		return true, "Tsubame Gaeshi", 1
+	}
+	return false, "", 0
+}
+
+func checkKanburi(gs *GameState, isTsumo bool) (bool, string, int) {
+	if !isTsumo && gs.IsKanburiDiscard {
+		return true, "Kanburi", 1
+	}
+	return false, "", 0
+}
+
+func checkOpenRiichi(player *Player) (bool, string, int) {
+	if player.IsRiichi && player.IsOpenRiichi {
+		return true, "Open Riichi", 1
+	}
+	return false, "", 0
+}
+
+// checkOpenRiichiDealIn pays yakuman when a player not in Riichi discards into an Open Riichi:
+// they could see the hand. A discarder who is declaring Riichi on that tile counts as in Riichi.
+func checkOpenRiichiDealIn(player *Player, gs *GameState, isTsumo bool) (bool, string, int) {
+	if isTsumo || !player.IsRiichi || !player.IsOpenRiichi {
+		return false, "", 0
+	}
+	discarder := gs.Players[gs.CurrentPlayerIndex]
+	if discarder != player && !discarder.IsRiichi {
+		return true, "Open Riichi Deal-in", 13
+	}
+	return false, "", 0
+}
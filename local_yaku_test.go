@@ -0,0 +1,242 @@
+package main
+
+import (
+	"testing"
+)
+
+// setupLocalYakuHand is setupTestHandAndGameState with the given local yaku enabled, past the
+// first go-around so luck-based yakuman stay out of the way.
+func setupLocalYakuHand(t *testing.T, handStr string, agariTileStr string, isTsumo bool, localYaku ...string) (*Player, Tile, []Tile, *GameState) {
+	player, agariHai, allTiles, gs := setupTestHandAndGameState(t, handStr, nil, agariTileStr, isTsumo)
+	player.HasMadeFirstDiscardThisRound = true
+	gs.IsFirstGoAround = false
+	gs.Rules.LocalYaku = localYaku
+	return player, agariHai, allTiles, gs
+}
+
+func hasYaku(results []YakuResult, name string, han int) bool {
+	for _, r := range results {
+		if r.Name == name && r.Han == han {
+			return true
+		}
+	}
+	return false
+}
+
+func TestIdentifyYaku_RenhouMangan(t *testing.T) {
+	player, agariHai, _, gs := setupLocalYakuHand(t, "1m 2m 3m 4p 5p 6p 7s 8s 9s 2m 3m 4m E", "E", false, LocalYakuRenhouMangan)
+	player.HasMadeFirstDiscardThisRound = false
+	gs.IsFirstGoAround = true
+	gs.DealerIndexThisRound = 1
+	gs.CurrentPlayerIndex = 1
+
+	results, han := IdentifyYaku(player, agariHai, false, gs)
+	if !hasYaku(results, "Renhou", 5) || han < 5 || han >= 13 {
+		t.Errorf("TestIdentifyYaku_RenhouMangan: Expected Renhou valued at 5 Han (not Yakuman), got %v (%d Han)", results, han)
+	}
+}
+
+func TestCheckDaisharin_Valid(t *testing.T) {
+	player, agariHai, allTiles, gs := setupLocalYakuHand(t, "2p 2p 3p 3p 4p 4p 5p 5p 6p 6p 7p 7p 8p", "8p", false, LocalYakuDaisharin)
+	ok, name, han := checkDaisharin(true, allTiles)
+	if !ok || name != "Daisharin" || han != 13 {
+		t.Errorf("TestCheckDaisharin_Valid: Expected Daisharin (13 Han), got name:'%s' han:%d ok:%v", name, han, ok)
+	}
+	if results, _ := IdentifyYaku(player, agariHai, false, gs); !hasYaku(results, "Daisharin", 13) {
+		t.Errorf("TestCheckDaisharin_Valid: Expected IdentifyYaku to award Daisharin when enabled, got %v", results)
+	}
+	gs.Rules.LocalYaku = nil
+	if results, _ := IdentifyYaku(player, agariHai, false, gs); hasYaku(results, "Daisharin", 13) {
+		t.Errorf("TestCheckDaisharin_Valid: Expected no Daisharin when the local yaku is off, got %v", results)
+	}
+}
+
+func TestCheckShiisanpuutaa_FirstDraw(t *testing.T) {
+	player, _, allTiles, gs := setupLocalYakuHand(t, "1m 4m 7m 1p 4p 7p 1s 4s 7s E S W N", "N", true, LocalYakuShiisanpuutaa)
+	player.HasMadeFirstDiscardThisRound = false
+	gs.AnyCallMadeThisRound = false
+
+	ok, name, han := checkShiisanpuutaa(player, gs, true, allTiles)
+	if !ok || name != "Shiisanpuutaa" || han != 13 {
+		t.Errorf("TestCheckShiisanpuutaa_FirstDraw: Expected Shiisanpuutaa (13 Han), got name:'%s' han:%d ok:%v", name, han, ok)
+	}
+	drawn := player.Hand[len(player.Hand)-1]
+	player.JustDrawnTile = &drawn
+	if !CanDeclareTsumo(player, gs) {
+		t.Errorf("TestCheckShiisanpuutaa_FirstDraw: Expected Tsumo to be allowed on an incomplete Shiisanpuutaa hand")
+	}
+	gs.Rules.LocalYaku = nil
+	if CanDeclareTsumo(player, gs) {
+		t.Errorf("TestCheckShiisanpuutaa_FirstDraw: Expected no Tsumo on an incomplete hand when Shiisanpuutaa is off")
+	}
+
+	player.HasMadeFirstDiscardThisRound = true
+	if ok, _, _ := checkShiisanpuutaa(player, gs, true, allTiles); ok {
+		t.Errorf("TestCheckShiisanpuutaa_FirstDraw: Expected no Shiisanpuutaa after the first discard")
+	}
+}
+
+func TestCheckShiisanpuutaa_Invalid_HasKanchanShape(t *testing.T) {
+	player, _, allTiles, gs := setupLocalYakuHand(t, "1m 3m 7m 1p 4p 7p 1s 4s 7s E S W N", "N", true)
+	player.HasMadeFirstDiscardThisRound = false
+	if ok, _, _ := checkShiisanpuutaa(player, gs, true, allTiles); ok {
+		t.Errorf("TestCheckShiisanpuutaa_Invalid_HasKanchanShape: Expected no Shiisanpuutaa with a 1m-3m shape")
+	}
+}
+
+func TestCheckSanrenkou_Valid(t *testing.T) {
+	player, agariHai, allTiles, gs := setupLocalYakuHand(t, "2m 2m 2m 3m 3m 3m 4m 4m 4m 6p 7p 8p E", "E", false, LocalYakuSanrenkou)
+	ok, name, han := checkSanrenkou(player, allTiles)
+	if !ok || name != "Sanrenkou" || han != 2 {
+		t.Errorf("TestCheckSanrenkou_Valid: Expected Sanrenkou (2 Han), got name:'%s' han:%d ok:%v", name, han, ok)
+	}
+	if results, _ := IdentifyYaku(player, agariHai, false, gs); !hasYaku(results, "Sanrenkou", 2) {
+		t.Errorf("TestCheckSanrenkou_Valid: Expected IdentifyYaku to award Sanrenkou when enabled, got %v", results)
+	}
+	gs.Rules.LocalYaku = []string{LocalYakuSanrenkou, LocalYakuIishokuSanjun}
+	if results, _ := IdentifyYaku(player, agariHai, false, gs); hasYaku(results, "Sanrenkou", 2) || !hasYaku(results, "Iishoku Sanjun", 3) {
+		t.Errorf("TestCheckSanrenkou_Valid: Expected only Iishoku Sanjun when both count the same tiles, got %v", results)
+	}
+	gs.Rules.LocalYaku = nil
+	if results, _ := IdentifyYaku(player, agariHai, false, gs); hasYaku(results, "Sanrenkou", 2) {
+		t.Errorf("TestCheckSanrenkou_Valid: Expected no Sanrenkou when the local yaku is off, got %v", results)
+	}
+}
+
+func TestCheckSanrenkou_OpenPonsAreNotIishokuSanjun(t *testing.T) {
+	player, agariHai, _, gs := setupLocalYakuHand(t, "2m 2m 2m 3m 3m 3m 4m 4m 4m 6p 7p 8p E", "E", false, LocalYakuSanrenkou, LocalYakuIishokuSanjun)
+	player.Hand = player.Hand[9:]
+	for i, pon := range []string{"2m 2m 2m", "3m 3m 3m", "4m 4m 4m"} {
+		tiles := TilesFromString(pon)
+		for j := range tiles {
+			tiles[j].ID = 110 + 3*i + j
+		}
+		player.Melds = append(player.Melds, Meld{Type: "Pon", Tiles: tiles, CalledOn: tiles[0], FromPlayer: 1})
+	}
+	allTiles := getAllTilesInHand(player, agariHai, false)
+	if ok, _, _ := checkIishokuSanjun(player, false, allTiles); ok {
+		t.Errorf("TestCheckSanrenkou_OpenPonsAreNotIishokuSanjun: Expected called Pons not to be read as three identical sequences")
+	}
+	if results, _ := IdentifyYaku(player, agariHai, false, gs); !hasYaku(results, "Sanrenkou", 2) || hasYaku(results, "Iishoku Sanjun", 2) {
+		t.Errorf("TestCheckSanrenkou_OpenPonsAreNotIishokuSanjun: Expected Sanrenkou for open Pons, got %v", results)
+	}
+}
+
+func TestCheckIishokuSanjun_Valid(t *testing.T) {
+	player, agariHai, allTiles, gs := setupLocalYakuHand(t, "1s 2s 3s 1s 2s 3s 1s 2s 3s 5m 5m 7p 8p", "9p", false, LocalYakuIishokuSanjun)
+	ok, name, han := checkIishokuSanjun(player, true, allTiles)
+	if !ok || name != "Iishoku Sanjun" || han != 3 {
+		t.Errorf("TestCheckIishokuSanjun_Valid: Expected Iishoku Sanjun (3 Han closed), got name:'%s' han:%d ok:%v", name, han, ok)
+	}
+	if _, _, han := checkIishokuSanjun(player, false, allTiles); han != 2 {
+		t.Errorf("TestCheckIishokuSanjun_Valid: Expected 2 Han when open, got %d", han)
+	}
+	if results, _ := IdentifyYaku(player, agariHai, false, gs); !hasYaku(results, "Iishoku Sanjun", 3) || hasYaku(results, "Iipeikou", 1) {
+		t.Errorf("TestCheckIishokuSanjun_Valid: Expected Iishoku Sanjun without Iipeikou, got %v", results)
+	}
+}
+
+func TestCheckUumensai_Valid(t *testing.T) {
+	player, _, allTiles, _ := setupLocalYakuHand(t, "1m 2m 3m 4p 5p 6p 7s 8s 9s E E E r", "r", false, LocalYakuUumensai)
+	ok, name, han := checkUumensai(player, allTiles)
+	if !ok || name != "Uumensai" || han != 2 {
+		t.Errorf("TestCheckUumensai_Valid: Expected Uumensai (2 Han), got name:'%s' han:%d ok:%v. AllTiles: %s", name, han, ok, TilesToNames(allTiles))
+	}
+}
+
+func TestCheckUumensai_Invalid_NoDragon(t *testing.T) {
+	player, _, allTiles, _ := setupLocalYakuHand(t, "1m 2m 3m 4p 5p 6p 7s 8s 9s E E E S", "S", false, LocalYakuUumensai)
+	if ok, _, _ := checkUumensai(player, allTiles); ok {
+		t.Errorf("TestCheckUumensai_Invalid_NoDragon: Expected no Uumensai without a dragon component")
+	}
+}
+
+func TestCheckIshinoUenimoSannen_Valid(t *testing.T) {
+	player, _, _, gs := setupLocalYakuHand(t, "1m 2m 3m 4p 5p 6p 7s 8s 9s 2m 3m 4m E", "E", true, LocalYakuIshinoUenimoSannen)
+	player.IsRiichi = true
+	player.DeclaredDoubleRiichi = true
+	gs.Wall = []Tile{} // Haitei
+	ok, name, han := checkIshinoUenimoSannen(player, gs, true)
+	if !ok || name != "Ishino Uenimo Sannen" || han != 13 {
+		t.Errorf("TestCheckIshinoUenimoSannen_Valid: Expected Ishino Uenimo Sannen (13 Han), got name:'%s' han:%d ok:%v", name, han, ok)
+	}
+	player.DeclaredDoubleRiichi = false
+	if ok, _, _ := checkIshinoUenimoSannen(player, gs, true); ok {
+		t.Errorf("TestCheckIshinoUenimoSannen_Valid: Expected no yakuman for a plain Riichi on Haitei")
+	}
+}
+
+func TestCheckTsubameGaeshi_Valid(t *testing.T) {
+	player, _, _, gs := setupLocalYakuHand(t, "1m 2m 3m 4p 5p 6p 7s 8s 9s 2m 3m 4m E", "E", false, LocalYakuTsubameGaeshi)
+	riichiDiscarder := gs.Players[2]
+	riichiDiscarder.IsRiichi = true
+	riichiDiscarder.RiichiTurn = gs.TurnNumber - 1 // DiscardTile has already advanced TurnNumber
+	gs.CurrentPlayerIndex = 2
+
+	ok, name, han := checkTsubameGaeshi(player, gs, false)
+	if !ok || name != "Tsubame Gaeshi" || han != 1 {
+		t.Errorf("TestCheckTsubameGaeshi_Valid: Expected Tsubame Gaeshi (1 Han), got name:'%s' han:%d ok:%v", name, han, ok)
+	}
+	riichiDiscarder.RiichiTurn = gs.TurnNumber - 5 // Riichi declared earlier
+	if ok, _, _ := checkTsubameGaeshi(player, gs, false); ok {
+		t.Errorf("TestCheckTsubameGaeshi_Valid: Expected no Tsubame Gaeshi on a later discard of a Riichi player")
+	}
+}
+
+func TestCheckKanburi_Valid(t *testing.T) {
+	_, _, _, gs := setupLocalYakuHand(t, "1m 2m 3m 4p 5p 6p 7s 8s 9s 2m 3m 4m E", "E", false, LocalYakuKanburi)
+	gs.IsKanburiDiscard = true
+	ok, name, han := checkKanburi(gs, false)
+	if !ok || name != "Kanburi" || han != 1 {
+		t.Errorf("TestCheckKanburi_Valid: Expected Kanburi (1 Han), got name:'%s' han:%d ok:%v", name, han, ok)
+	}
+	if ok, _, _ := checkKanburi(gs, true); ok {
+		t.Errorf("TestCheckKanburi_Valid: Expected no Kanburi on Tsumo")
+	}
+}
+
+func TestCheckOpenRiichi_Valid(t *testing.T) {
+	player, agariHai, _, gs := setupLocalYakuHand(t, "1m 2m 3m 4p 5p 6p 7s 8s 9s 2m 3m 4m E", "E", false, LocalYakuOpenRiichi)
+	player.IsRiichi = true
+	player.IsOpenRiichi = true
+	ok, name, han := checkOpenRiichi(player)
+	if !ok || name != "Open Riichi" || han != 1 {
+		t.Errorf("TestCheckOpenRiichi_Valid: Expected Open Riichi (1 Han), got name:'%s' han:%d ok:%v", name, han, ok)
+	}
+	if results, _ := IdentifyYaku(player, agariHai, false, gs); !hasYaku(results, "Riichi", 1) || !hasYaku(results, "Open Riichi", 1) {
+		t.Errorf("TestCheckOpenRiichi_Valid: Expected Riichi and Open Riichi, got %v", results)
+	}
+	if view := NewPlayerView(gs, 1); len(view.Players[0].OpenHand) != len(player.Hand) {
+		t.Errorf("TestCheckOpenRiichi_Valid: Expected the open hand to be visible to other seats, got %v", view.Players[0].OpenHand)
+	}
+}
+
+func TestCheckPaarenchan_Valid(t *testing.T) {
+	player, _, _, gs := setupLocalYakuHand(t, "1m 2m 3m 4p 5p 6p 7s 8s 9s 2m 3m 4m E", "E", false, LocalYakuPaarenchan)
+	gs.DealerIndexThisRound = 0
+	gs.DealerWinStreak = 7
+	ok, name, han := checkPaarenchan(player, gs)
+	if !ok || name != "Paarenchan" || han != 13 {
+		t.Errorf("TestCheckPaarenchan_Valid: Expected Paarenchan (13 Han), got name:'%s' han:%d ok:%v", name, han, ok)
+	}
+	gs.DealerWinStreak = 6
+	if ok, _, _ := checkPaarenchan(player, gs); ok {
+		t.Errorf("TestCheckPaarenchan_Valid: Expected no Paarenchan on the dealer's seventh win")
+	}
+}
+
+func TestRuleSetValidate_LocalYaku(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.LocalYaku = []string{LocalYakuDaisharin, LocalYakuKanburi}
+	if err := rules.Validate(); err != nil {
+		t.Errorf("TestRuleSetValidate_LocalYaku: Expected Daisharin and Kanburi to be accepted, got %v", err)
+	}
+	rules.LocalYaku = AllLocalYaku
+	if err := rules.Validate(); err != nil {
+		t.Errorf("TestRuleSetValidate_LocalYaku: Expected every local yaku to be accepted, got %v", err)
+	}
+	rules.LocalYaku = []string{LocalYakuDaisharin, "Nope"}
+	if err := rules.Validate(); err == nil {
+		t.Errorf("TestRuleSetValidate_LocalYaku: Expected an error for an unknown yaku")
+	}
+}
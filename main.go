@@ -1,27 +1,84 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math/rand"
 	"os"
 	"sort"
-
-	// "strings" // Not directly used in this version of main.go
+	"strings"
 	"time"
 )
 
 // Constants are now in types.go
 
+// defaultPlayerNames seats the human at index 0 against three AI players.
+var defaultPlayerNames = []string{"Player 1 (You)", "Player 2 (AI)", "Player 3 (AI)", "Player 4 (AI)"}
+
 func main() {
 	rand.Seed(time.Now().UnixNano()) // Seed random number generator once
-	fmt.Println("Starting Riichi Mahjong Game")
-	gsLog := []string{} // Game-level log, if needed beyond gs.GameLog
 
-	playerNames := []string{"Player 1 (You)", "Player 2 (AI)", "Player 3 (AI)", "Player 4 (AI)"}
-	gameState := NewGameState(playerNames) // NewGameState sets PhaseDealing, PrevalentWind, etc.
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		switch os.Args[1] {
+		case "gui":
+			runGUICommand(os.Args[2:])
+			return
+		case "serve":
+			runServeCommand(os.Args[2:])
+			return
+		case "league":
+			runLeagueCommand(os.Args[2:])
+			return
+		case "train":
+			runTrainCommand(os.Args[2:])
+			return
+		case "quiz":
+			runQuizCommand(os.Args[2:])
+			return
+		case "review":
+			runReviewCommand(os.Args[2:])
+			return
+		case "stats":
+			runStatsCommand(os.Args[2:])
+			return
+		case "estimate":
+			runEstimateCommand(os.Args[2:])
+			return
+		default:
+			fmt.Printf("Unknown command %q. Usage: mahjong-go [-rules file] [-stats file] or mahjong-go [gui|serve|league|train|quiz|review|stats|estimate]\n", os.Args[1])
+			os.Exit(2)
+		}
+	}
+
+	runConsoleCommand(os.Args[1:])
+	os.Exit(0)
+}
+
+// runConsoleCommand plays one game in the terminal (no command, or only flags), saving the
+// human's decisions for the review command.
+func runConsoleCommand(args []string) {
+	flags := flag.NewFlagSet("mahjong-go", flag.ExitOnError)
+	rulesFile := addRulesFlag(flags)
+	statsFile := flags.String("stats", "", "add the finished game to the statistics in this file")
+	flags.Parse(args)
+	rules := mustLoadRuleSet(*rulesFile)
 
+	fmt.Println("Starting Riichi Mahjong Game")
+	gameState := NewGameStateWithRules(defaultPlayerNames, rules) // Sets PhaseDealing, PrevalentWind, etc.
+	record := PlayRecordedGame(gameState, *statsFile)
+	PrintFinalResults(gameState)
+	if err := record.Save(DefaultRecordFile); err != nil {
+		fmt.Println("Error saving game record:", err)
+	} else {
+		fmt.Printf("Game record saved to %s; run \"mahjong-go review\" to go over your decisions.\n", DefaultRecordFile)
+	}
+}
+
+// RunGame plays rounds until the game ends. Decisions for the human seat (index 0)
+// go through gameState.Input, so the console, GUI and web clients share this loop.
+func RunGame(gameState *GameState) {
 	// Main Game Loop - continues as long as the game is not over
-	for gameState.GamePhase != PhaseGameEnd {
+	for gameState.GamePhase != PhaseGameEnd && !gameState.Stopped {
 
 		// --- New Round Setup ---
 		if gameState.GamePhase == PhaseDealing {
@@ -36,7 +93,7 @@ func main() {
 
 		// --- Player Turn Loop (Inner loop for a single round's turns) ---
 		// This loop runs as long as it's a player's turn and the round/game hasn't ended.
-		for gameState.GamePhase == PhasePlayerTurn {
+		for gameState.GamePhase == PhasePlayerTurn && !gameState.Stopped {
 			currentPlayer := gameState.Players[gameState.CurrentPlayerIndex]
 			isHumanPlayer := gameState.CurrentPlayerIndex == 0
 
@@ -58,14 +115,14 @@ func main() {
 
 			// --- Kyuushuu Kyuuhai Check (before draw) ---
 			// Conditions: Player's first turn of the round, no calls made by anyone yet in the round.
-			if !currentPlayer.HasDrawnFirstTileThisRound && !gameState.AnyCallMadeThisRound &&
+			if gameState.Rules.DrawEnabled(DrawKyuushuuKyuuhai) && !currentPlayer.HasDrawnFirstTileThisRound && !gameState.AnyCallMadeThisRound &&
 				gameState.TurnNumber < len(gameState.Players) { // Ensures it's within the first cycle of turns
 
 				if CheckKyuushuuKyuuhai(currentPlayer.Hand, currentPlayer.Melds) { // Pass melds to ensure no open melds
 					kyuushuuDeclared := false
 					if isHumanPlayer {
 						fmt.Println("Your hand qualifies for Kyuushuu Kyuuhai (9+ unique terminal/honor tiles).")
-						if GetPlayerChoice(gameState.InputReader, "Declare Kyuushuu Kyuuhai for an abortive draw? (y/n): ") {
+						if gameState.Input.Confirm(gameState, currentPlayer, ActionKyuushuu, Tile{}, "Declare Kyuushuu Kyuuhai for an abortive draw? (y/n): ") {
 							kyuushuuDeclared = true
 						}
 					} else { // AI always declares
@@ -78,7 +135,8 @@ func main() {
 						// fmt.Printf("%s declares Kyuushuu Kyuuhai! Round ends in an abortive draw.\n", currentPlayer.Name)
 						gameState.GamePhase = PhaseRoundEnd
 						gameState.RoundWinner = nil // Mark as draw
-						// Honba usually increments for abortive draws. This is handled in Round End processing.
+						gameState.DrawType = DrawKyuushuuKyuuhai
+						// Honba and the deal follow gameState.Rules.DrawRule. This is handled in Round End processing.
 						break // Exit player turn loop, proceed to Round End processing
 					}
 				}
@@ -115,16 +173,21 @@ func main() {
 			// --- Action Phase (Tsumo, Kan on Draw) ---
 			actionTakenThisSegment := false
 
-			if CanDeclareTsumo(currentPlayer, gameState) {
+			handBeforeDraw := removeTileByID(currentPlayer.Hand, drawnTile.ID)
+			if CanDeclareTsumo(currentPlayer, gameState) || ManualDeclarationOffered(gameState, currentPlayer, handBeforeDraw) {
 				tsumoConfirm := !isHumanPlayer // AI default: Tsumo if possible
 				if isHumanPlayer {
 					DisplayPlayerState(currentPlayer) // Show hand before Tsumo choice
-					tsumoConfirm = GetPlayerChoice(gameState.InputReader, "Declare TSUMO? (y/n): ")
+					tsumoConfirm = gameState.Input.Confirm(gameState, currentPlayer, ActionTsumo, drawnTile, "Declare TSUMO? (y/n): ")
 				}
 				if tsumoConfirm {
 					// gameState.AddToGameLog(fmt.Sprintf("%s declares TSUMO!", currentPlayer.Name))
 					// fmt.Printf("%s declares TSUMO!\n", currentPlayer.Name)
-					HandleWin(gameState, currentPlayer, drawnTile, true) // Sets GamePhase to RoundEnd
+					if reason := falseWinReason(gameState, currentPlayer, drawnTile, true); reason != "" {
+						HandleChombo(gameState, currentPlayer, reason) // Manual declarations only
+					} else {
+						HandleWin(gameState, currentPlayer, drawnTile, true) // Sets GamePhase to RoundEnd
+					}
 					actionTakenThisSegment = true
 				}
 			}
@@ -139,7 +202,7 @@ func main() {
 					kanConfirm := !isHumanPlayer // AI decision for Kan
 					if isHumanPlayer {
 						DisplayPlayerState(currentPlayer) // Show hand before Kan choice
-						kanConfirm = GetPlayerChoice(gameState.InputReader, fmt.Sprintf("Declare %s with %s? (y/n): ", possibleKanType, kanTargetTile.Name))
+						kanConfirm = gameState.Input.Confirm(gameState, currentPlayer, possibleKanType, kanTargetTile, fmt.Sprintf("Declare %s with %s? (y/n): ", possibleKanType, kanTargetTile.Name))
 					} else { // AI Kan logic
 						// AI: Kan if not Riichi, or if Riichi and waits don't change
 						if !currentPlayer.IsRiichi || (currentPlayer.IsRiichi && !checkWaitChangeForRiichiKan(currentPlayer, gameState, kanTargetTile, possibleKanType)) {
@@ -177,24 +240,29 @@ func main() {
 				if isHumanPlayer {
 					DisplayPlayerState(currentPlayer) // Show hand before any discard choice
 					if canRiichi {
-						chosenOptionIndex, choiceMade := GetPlayerRiichiChoice(gameState.InputReader, riichiOptions)
+						chosenOptionIndex, choiceMade := gameState.Input.ChooseRiichi(gameState, currentPlayer, riichiOptions)
 						if choiceMade {
 							selectedOption := riichiOptions[chosenOptionIndex]
 							discardIndex = selectedOption.DiscardIndex // This is index in the 14-tile hand
+							if gameState.Rules.LocalYakuEnabled(LocalYakuOpenRiichi) {
+								openPrompt := fmt.Sprintf("%s, declare OPEN Riichi (show your hand for 1 extra han)? (y/n): ", currentPlayer.Name)
+								currentPlayer.IsOpenRiichi = gameState.Input.Confirm(gameState, currentPlayer, ActionOpenRiichi, Tile{}, openPrompt)
+							}
 							if HandleRiichiAction(gameState, currentPlayer, discardIndex) {
 								riichiDeclaredSuccessfully = true // Riichi and discard happened
 							} else { // Riichi validation failed (e.g., chosen discard wrong)
+								currentPlayer.IsOpenRiichi = false
 								gameState.AddToGameLog("Riichi declaration failed internal validation. Proceeding with normal discard.")
 								// fmt.Println("Riichi declaration failed validation. Proceeding with normal discard.")
-								discardIndex = GetPlayerDiscardChoice(gameState.InputReader, currentPlayer)
+								discardIndex = gameState.Input.ChooseDiscard(gameState, currentPlayer)
 							}
 						} else { // Player cancelled Riichi choice
 							gameState.AddToGameLog(fmt.Sprintf("%s cancelled Riichi. Proceeding with normal discard.", currentPlayer.Name))
 							// fmt.Println("Proceeding with normal discard.")
-							discardIndex = GetPlayerDiscardChoice(gameState.InputReader, currentPlayer)
+							discardIndex = gameState.Input.ChooseDiscard(gameState, currentPlayer)
 						}
 					} else { // Cannot Riichi, just get normal discard
-						discardIndex = GetPlayerDiscardChoice(gameState.InputReader, currentPlayer)
+						discardIndex = gameState.Input.ChooseDiscard(gameState, currentPlayer)
 					}
 				} else { // AI Logic for discard
 					// gameState.AddToGameLog(fmt.Sprintf("AI %s thinking for discard...", currentPlayer.Name))
@@ -290,7 +358,7 @@ func main() {
 
 			// --- Post-Discard Checks (Abortive Draws, Ryuukyoku) ---
 			// Check for Suu Riichi completion (abort if 4th Riichi discard not Ronned)
-			if CheckSuuRiichi(gameState) {
+			if gameState.Rules.DrawEnabled(DrawSuuchaRiichi) && CheckSuuRiichi(gameState) {
 				// Abort only if the discard *just made* by the 4th Riichi player was not Ronned.
 				// DiscardTile would have set GamePhase to RoundEnd if Ron occurred.
 				if gameState.GamePhase == PhasePlayerTurn {
@@ -298,6 +366,7 @@ func main() {
 					// fmt.Println("Suu Riichi! Round aborts as 4th Riichi player's discard was not Ronned.")
 					gameState.GamePhase = PhaseRoundEnd
 					gameState.RoundWinner = nil
+					gameState.DrawType = DrawSuuchaRiichi
 					break
 				}
 			}
@@ -332,82 +401,54 @@ func main() {
 				gameState.PrevalentWind, gameState.RoundNumber, gameState.DealerRoundCount, gameState.CurrentWindRoundNumber))
 			// fmt.Println("\n--- Round End Processing ---")
 
-			nagashiWinner := (*Player)(nil)
-			if gameState.RoundWinner == nil { // Exhaustive draw or abortive draw
-				for _, p := range gameState.Players {
-					if isNagashi, nagashiName, _ := checkNagashiMangan(p, gameState); isNagashi {
-						gameState.AddToGameLog(fmt.Sprintf("!!! %s achieves %s !!!", p.Name, nagashiName))
-						// fmt.Printf("!!! %s achieves %s! (Scoring to be refined) !!!\n", p.Name, nagashiName)
-						nagashiWinner = p
-						// Simulate Mangan Tsumo for Nagashi winner.
-						isWinnerDealer := (gameState.Players[gameState.DealerIndexThisRound] == p)
-						// Create a dummy winning tile for payment calculation, Yaku calc will be overridden.
-						dummyAgari := Tile{Suit: "Special", Value: 1, Name: "Nagashi"}
-						payment := CalculatePointPayment(5, 30, isWinnerDealer, true, gameState.Honba, gameState.RiichiSticks) // Mangan
-
-						// Pao logic for Nagashi is not standard. Direct transfer:
-						nagashiTotalPayment := 0
-						if isWinnerDealer {
-							nagashiTotalPayment = payment.TsumoNonDealerPay * (len(gameState.Players) - 1)
-						} else {
-							nagashiTotalPayment = payment.TsumoDealerPay + payment.TsumoNonDealerPay*(len(gameState.Players)-2)
-						}
-						// Transfer from others to Nagashi winner
-						for _, otherP := range gameState.Players {
-							if otherP == p {
-								continue
-							}
-							var amountToPay int
-							if isWinnerDealer {
-								amountToPay = payment.TsumoNonDealerPay
-							} else {
-								if gameState.Players[gameState.DealerIndexThisRound] == otherP {
-									amountToPay = payment.TsumoDealerPay
-								} else {
-									amountToPay = payment.TsumoNonDealerPay
-								}
-							}
-							otherP.Score -= amountToPay
-							gameState.AddToGameLog(fmt.Sprintf("%s pays %d to %s for Nagashi Mangan.", otherP.Name, amountToPay, p.Name))
-						}
-						p.Score += nagashiTotalPayment
-						p.Score += gameState.RiichiSticks * RiichiBet // Nagashi winner gets Riichi sticks
-						gameState.RiichiSticks = 0
-
-						gameState.RoundWinner = p // Nagashi Mangan is a form of win.
-						break                     // Only one Nagashi Mangan.
-					}
-				}
+			if gameState.RoundWinner == nil && gameState.DrawType == "" {
+				gameState.DrawType = DrawExhaustive // Wall exhausted (or an error ended the round)
+			}
+			if gameState.DrawType == DrawExhaustive && gameState.Rules.ManualDeclarations {
+				CheckNotenRiichi(gameState) // A Riichi shown noten is a chombo: DrawType becomes DrawChombo
+			}
+			if gameState.DrawType == DrawExhaustive {
+				HandleNagashiMangan(gameState) // Nagashi as a win sets RoundWinner; as a draw, DrawType
 			}
 
 			// Tenpai/Notenpai for Ryuukyoku (if no winner from Nagashi etc.)
-			if gameState.RoundWinner == nil { // Still a draw after Nagashi check (or no Nagashi)
+			if gameState.RoundWinner == nil && gameState.DrawType != DrawChombo { // Still a draw after Nagashi check (or no Nagashi)
 				for _, p := range gameState.Players {
 					p.IsTenpai = IsTenpai(p.Hand, p.Melds)
-					gameState.AddToGameLog(fmt.Sprintf("%s is %s at Ryuukyoku.", p.Name, If(p.IsTenpai, "Tenpai", "Noten")))
+					gameState.AddToGameLog(fmt.Sprintf("%s is %s at %s.", p.Name, If(p.IsTenpai, "Tenpai", "Noten"), gameState.DrawType))
+				}
+				if gameState.DrawType == DrawExhaustive {
+					HandleNotenBappu(gameState) // Handles point transfers for Noten Bappu
 				}
-				HandleNotenBappu(gameState) // Handles point transfers for Noten Bappu
-				if isHumanPlayer || true {
-					DisplayGameState(gameState)
-				} // Show Tenpai statuses and score changes
+				DisplayGameState(gameState) // Show Tenpai statuses and score changes
 			}
 
+			gameState.Input.RoundEnded(gameState) // Lets graphical clients show the hand result
+
 			// --- Game End Conditions Check ---
 			gameShouldActuallyEnd := false
 			for _, p := range gameState.Players {
-				if p.Score < 0 {
+				if gameState.Rules.BustEndsGame && IsBusted(gameState, p) {
 					gameState.AddToGameLog(fmt.Sprintf("Player %s has busted (score: %d)! Game Over.", p.Name, p.Score))
 					// fmt.Printf("Player %s has a negative score (%d). Game Over!\n", p.Name, p.Score)
 					gameShouldActuallyEnd = true
 					break
 				}
 			}
-			// Hanchan End Logic
-			if !gameShouldActuallyEnd {
+			// Hanchan End Logic (a chombo redeals the same round, so only a bust ends the game after one)
+			redeal := gameState.DrawType == DrawChombo
+			if !gameShouldActuallyEnd && !redeal && gameState.CurrentWindRoundNumber > gameState.MaxWindRounds {
+				gameShouldActuallyEnd = ExtensionRoundEnds(gameState) // West round (or later) sudden death
+			} else if !gameShouldActuallyEnd && !redeal {
 				// Yame Conditions Check
 				isLastProgrammedTurn := gameState.CurrentWindRoundNumber >= gameState.MaxWindRounds && gameState.RoundNumber >= 4
+				if isLastProgrammedTurn && ShouldExtendGame(gameState) {
+					gameState.AddToGameLog(fmt.Sprintf("Nobody has reached %d points. The game continues into the %s round.",
+						gameState.Rules.TargetScore, PrevalentWindForRound(gameState.CurrentWindRoundNumber+1)))
+					isLastProgrammedTurn = false
+				}
 				dealerPlayer := gameState.Players[gameState.DealerIndexThisRound]
-				dealerWinsOrTenpaiAtDraw := (gameState.RoundWinner == dealerPlayer) || (gameState.RoundWinner == nil && dealerPlayer.IsTenpai)
+				dealerWinsOrTenpaiAtDraw, _ := RenchanAfterRound(gameState) // Dealer win, or a draw that keeps the deal
 
 				if isLastProgrammedTurn && dealerWinsOrTenpaiAtDraw {
 					isDealerTopScorer := true
@@ -419,11 +460,10 @@ func main() {
 					}
 
 					if isDealerTopScorer {
-						canChooseYame := true // Condition met for Yame choice
 						choseYame := false
 						if gameState.GetPlayerIndex(dealerPlayer) == 0 { // Human dealer
-							fmt.Printf("%s, you are top and won/Tenpai in the final programmed round. Declare Agari/Tenpai Yame to end the game? (y/n): ", dealerPlayer.Name)
-							if GetPlayerChoice(gameState.InputReader, "") { // Empty prompt string as Printf already displayed it
+							yamePrompt := fmt.Sprintf("%s, you are top and won/Tenpai in the final programmed round. Declare Agari/Tenpai Yame to end the game? (y/n): ", dealerPlayer.Name)
+							if gameState.Input.Confirm(gameState, dealerPlayer, ActionYame, Tile{}, yamePrompt) {
 								choseYame = true
 							}
 						} else { // AI dealer
@@ -456,50 +496,41 @@ func main() {
 			} else { // Prepare for Next Round
 				gameState.AddToGameLog("Preparing for next round setup...")
 				currentRoundDealerPlayer := gameState.Players[gameState.DealerIndexThisRound] // Dealer of the round that just ended
-				isDealerWin := gameState.RoundWinner == currentRoundDealerPlayer
-				isDealerTenpaiAtDraw := (gameState.RoundWinner == nil && currentRoundDealerPlayer.IsTenpai)
+				dealerKeepsDeal, nextHonba := RenchanAfterRound(gameState)
 
 				// Renchan Logic for Honba & Dealer Position
-				if isDealerWin || isDealerTenpaiAtDraw { // Dealer Renchan
+				if IsDealerWin(gameState) {
+					gameState.DealerWinStreak++ // Counts toward Paarenchan
+				}
+				gameState.Honba = nextHonba
+				if gameState.DrawType == DrawChombo { // Same dealer, same Honba, a fresh deal
+					gameState.AddToGameLog(fmt.Sprintf("The round is redealt after the chombo. Dealer %s, Honba %d.",
+						currentRoundDealerPlayer.Name, gameState.Honba))
+				} else if dealerKeepsDeal { // Dealer Renchan
 					// If Yame was possible but declined, this Renchan logic might be skipped if gameShouldActuallyEnd was set.
 					// However, the structure implies if gameShouldActuallyEnd is false here, it means it's not the absolute end.
-					gameState.Honba++
 					// DealerIndexThisRound remains the same.
 					gameState.DealerRoundCount++ // This dealer's consecutive rounds as dealer.
 					gameState.AddToGameLog(fmt.Sprintf("Dealer %s retained (Renchan). Honba to %d. Dealer's %d round as dealer.",
 						currentRoundDealerPlayer.Name, gameState.Honba, gameState.DealerRoundCount))
 				} else { // Dealer changes
-					// If dealer is Noten at Ryuukyoku, Honba still increments even if dealership passes.
-					if gameState.RoundWinner == nil && !currentRoundDealerPlayer.IsTenpai {
-						gameState.Honba++
-						gameState.AddToGameLog(fmt.Sprintf("Dealer %s Noten at Ryuukyoku. Honba to %d. Dealership passes.",
-							currentRoundDealerPlayer.Name, gameState.Honba))
+					if gameState.RoundWinner == nil { // e.g. dealer Noten at Ryuukyoku
+						gameState.AddToGameLog(fmt.Sprintf("Dealer %s loses the deal after %s. Honba to %d. Dealership passes.",
+							currentRoundDealerPlayer.Name, gameState.DrawType, gameState.Honba))
 					} else { // Non-dealer win
-						gameState.Honba = 0 // Reset Honba
 						gameState.AddToGameLog("Non-dealer win. Honba reset.")
 					}
 
 					gameState.DealerIndexThisRound = (gameState.DealerIndexThisRound + 1) % len(gameState.Players)
 					gameState.DealerRoundCount = 1 // New dealer starts their 1st round count.
+					gameState.DealerWinStreak = 0
 
 					// Advance Round Number (within the current Prevalent Wind)
 					gameState.RoundNumber++ // This is the round number for the current Prevalent Wind (e.g., East 1, East 2 ...)
 					if gameState.RoundNumber > 4 {
 						gameState.RoundNumber = 1          // Reset to 1 for the new Prevalent Wind
-						gameState.CurrentWindRoundNumber++ // This tracks which wind it is (1=E, 2=S)
-						switch gameState.PrevalentWind {
-						case "East":
-							gameState.PrevalentWind = "South"
-						case "South":
-							gameState.PrevalentWind = "West" // If MaxWindRounds allows
-						case "West":
-							gameState.PrevalentWind = "North" // If MaxWindRounds allows
-						case "North": // Game usually ends or loops based on complex rules
-							if gameState.MaxWindRounds > 4 {
-								gameState.PrevalentWind = "East"
-							} else { /* Game should have ended */
-							}
-						}
+						gameState.CurrentWindRoundNumber++ // This tracks which wind it is (1=E, 2=S, 3=W)
+						gameState.PrevalentWind = PrevalentWindForRound(gameState.CurrentWindRoundNumber)
 						gameState.AddToGameLog(fmt.Sprintf("Prevalent Wind advances to %s.", gameState.PrevalentWind))
 					}
 					gameState.AddToGameLog(fmt.Sprintf("Dealer changes to %s. Wind Round: %s. Dealer turn in wind: %d. Their dealer streak: %d.",
@@ -517,15 +548,18 @@ func main() {
 				// Reset round-specific player flags
 				for _, p := range gameState.Players {
 					p.Hand = []Tile{}
-					p.Discards = []Tile{}
+					p.Discards = []Discard{}
 					p.Melds = []Meld{}
 					p.IsRiichi = false
+					p.IsOpenRiichi = false
+					p.IsRiichiPending = false
 					p.RiichiTurn = -1
 					p.IsIppatsu = false
 					p.DeclaredDoubleRiichi = false
 					p.HasMadeFirstDiscardThisRound = false
 					p.HasDrawnFirstTileThisRound = false
 					p.JustDrawnTile = nil
+					p.KuikaeForbidden = nil
 					p.IsFuriten = false
 					p.IsPermanentRiichiFuriten = false
 					p.DeclinedRonOnTurn = -1
@@ -533,6 +567,7 @@ func main() {
 					p.RiichiDeclaredWaits = []Tile{}
 					p.IsTenpai = false
 					p.PaoSourcePlayerIndex = -1
+					p.PaoYakuman = ""
 					p.PaoTargetFor = nil
 					p.HasHadDiscardCalledThisRound = false
 				}
@@ -544,16 +579,37 @@ func main() {
 				gameState.TurnNumber = 0 // Reset turn counter for the new round (discards in round)
 				gameState.DeclaredRiichiPlayerIndices = make(map[int]bool)
 				gameState.TotalKansDeclaredThisRound = 0
-				gameState.FirstTurnDiscardCount = 0
-				gameState.FirstTurnDiscards = [4]Tile{} // Reset for Ssuufon Renda
-				gs.SanchahouRonners = []*Player{}
 				gameState.RoundWinner = nil
+				gameState.RoundWinners = nil
+				gameState.LastWinResult = nil
+				gameState.WinResults = nil
+				gameState.DrawType = ""
 
 				gameState.setupNewRoundDeck()      // Sets up Wall, DeadWall, initial Dora
 				gameState.GamePhase = PhaseDealing // Ready for next round's deal
 			}
 		} // End Round End Processing
 	} // End Main Game Loop
+}
+
+// FinalStandings returns the players ordered by final score, highest first. Ties go to the
+// player seated earlier in the first round (East before South, and so on).
+func FinalStandings(gameState *GameState) []*Player {
+	finalScores := make([]*Player, len(gameState.Players))
+	copy(finalScores, gameState.Players)
+	sort.SliceStable(finalScores, func(i, j int) bool {
+		if finalScores[i].Score != finalScores[j].Score {
+			return finalScores[i].Score > finalScores[j].Score
+		}
+		// Equal scores: the player seated closer to the first dealer (East 1) ranks higher.
+		return initialSeatOrder(gameState, finalScores[i]) < initialSeatOrder(gameState, finalScores[j])
+	})
+	return finalScores
+}
+
+// PrintFinalResults prints the final scores and the full game log once the game has ended.
+func PrintFinalResults(gameState *GameState) {
+	gsLog := []string{} // Game-level log, if needed beyond gs.GameLog
 
 	// --- Final Game Outcome ---
 	if gameState.GamePhase == PhaseGameEnd {
@@ -562,15 +618,15 @@ func main() {
 		DisplayGameState(gameState)                         // Show final state with scores
 
 		// Sort players by score for final display
-		finalScores := make([]*Player, len(gameState.Players))
-		copy(finalScores, gameState.Players)
-		sort.Slice(finalScores, func(i, j int) bool {
-			return finalScores[i].Score > finalScores[j].Score
-		})
+		finalScores := FinalStandings(gameState)
 		fmt.Println("Final Scores:")
 		gsLog = append(gsLog, "Final Scores:")
+		placements := FinalPlacements(gameState)
 		for i, p := range finalScores {
-			scoreStr := fmt.Sprintf("%d. %s: %d points", i+1, p.Name, p.Score)
+			scoreStr := fmt.Sprintf("%d. %s: %d points (%s)", i+1, p.Name, p.Score, FormatPlacementPoints(placements[i].Points))
+			if p.Chombos > 0 {
+				scoreStr += fmt.Sprintf(", %d chombo", p.Chombos)
+			}
 			fmt.Println(scoreStr)
 			gsLog = append(gsLog, scoreStr)
 		}
@@ -582,7 +638,5 @@ func main() {
 		for _, entry := range gameState.GameLog {
 			fmt.Println(entry)
 		}
-		// Exit or offer new game
-		os.Exit(0)
 	}
 }
@@ -0,0 +1,150 @@
+package main
+
+import "fmt"
+
+// Pao (sekinin barai): a player whose discard lets another player complete the open sets of
+// Daisangen or Daisuushii (and, with RuleSet.SuukantsuPao, the fourth Kan) is liable for that
+// yakuman. With RuleSet.RinshanPao, the discarder of a Daiminkan tile is liable for a Rinshan
+// Kaihou won on the replacement draw.
+
+// PaoPayment is the part of a win a liable player pays (see TransferPoints).
+type PaoPayment struct {
+	PlayerIndex int     // The liable player
+	Reason      string  // "Daisangen", "Daisuushii", "Suukantsu" or "Rinshan Kaihou"
+	RonValue    int     // The liable portion valued as a Ron, without Honba
+	Rest        Payment // Tsumo only: what the rest of the hand (other yakuman) pays as a normal Tsumo, without Honba
+}
+
+// UpdatePaoOnCall is called after an open Pon or Daiminkan from sourceIndex has been added as
+// the player's last meld. If that call completed a pao yakuman, sourceIndex becomes liable.
+func UpdatePaoOnCall(gs *GameState, player *Player, sourceIndex int) {
+	if sourceIndex < 0 || sourceIndex >= len(gs.Players) || gs.Players[sourceIndex] == player || player.PaoSourcePlayerIndex != -1 {
+		return
+	}
+	yakuman := paoYakumanCompletedByLastMeld(player, gs.Rules)
+	if yakuman == "" {
+		return
+	}
+	player.PaoSourcePlayerIndex = sourceIndex
+	player.PaoYakuman = yakuman
+	gs.Players[sourceIndex].PaoTargetFor = player
+	gs.AddToGameLog(fmt.Sprintf("PAO Triggered: %s is liable for %s's %s.", gs.Players[sourceIndex].Name, player.Name, yakuman))
+}
+
+// paoYakumanCompletedByLastMeld returns the pao yakuman whose last set is the player's newest meld.
+func paoYakumanCompletedByLastMeld(player *Player, rules RuleSet) string {
+	if len(player.Melds) == 0 {
+		return ""
+	}
+	dragonSets, windSets, kans := 0, 0, 0
+	for _, meld := range player.Melds {
+		if IsKanMeld(meld) {
+			kans++
+		}
+		if meld.Type == "Chi" || len(meld.Tiles) == 0 {
+			continue
+		}
+		switch meld.Tiles[0].Suit {
+		case "Dragon":
+			dragonSets++
+		case "Wind":
+			windSets++
+		}
+	}
+	last := player.Melds[len(player.Melds)-1]
+	if last.Type != "Chi" && len(last.Tiles) > 0 {
+		if last.Tiles[0].Suit == "Dragon" && dragonSets == 3 {
+			return "Daisangen"
+		}
+		if last.Tiles[0].Suit == "Wind" && windSets == 4 {
+			return "Daisuushii"
+		}
+	}
+	if rules.SuukantsuPao && last.Type == "Daiminkan" && kans == 4 {
+		return "Suukantsu"
+	}
+	return ""
+}
+
+// DeterminePao returns who is liable for which part of a win, or nil. Yakuman pao covers only
+// the liable yakuman; any other yakuman in the hand is paid normally. Rinshan pao covers the
+// whole hand.
+func DeterminePao(gs *GameState, winner *Player, yaku []YakuResult, han, fu int, isTsumo bool) *PaoPayment {
+	isDealer := gs.Players[gs.DealerIndexThisRound] == winner
+	if winner.PaoSourcePlayerIndex != -1 {
+		for _, y := range yaku {
+			if y.Name != winner.PaoYakuman || y.Yakuman == 0 {
+				continue
+			}
+			total := YakumanMultiple(yaku)
+			if gs.Rules.YakumanCap > 0 && total > gs.Rules.YakumanCap {
+				total = gs.Rules.YakumanCap
+			}
+			liable := y.Yakuman
+			if liable > total {
+				liable = total
+			}
+			pao := &PaoPayment{
+				PlayerIndex: winner.PaoSourcePlayerIndex,
+				Reason:      y.Name,
+				RonValue:    paymentFromBasePoints(8000.0*float64(liable), "", isDealer, false, 0).RonValue,
+			}
+			if isTsumo && total > liable {
+				pao.Rest = paymentFromBasePoints(8000.0*float64(total-liable), "", isDealer, true, 0)
+			}
+			return pao
+		}
+	}
+	if gs.Rules.RinshanPao && isTsumo && gs.IsRinshanWin && gs.RinshanPaoSourceIndex >= 0 && gs.Players[gs.RinshanPaoSourceIndex] != winner {
+		return &PaoPayment{
+			PlayerIndex: gs.RinshanPaoSourceIndex,
+			Reason:      "Rinshan Kaihou",
+			RonValue:    CalculateWinPayment(yaku, han, fu, gs.Rules, isDealer, false, 0).RonValue,
+		}
+	}
+	return nil
+}
+
+// transferPaoPoints pays a win that someone is liable for. On Tsumo the liable player pays the
+// liable portion as if it were a Ron, plus all Honba; the rest of the hand is a normal Tsumo.
+// On Ron by someone else's discard the liable portion is split half each with the discarder,
+// who also pays the rest and the Honba; if the liable player dealt in, they pay everything.
+func transferPaoPoints(gs *GameState, winner, discarder *Player, isTsumo bool, payment Payment) {
+	pao := payment.Pao
+	liable := gs.Players[pao.PlayerIndex]
+	owed := make(map[*Player]int)
+	if isTsumo {
+		owed[liable] += pao.RonValue + payment.Honba*300
+		for _, p := range gs.Players {
+			if p == winner {
+				continue
+			}
+			if gs.Players[gs.DealerIndexThisRound] == p {
+				owed[p] += pao.Rest.TsumoDealerPay
+			} else {
+				owed[p] += pao.Rest.TsumoNonDealerPay
+			}
+		}
+	} else if discarder == nil {
+		gs.AddToGameLog(fmt.Sprintf("Error: Discarder was nil during Ron point transfer to %s.", winner.Name))
+		return
+	} else if discarder == liable {
+		owed[discarder] += payment.RonValue
+	} else {
+		half := pao.RonValue / 2
+		owed[liable] += half
+		owed[discarder] += payment.RonValue - half
+	}
+
+	gs.AddToGameLog(fmt.Sprintf("PAO: %s is liable for %s's %s.", liable.Name, winner.Name, pao.Reason))
+	for i, p := range gs.Players { // In seat order, so the log and bust checks are deterministic
+		amount, ok := owed[p]
+		if !ok || amount == 0 {
+			continue
+		}
+		gs.AddToGameLog(fmt.Sprintf("%s (P%d) pays %d to %s (P%d).", p.Name, i+1, amount, winner.Name, gs.GetPlayerIndex(winner)+1))
+		p.Score -= amount
+		winner.Score += amount
+		CheckAndHandleBust(gs, p, winner)
+	}
+}
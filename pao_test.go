@@ -0,0 +1,147 @@
+package main
+
+import "testing"
+
+// setupPaoWin returns a game where P2 (non-dealer) wins with P4 liable for the given yakuman.
+func setupPaoWin(yakuman string) (*GameState, *Player, *Player) {
+	gs := createTestGameState(nil)
+	gs.GamePhase = PhasePlayerTurn
+	winner, liable := gs.Players[1], gs.Players[3]
+	winner.PaoSourcePlayerIndex = 3
+	winner.PaoYakuman = yakuman
+	return gs, winner, liable
+}
+
+func payPaoWin(gs *GameState, winner, discarder *Player, yaku []YakuResult, isTsumo bool) Payment {
+	han := 0
+	for _, y := range yaku {
+		han += y.Han
+	}
+	payment := CalculateWinPayment(yaku, han, 0, gs.Rules, false, isTsumo, gs.Honba)
+	payment.Pao = DeterminePao(gs, winner, yaku, han, 0, isTsumo)
+	TransferPoints(gs, winner, discarder, isTsumo, payment)
+	return payment
+}
+
+func TestTransferPoints_PaoTsumo(t *testing.T) {
+	gs, winner, liable := setupPaoWin("Daisangen")
+	gs.Honba = 2
+	gs.RiichiSticks = 1
+
+	payPaoWin(gs, winner, nil, []YakuResult{{Name: "Daisangen", Han: 13, Yakuman: 1}}, true)
+
+	if liable.Score != InitialScore-32000-600 {
+		t.Errorf("TestTransferPoints_PaoTsumo: Expected liable player to pay the Ron value plus Honba (%d), got %d", InitialScore-32600, liable.Score)
+	}
+	if winner.Score != InitialScore+32600+RiichiBet {
+		t.Errorf("TestTransferPoints_PaoTsumo: Expected winner score %d, got %d", InitialScore+32600+RiichiBet, winner.Score)
+	}
+	if gs.Players[0].Score != InitialScore || gs.Players[2].Score != InitialScore {
+		t.Errorf("TestTransferPoints_PaoTsumo: Expected other players to pay nothing, got %d and %d", gs.Players[0].Score, gs.Players[2].Score)
+	}
+}
+
+func TestTransferPoints_PaoRonSplit(t *testing.T) {
+	gs, winner, liable := setupPaoWin("Daisuushii")
+	gs.Honba = 1
+	discarder := gs.Players[2]
+
+	payPaoWin(gs, winner, discarder, []YakuResult{{Name: "Daisuushii", Han: 26, Yakuman: 2}}, false)
+
+	if liable.Score != InitialScore-32000 {
+		t.Errorf("TestTransferPoints_PaoRonSplit: Expected liable player to pay half (32000), got %d", InitialScore-liable.Score)
+	}
+	if discarder.Score != InitialScore-32000-300 {
+		t.Errorf("TestTransferPoints_PaoRonSplit: Expected discarder to pay half plus Honba (32300), got %d", InitialScore-discarder.Score)
+	}
+	if winner.Score != InitialScore+64300 {
+		t.Errorf("TestTransferPoints_PaoRonSplit: Expected winner score %d, got %d", InitialScore+64300, winner.Score)
+	}
+}
+
+func TestTransferPoints_PaoRonByLiablePlayer(t *testing.T) {
+	gs, winner, liable := setupPaoWin("Daisangen")
+	gs.Honba = 1
+
+	payPaoWin(gs, winner, liable, []YakuResult{{Name: "Daisangen", Han: 13, Yakuman: 1}}, false)
+
+	if liable.Score != InitialScore-32300 {
+		t.Errorf("TestTransferPoints_PaoRonByLiablePlayer: Expected liable discarder to pay everything (32300), got %d", InitialScore-liable.Score)
+	}
+	if winner.Score != InitialScore+32300 {
+		t.Errorf("TestTransferPoints_PaoRonByLiablePlayer: Expected winner score %d, got %d", InitialScore+32300, winner.Score)
+	}
+}
+
+func TestTransferPoints_PaoOnlyCoversLiableYakuman(t *testing.T) {
+	gs, winner, liable := setupPaoWin("Daisangen")
+	gs.Honba = 1
+	yaku := []YakuResult{{Name: "Daisangen", Han: 13, Yakuman: 1}, {Name: "Tsuuiisou", Han: 13, Yakuman: 1}}
+
+	payment := payPaoWin(gs, winner, nil, yaku, true)
+
+	if payment.Pao == nil || payment.Pao.RonValue != 32000 {
+		t.Fatalf("TestTransferPoints_PaoOnlyCoversLiableYakuman: Expected pao on a single yakuman (32000), got %+v", payment.Pao)
+	}
+	// Liable: 32000 for Daisangen + 300 Honba + 8000 as a non-dealer for the Tsuuiisou Tsumo.
+	if liable.Score != InitialScore-40300 {
+		t.Errorf("TestTransferPoints_PaoOnlyCoversLiableYakuman: Expected liable player to pay 40300, got %d", InitialScore-liable.Score)
+	}
+	if gs.Players[0].Score != InitialScore-16000 || gs.Players[2].Score != InitialScore-8000 {
+		t.Errorf("TestTransferPoints_PaoOnlyCoversLiableYakuman: Expected dealer to pay 16000 and non-dealer 8000, got %d and %d",
+			InitialScore-gs.Players[0].Score, InitialScore-gs.Players[2].Score)
+	}
+	if winner.Score != InitialScore+64300 {
+		t.Errorf("TestTransferPoints_PaoOnlyCoversLiableYakuman: Expected winner score %d, got %d", InitialScore+64300, winner.Score)
+	}
+}
+
+func TestUpdatePaoOnCall(t *testing.T) {
+	gs := createTestGameState(nil)
+	player := gs.Players[0]
+	for _, set := range []string{"w w w", "g g g", "r r r"} {
+		tiles := TilesFromString(set)
+		player.Melds = append(player.Melds, Meld{Type: "Pon", Tiles: tiles, CalledOn: tiles[0], FromPlayer: 2})
+		UpdatePaoOnCall(gs, player, 2)
+	}
+	if player.PaoSourcePlayerIndex != 2 || player.PaoYakuman != "Daisangen" || gs.Players[2].PaoTargetFor != player {
+		t.Errorf("TestUpdatePaoOnCall: Expected P3 liable for Daisangen, got P%d for %q", player.PaoSourcePlayerIndex+1, player.PaoYakuman)
+	}
+
+	player = gs.Players[1]
+	kans := []string{"1m 1m 1m 1m", "2p 2p 2p 2p", "3s 3s 3s 3s", "9m 9m 9m 9m"}
+	for i, set := range kans {
+		tiles := TilesFromString(set)
+		meldType := "Ankan"
+		if i == len(kans)-1 {
+			meldType = "Daiminkan"
+		}
+		player.Melds = append(player.Melds, Meld{Type: meldType, Tiles: tiles, CalledOn: tiles[0], FromPlayer: 3})
+	}
+	UpdatePaoOnCall(gs, player, 3)
+	if player.PaoSourcePlayerIndex != -1 {
+		t.Errorf("TestUpdatePaoOnCall: Expected no Suukantsu pao by default, got P%d", player.PaoSourcePlayerIndex+1)
+	}
+	gs.Rules.SuukantsuPao = true
+	UpdatePaoOnCall(gs, player, 3)
+	if player.PaoSourcePlayerIndex != 3 || player.PaoYakuman != "Suukantsu" {
+		t.Errorf("TestUpdatePaoOnCall: Expected P4 liable for Suukantsu with SuukantsuPao, got P%d for %q", player.PaoSourcePlayerIndex+1, player.PaoYakuman)
+	}
+}
+
+func TestDeterminePao_Rinshan(t *testing.T) {
+	gs := createTestGameState(nil)
+	winner := gs.Players[1]
+	gs.IsRinshanWin = true
+	gs.RinshanPaoSourceIndex = 2
+	yaku := []YakuResult{{Name: "Rinshan Kaihou", Han: 1}, {Name: "Tanyao", Han: 1}}
+
+	if pao := DeterminePao(gs, winner, yaku, 2, 40, true); pao != nil {
+		t.Errorf("TestDeterminePao_Rinshan: Expected no Rinshan pao by default, got %+v", pao)
+	}
+	gs.Rules.RinshanPao = true
+	pao := DeterminePao(gs, winner, yaku, 2, 40, true)
+	if pao == nil || pao.PlayerIndex != 2 || pao.RonValue != 2600 {
+		t.Errorf("TestDeterminePao_Rinshan: Expected P3 to pay the hand as a Ron (2600), got %+v", pao)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+// PlayerView is the table as one seat is allowed to see it: every public detail,
+// but only that seat's own concealed hand. Front ends and bots read this instead
+// of the full GameState.
+type PlayerView struct {
+	Seat           int        `json:"seat"`
+	PrevalentWind  string     `json:"prevalentWind"`
+	RoundNumber    int        `json:"roundNumber"`
+	Honba          int        `json:"honba"`
+	RiichiSticks   int        `json:"riichiSticks"`
+	WallTiles      int        `json:"wallTiles"`
+	DoraIndicators []string   `json:"doraIndicators"`
+	Players        []SeatView `json:"players"`
+	Hand           []string   `json:"hand"`
+	DrawnTile      string     `json:"drawnTile,omitempty"`
+	Waits          string     `json:"waits,omitempty"` // The seat's wait when its hand is tenpai (see AnalyzeWaits)
+}
+
+// SeatView is the public part of one player's state.
+type SeatView struct {
+	Name      string        `json:"name"`
+	SeatWind  string        `json:"seatWind"`
+	Score     int           `json:"score"`
+	IsRiichi  bool          `json:"isRiichi"`
+	IsDealer  bool          `json:"isDealer"`
+	IsCurrent bool          `json:"isCurrent"`
+	Melds     string        `json:"melds"`
+	Discards  []string      `json:"discards"`           // Called tiles stay in the river, marked "(called)"
+	River     []DiscardView `json:"river"`              // The same discards with how each was made
+	Signals   []string      `json:"signals,omitempty"`  // Tenpai signals read from the river (see TenpaiSignals)
+	OpenHand  []string      `json:"openHand,omitempty"` // Concealed tiles shown by an Open Riichi
+}
+
+// DiscardView is one discard in a river: tsumogiri (the drawn tile) or tedashi (from the hand).
+type DiscardView struct {
+	Tile       string `json:"tile"`
+	Turn       int    `json:"turn"`
+	Tsumogiri  bool   `json:"tsumogiri"`
+	RiichiTile bool   `json:"riichiTile,omitempty"`
+	Called     bool   `json:"called,omitempty"`
+}
+
+// NewPlayerView builds the view of gs from the given seat.
+func NewPlayerView(gs *GameState, seat int) PlayerView {
+	view := PlayerView{
+		Seat:           seat,
+		PrevalentWind:  gs.PrevalentWind,
+		RoundNumber:    gs.RoundNumber,
+		Honba:          gs.Honba,
+		RiichiSticks:   gs.RiichiSticks,
+		WallTiles:      len(gs.Wall),
+		DoraIndicators: TilesToNames(gs.DoraIndicators),
+		Players:        make([]SeatView, len(gs.Players)),
+	}
+	for i, p := range gs.Players {
+		view.Players[i] = SeatView{
+			Name:      p.Name,
+			SeatWind:  p.SeatWind,
+			Score:     p.Score,
+			IsRiichi:  p.IsRiichi,
+			IsDealer:  i == gs.DealerIndexThisRound,
+			IsCurrent: i == gs.CurrentPlayerIndex,
+			Melds:     FormatMeldsForDisplay(p.Melds),
+			Discards:  RiverNames(p),
+		}
+		for _, d := range p.Discards {
+			view.Players[i].River = append(view.Players[i].River, DiscardView{
+				Tile: d.Tile.Name, Turn: d.Turn, Tsumogiri: d.Tsumogiri, RiichiTile: d.RiichiTile, Called: d.Called,
+			})
+		}
+		for _, signal := range TenpaiSignals(p.Discards) {
+			view.Players[i].Signals = append(view.Players[i].Signals, signal.Reason)
+		}
+		if p.IsRiichi && p.IsOpenRiichi {
+			view.Players[i].OpenHand = TilesToNames(p.Hand)
+		}
+	}
+	if seat >= 0 && seat < len(gs.Players) {
+		self := gs.Players[seat]
+		view.Hand = TilesToNames(self.Hand)
+		if self.JustDrawnTile != nil {
+			view.DrawnTile = self.JustDrawnTile.Name
+		}
+		if self.JustDrawnTile == nil && IsTenpai(self.Hand, self.Melds) {
+			view.Waits = FormatWaitAnalysis(AnalyzeWaits(gs, self))
+		}
+	}
+	return view
+}
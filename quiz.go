@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Scoring quiz: random winning hands with their table context, asking for han, fu and
+// payment. The answers are what the engine scores the hand with (IdentifyYaku,
+// CalculateWinFu and CalculatePointPayment).
+
+// ScoringQuestion is one quiz hand with its scored answers. Player 0 of GS is the winner.
+type ScoringQuestion struct {
+	GS          *GameState
+	Winner      *Player
+	WinningTile Tile
+	IsTsumo     bool
+	Discarder   int // Index of the player dealing in on Ron, -1 on Tsumo
+
+	Yaku        []YakuResult
+	Han         int
+	Fu          int
+	FuBreakdown []string // The "Fu Calc" steps CalculateFu logged
+	Payment     Payment
+}
+
+// quizOpenHandChance is how often (out of 5) a quiz hand has called melds.
+const quizOpenHandChance = 2
+
+// NewScoringQuestion builds winning hands until one has a yaku and scores below Yakuman.
+func NewScoringQuestion() *ScoringQuestion {
+	for {
+		q := dealScoringQuestion()
+		if len(q.Yaku) == 0 || YakumanMultiple(q.Yaku) > 0 || q.Han >= 13 {
+			continue
+		}
+		return q
+	}
+}
+
+// dealScoringQuestion builds a random complete hand of four sets and a pair for player 0,
+// some of them called, in a random round and seat, and scores it.
+func dealScoringQuestion() *ScoringQuestion {
+	gs := NewGameState(defaultPlayerNames)
+	gs.QuietLog = true // Scoring the hand logs the yaku and fu, which would give the answers away
+	winds := []string{"East", "South", "West", "North"}
+	gs.PrevalentWind = winds[rand.Intn(2)]
+	gs.RoundNumber = 1 + rand.Intn(4)
+	gs.DealerIndexThisRound = (gs.RoundNumber + 3) % 4 // Player 0 deals East 1
+	for i, p := range gs.Players {
+		p.SeatWind = winds[(i-gs.DealerIndexThisRound+4)%4]
+		p.HasDrawnFirstTileThisRound = true
+		p.HasMadeFirstDiscardThisRound = true
+	}
+	gs.IsFirstGoAround = false
+	gs.TurnNumber = 8 + rand.Intn(40)
+
+	var counts [34]int
+	nextID := TotalTiles // Past every wall tile ID
+	redUsed := map[string]bool{}
+	take := func(kind int) Tile {
+		counts[kind]++
+		tile := kindTile(kind)
+		tile.ID = nextID
+		nextID++
+		if tile.Value == 5 && !IsHonor(tile) && !redUsed[tile.Suit] && rand.Intn(4) == 0 {
+			redUsed[tile.Suit] = true
+			tile.IsRed = true
+			tile.Name = "Red " + tile.Name
+		}
+		return tile
+	}
+
+	groups := [][]Tile{}
+	for len(groups) < 4 {
+		if rand.Intn(5) < 3 { // Sequence
+			start := rand.Intn(3)*9 + rand.Intn(7)
+			if counts[start] < 4 && counts[start+1] < 4 && counts[start+2] < 4 {
+				groups = append(groups, []Tile{take(start), take(start + 1), take(start + 2)})
+			}
+		} else if kind := rand.Intn(34); counts[kind] == 0 {
+			groups = append(groups, []Tile{take(kind), take(kind), take(kind)})
+		}
+	}
+	pairKind := rand.Intn(34)
+	for counts[pairKind] > 2 {
+		pairKind = rand.Intn(34)
+	}
+	concealed := []Tile{take(pairKind), take(pairKind)}
+
+	winner := gs.Players[0]
+	winner.Melds = []Meld{}
+	openGroups := 0
+	if rand.Intn(5) < quizOpenHandChance {
+		openGroups = 1 + rand.Intn(2)
+	}
+	for i, group := range groups {
+		if i >= openGroups {
+			concealed = append(concealed, group...)
+			continue
+		}
+		meld := Meld{Type: "Pon", Tiles: group, CalledOn: group[rand.Intn(3)], FromPlayer: 1 + rand.Intn(3)}
+		if group[0].Value != group[1].Value {
+			meld.Type = "Chi"
+			meld.FromPlayer = 3 // Chi is only called from the player to the left
+		}
+		winner.Melds = append(winner.Melds, meld)
+	}
+
+	for {
+		indicator := rand.Intn(34)
+		if counts[indicator] < 4 {
+			gs.DoraIndicators = []Tile{take(indicator)}
+			break
+		}
+	}
+
+	q := &ScoringQuestion{GS: gs, Winner: winner, IsTsumo: rand.Intn(2) == 0, Discarder: -1}
+	q.WinningTile = concealed[rand.Intn(len(concealed))]
+	if q.IsTsumo {
+		winner.Hand = concealed
+		winner.JustDrawnTile = &q.WinningTile
+		gs.CurrentPlayerIndex = 0
+	} else {
+		winner.Hand = removeTileByID(concealed, q.WinningTile.ID)
+		q.Discarder = 1 + rand.Intn(3)
+		gs.CurrentPlayerIndex = q.Discarder
+		gs.LastDiscard = &q.WinningTile
+	}
+	sort.Sort(BySuitValue(winner.Hand))
+	if openGroups == 0 && rand.Intn(2) == 0 {
+		winner.IsRiichi = true
+		winner.RiichiTurn = gs.TurnNumber - 4 - rand.Intn(4)
+	}
+
+	q.Yaku, q.Han = IdentifyYaku(winner, q.WinningTile, q.IsTsumo, gs)
+	if len(q.Yaku) == 0 || YakumanMultiple(q.Yaku) > 0 {
+		return q
+	}
+	q.Fu, q.FuBreakdown = CalculateWinFu(gs, winner, q.WinningTile, q.IsTsumo, q.Yaku)
+	q.Payment = CalculatePointPayment(q.Han, q.Fu, q.IsDealer(), q.IsTsumo, gs.Honba, gs.RiichiSticks)
+	return q
+}
+
+// IsDealer reports whether the winner is the dealer.
+func (q *ScoringQuestion) IsDealer() bool {
+	return q.GS.Players[q.GS.DealerIndexThisRound] == q.Winner
+}
+
+// Context describes the hand as the quiz shows it: round, seat, win, hand, melds and dora.
+func (q *ScoringQuestion) Context() []string {
+	gs := q.GS
+	lines := []string{fmt.Sprintf("%s %d. You are %s (%s).", gs.PrevalentWind, gs.RoundNumber, q.Winner.SeatWind, If(q.IsDealer(), "dealer", "non-dealer"))}
+	if q.IsTsumo {
+		lines = append(lines, fmt.Sprintf("Tsumo on %s.", q.WinningTile.Name))
+	} else {
+		lines = append(lines, fmt.Sprintf("Ron on %s from %s.", q.WinningTile.Name, gs.Players[q.Discarder].SeatWind))
+	}
+	if q.Winner.IsRiichi {
+		lines = append(lines, "You are in Riichi (no ura dora).")
+	}
+	hand := removeTileByID(q.Winner.Hand, q.WinningTile.ID)
+	lines = append(lines, fmt.Sprintf("Hand: %s + %s", FormatHandForDisplay(hand), q.WinningTile.Name))
+	lines = append(lines, "Melds: "+FormatMeldsForDisplay(q.Winner.Melds))
+	doraNames := []string{}
+	for _, indicator := range gs.DoraIndicators {
+		doraNames = append(doraNames, fmt.Sprintf("%s (dora %s)", indicator.Name, getDoraTile(indicator).Name))
+	}
+	lines = append(lines, "Dora Indicators: "+strings.Join(doraNames, ", "))
+	return lines
+}
+
+// PaymentAnswer is the expected payment as the quiz writes it: the Ron value, "X all" for a
+// dealer Tsumo, or "non-dealers/dealer" for a non-dealer Tsumo.
+func (q *ScoringQuestion) PaymentAnswer() string {
+	switch {
+	case !q.IsTsumo:
+		return strconv.Itoa(q.Payment.RonValue)
+	case q.IsDealer():
+		return fmt.Sprintf("%d all", q.Payment.TsumoNonDealerPay)
+	default:
+		return fmt.Sprintf("%d/%d", q.Payment.TsumoNonDealerPay, q.Payment.TsumoDealerPay)
+	}
+}
+
+// CheckPayment compares an answer to PaymentAnswer, reading only the numbers in it.
+func (q *ScoringQuestion) CheckPayment(answer string) bool {
+	expected := strings.Fields(strings.NewReplacer("/", " ", "all", "").Replace(q.PaymentAnswer()))
+	given := strings.FieldsFunc(answer, func(r rune) bool { return r < '0' || r > '9' })
+	if len(given) != len(expected) {
+		return false
+	}
+	for i := range expected {
+		if given[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Breakdown itemizes the answer: each yaku, the fu steps and the payment.
+func (q *ScoringQuestion) Breakdown() []string {
+	lines := []string{"Yaku:"}
+	for _, y := range q.Yaku {
+		lines = append(lines, fmt.Sprintf("  %s: %d han", y.Name, y.Han))
+	}
+	lines = append(lines, fmt.Sprintf("Total: %d han", q.Han), "Fu:")
+	for _, step := range q.FuBreakdown {
+		lines = append(lines, "  "+step)
+	}
+	lines = append(lines, fmt.Sprintf("Total: %d fu", q.Fu), "Payment: "+q.Payment.Description)
+	return lines
+}
+
+// readQuizAnswer prompts for one answer line.
+func readQuizAnswer(reader *bufio.Reader, prompt string) string {
+	fmt.Print(prompt)
+	input, err := reader.ReadString('\n')
+	if err != nil && input == "" {
+		return ""
+	}
+	return strings.TrimSpace(input)
+}
+
+// runQuizCommand handles "mahjong-go quiz": it asks for the han, fu and payment of random
+// winning hands and shows the itemized scoring after each one.
+func runQuizCommand(args []string) {
+	flags := flag.NewFlagSet("quiz", flag.ExitOnError)
+	count := flags.Int("count", 5, "number of hands to score")
+	flags.Parse(args)
+
+	reader := bufio.NewReader(os.Stdin)
+	correct := 0
+	for i := 0; i < *count; i++ {
+		q := NewScoringQuestion()
+		fmt.Printf("\n--- Hand %d of %d ---\n", i+1, *count)
+		for _, line := range q.Context() {
+			fmt.Println(line)
+		}
+
+		han := readQuizAnswer(reader, "Han: ")
+		fu := readQuizAnswer(reader, "Fu: ")
+		payment := readQuizAnswer(reader, If(q.IsTsumo && !q.IsDealer(), "Payment (non-dealers/dealer, e.g. 1000/2000): ", "Payment: "))
+
+		results := []struct {
+			name, given, expected string
+			ok                    bool
+		}{
+			{"Han", han, strconv.Itoa(q.Han), han == strconv.Itoa(q.Han)},
+			{"Fu", fu, strconv.Itoa(q.Fu), fu == strconv.Itoa(q.Fu)},
+			{"Payment", payment, q.PaymentAnswer(), q.CheckPayment(payment)},
+		}
+		for _, r := range results {
+			if r.ok {
+				correct++
+				fmt.Printf("%s: %s, correct.\n", r.name, r.given)
+			} else {
+				fmt.Printf("%s: %q, expected %s.\n", r.name, r.given, r.expected)
+			}
+		}
+		for _, line := range q.Breakdown() {
+			fmt.Println(line)
+		}
+	}
+	fmt.Printf("\nScore: %d of %d\n", correct, 3**count)
+}
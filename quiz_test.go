@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewScoringQuestion(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		q := NewScoringQuestion()
+		if len(q.Yaku) == 0 || YakumanMultiple(q.Yaku) > 0 || q.Han >= 13 {
+			t.Fatalf("TestNewScoringQuestion: Expected a scored hand below Yakuman, got %v (%d han)", q.Yaku, q.Han)
+		}
+		tiles := len(q.Winner.Hand)
+		for _, m := range q.Winner.Melds {
+			tiles += len(m.Tiles)
+		}
+		if !q.IsTsumo {
+			tiles++
+		}
+		if tiles != 14 {
+			t.Errorf("TestNewScoringQuestion: Expected 14 tiles, got %d", tiles)
+		}
+		if q.Fu < 20 || len(q.FuBreakdown) == 0 {
+			t.Errorf("TestNewScoringQuestion: Expected fu with a breakdown, got %d fu and %v", q.Fu, q.FuBreakdown)
+		}
+		if !q.GS.QuietLog {
+			t.Errorf("TestNewScoringQuestion: Expected the question's game to log quietly so the answers are not printed")
+		}
+		expected := CalculatePointPayment(q.Han, q.Fu, q.IsDealer(), q.IsTsumo, 0, 0)
+		if q.Payment.RonValue != expected.RonValue || q.Payment.TsumoDealerPay != expected.TsumoDealerPay || q.Payment.TsumoNonDealerPay != expected.TsumoNonDealerPay {
+			t.Errorf("TestNewScoringQuestion: Expected payment %+v, got %+v", expected, q.Payment)
+		}
+		if !q.CheckPayment(q.PaymentAnswer()) {
+			t.Errorf("TestNewScoringQuestion: Expected %q to check as correct", q.PaymentAnswer())
+		}
+	}
+}
+
+func TestScoringQuestion_Payment(t *testing.T) {
+	gs := createTestGameState(nil)
+	q := &ScoringQuestion{GS: gs, Winner: gs.Players[1], IsTsumo: true, Discarder: -1,
+		Payment: CalculatePointPayment(3, 30, false, true, 0, 0)}
+	if got := q.PaymentAnswer(); got != "1000/2000" {
+		t.Errorf("TestScoringQuestion_Payment: Expected 1000/2000 for a non-dealer 3 han 30 fu Tsumo, got %s", got)
+	}
+	for _, answer := range []string{"1000/2000", "1000 2000", " 1000-2000 "} {
+		if !q.CheckPayment(answer) {
+			t.Errorf("TestScoringQuestion_Payment: Expected %q to be correct", answer)
+		}
+	}
+	for _, answer := range []string{"2000/1000", "4000", ""} {
+		if q.CheckPayment(answer) {
+			t.Errorf("TestScoringQuestion_Payment: Expected %q to be wrong", answer)
+		}
+	}
+
+	q.Winner = gs.Players[0] // Dealer
+	q.Payment = CalculatePointPayment(3, 30, true, true, 0, 0)
+	if got := q.PaymentAnswer(); got != "2000 all" || !q.CheckPayment("2000") {
+		t.Errorf("TestScoringQuestion_Payment: Expected 2000 all for a dealer 3 han 30 fu Tsumo, got %s", got)
+	}
+
+	q.IsTsumo = false
+	q.Discarder = 2
+	q.Payment = CalculatePointPayment(3, 30, true, false, 0, 0)
+	if got := q.PaymentAnswer(); got != "5800" || !q.CheckPayment("5800") {
+		t.Errorf("TestScoringQuestion_Payment: Expected 5800 for a dealer 3 han 30 fu Ron, got %s", got)
+	}
+}
+
+func TestScoringQuestion_Context(t *testing.T) {
+	gs := createTestGameState(nil)
+	player := gs.Players[1]
+	player.Hand = TilesFromString("123m456p789s E E 23s")
+	win := TilesFromString("4s")[0]
+	win.ID = 99
+	q := &ScoringQuestion{GS: gs, Winner: player, WinningTile: win, Discarder: 2}
+	context := strings.Join(q.Context(), "\n")
+	for _, want := range []string{"East 1. You are South (non-dealer).", "Ron on Sou 4 from West.", "+ Sou 4", "Melds: None"} {
+		if !strings.Contains(context, want) {
+			t.Errorf("TestScoringQuestion_Context: Expected %q in\n%s", want, context)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultRecordFile is where a console game saves the human's decisions for the review command.
+const DefaultRecordFile = "last-game.json"
+
+// Decision kinds recorded besides the PlayerInput.Confirm actions (Ron, Pon, Chi, Tsumo, ...).
+const (
+	DecisionDiscard = "Discard"
+	DecisionRiichi  = "Riichi"
+)
+
+// GameRecord is every decision the human seat made in one game, in order, with how each hand
+// ended for every seat and the final placements.
+type GameRecord struct {
+	ID         string       `json:"id"` // Set when the game starts, so the statistics count each game once
+	Player     string       `json:"player"`
+	Decisions  []Decision   `json:"decisions"`
+	Hands      []HandResult `json:"hands"`
+	Placements []Placement  `json:"placements"`
+}
+
+// HandResult is how one hand ended.
+type HandResult struct {
+	Round string       `json:"round"`
+	Seats []SeatResult `json:"seats"`
+}
+
+// SeatResult is one seat's part in a hand.
+type SeatResult struct {
+	Name        string   `json:"name"`
+	Won         bool     `json:"won"`
+	DealtIn     bool     `json:"dealtIn"`
+	Called      bool     `json:"called"`               // Made an open call
+	Riichi      bool     `json:"riichi"`               // Declared Riichi
+	RiichiTurn  int      `json:"riichiTurn,omitempty"` // Position of the Riichi discard in the player's own river (1 = first discard)
+	ScoreChange int      `json:"scoreChange"`          // Points gained or lost over the hand, Riichi sticks included
+	Yaku        []string `json:"yaku,omitempty"`       // Yaku of a win, without Dora
+}
+
+// Decision is one choice with what the player could see when making it.
+type Decision struct {
+	Kind           string          `json:"kind"`
+	Round          string          `json:"round"` // e.g. "East 2, 1 Honba"
+	Turn           int             `json:"turn"`
+	Hand           []Tile          `json:"hand"` // Concealed hand when asked (14 tiles counting melds on a discard)
+	Melds          []Meld          `json:"melds"`
+	River          []Discard       `json:"river"`
+	Tile           Tile            `json:"tile"`               // The tile discarded, or the tile the action was offered on
+	Sequence       []Tile          `json:"sequence,omitempty"` // The chosen Chi
+	Accepted       bool            `json:"accepted"`           // False if the action or Riichi was declined
+	DoraIndicators []Tile          `json:"doraIndicators"`
+	Opponents      []OpponentState `json:"opponents"`
+}
+
+// NewGameRecord starts the record of a game played by player, with a fresh ID.
+func NewGameRecord(player string) *GameRecord {
+	return &GameRecord{ID: fmt.Sprintf("%d-%04d", time.Now().UnixNano(), rand.Intn(10000)), Player: player}
+}
+
+// PlayRecordedGame runs gs through RunGame, recording the decisions of seat 0 and how every hand
+// ended. With statsFile set, a finished game is added to the statistics in that file; games left
+// unfinished (gs.Stopped) are not counted.
+func PlayRecordedGame(gs *GameState, statsFile string) *GameRecord {
+	record := NewGameRecord(gs.Players[0].Name)
+	gs.Input = &RecordingInput{Input: gs.Input, Record: record}
+	RunGame(gs)
+	record.Placements = FinalPlacements(gs)
+	if statsFile != "" && !gs.Stopped {
+		if err := UpdateStats(statsFile, record); err != nil {
+			fmt.Println("Error updating stats:", err)
+		}
+	}
+	return record
+}
+
+// OpponentState is the public state of another seat at a decision.
+type OpponentState struct {
+	Name     string    `json:"name"`
+	SeatWind string    `json:"seatWind"`
+	IsRiichi bool      `json:"isRiichi"`
+	River    []Discard `json:"river"`
+	Melds    []Meld    `json:"melds"`
+}
+
+// newDecision snapshots gs as player sees it.
+func newDecision(gs *GameState, player *Player, kind string) Decision {
+	d := Decision{
+		Kind:           kind,
+		Round:          fmt.Sprintf("%s %d, %d Honba", gs.PrevalentWind, gs.RoundNumber, gs.Honba),
+		Turn:           gs.TurnNumber,
+		Hand:           append([]Tile{}, player.Hand...),
+		Melds:          append([]Meld{}, player.Melds...),
+		River:          append([]Discard{}, player.Discards...),
+		DoraIndicators: append([]Tile{}, gs.DoraIndicators...),
+	}
+	for _, p := range gs.Players {
+		if p == player {
+			continue
+		}
+		d.Opponents = append(d.Opponents, OpponentState{
+			Name:     p.Name,
+			SeatWind: p.SeatWind,
+			IsRiichi: p.IsRiichi,
+			River:    append([]Discard{}, p.Discards...),
+			Melds:    append([]Meld{}, p.Melds...),
+		})
+	}
+	return d
+}
+
+// Save writes the record as JSON.
+func (r *GameRecord) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadGameRecord reads a record saved by Save.
+func LoadGameRecord(path string) (*GameRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	record := &GameRecord{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("reading game record %s: %w", path, err)
+	}
+	return record, nil
+}
+
+// AddHand records how the hand in gs ended. scoresBefore are the seats' scores when it started.
+func (r *GameRecord) AddHand(gs *GameState, scoresBefore []int) {
+	hand := HandResult{Round: fmt.Sprintf("%s %d, %d Honba", gs.PrevalentWind, gs.RoundNumber, gs.Honba)}
+	for i, p := range gs.Players {
+		seat := SeatResult{Name: p.Name, ScoreChange: p.Score - scoresBefore[i]}
+		for _, m := range p.Melds {
+			if !m.IsConcealed {
+				seat.Called = true
+			}
+		}
+		for n, d := range p.Discards {
+			if d.RiichiTile {
+				seat.Riichi, seat.RiichiTurn = true, n+1
+			}
+		}
+		for _, win := range gs.WinResults {
+			if win.Winner == p {
+				seat.Won = true
+				for _, y := range win.Yaku {
+					if !strings.HasPrefix(y.Name, "Dora ") {
+						seat.Yaku = append(seat.Yaku, y.Name)
+					}
+				}
+			}
+			if win.Discarder == p {
+				seat.DealtIn = true
+			}
+		}
+		hand.Seats = append(hand.Seats, seat)
+	}
+	r.Hands = append(r.Hands, hand)
+}
+
+// RecordingInput passes every decision on to Input and adds it to Record, along with each
+// hand's result.
+type RecordingInput struct {
+	Input  PlayerInput
+	Record *GameRecord
+
+	scores []int // Scores at the start of the current hand
+}
+
+func (in *RecordingInput) ChooseDiscard(gs *GameState, player *Player) int {
+	d := newDecision(gs, player, DecisionDiscard)
+	index := in.Input.ChooseDiscard(gs, player)
+	if index >= 0 && index < len(player.Hand) && !IsKuikaeDiscard(player, player.Hand[index]) { // A kuikae choice is asked again
+		d.Tile, d.Accepted = player.Hand[index], true
+		in.Record.Decisions = append(in.Record.Decisions, d)
+	}
+	return index
+}
+
+func (in *RecordingInput) ChooseRiichi(gs *GameState, player *Player, options []RiichiOption) (int, bool) {
+	d := newDecision(gs, player, DecisionRiichi)
+	choice, ok := in.Input.ChooseRiichi(gs, player, options)
+	if ok && choice >= 0 && choice < len(options) {
+		d.Tile, d.Accepted = options[choice].DiscardTile, true
+	}
+	in.Record.Decisions = append(in.Record.Decisions, d) // A declined Riichi is followed by a Discard decision
+	return choice, ok
+}
+
+func (in *RecordingInput) ChooseChi(gs *GameState, player *Player, discardedTile Tile) (int, []Tile) {
+	d := newDecision(gs, player, ActionChi)
+	choice, sequence := in.Input.ChooseChi(gs, player, discardedTile)
+	d.Tile, d.Accepted, d.Sequence = discardedTile, choice > 0, sequence
+	in.Record.Decisions = append(in.Record.Decisions, d)
+	return choice, sequence
+}
+
+func (in *RecordingInput) Confirm(gs *GameState, player *Player, action string, tile Tile, prompt string) bool {
+	d := newDecision(gs, player, action)
+	ok := in.Input.Confirm(gs, player, action, tile, prompt)
+	d.Tile, d.Accepted = tile, ok
+	in.Record.Decisions = append(in.Record.Decisions, d)
+	return ok
+}
+
+func (in *RecordingInput) RoundEnded(gs *GameState) {
+	if in.scores == nil {
+		for range gs.Players {
+			in.scores = append(in.scores, gs.Rules.StartingPoints)
+		}
+	}
+	in.Record.AddHand(gs, in.scores)
+	for i, p := range gs.Players {
+		in.scores[i] = p.Score
+	}
+	in.Input.RoundEnded(gs)
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Post-game review: every recorded decision is compared with what the bot would choose, using
+// the trainer's acceptance counts (AnalyzeDiscards) for efficiency and DealInRisk against
+// players in Riichi. Findings are ranked in tiles of acceptance: a shanten step is worth
+// ReviewShantenWeight tiles and each percentage point of deal-in risk one tile.
+const (
+	ReviewShantenWeight        = 20
+	ReviewFoldShanten          = 1   // Against a Riichi the bot folds when its best discard leaves this shanten or worse
+	ReviewNoProgressCallWeight = 10  // A call that does not lower the shanten
+	ReviewDeclinedWinWeight    = 100 // Skipping a Ron or Tsumo
+)
+
+// ReviewFinding is one decision that deviated from the bot's choice.
+type ReviewFinding struct {
+	Decision Decision
+	Chosen   string  // What the player did
+	Better   string  // What the bot would have done
+	Detail   string  // The difference in acceptance or deal-in risk
+	Severity float64 // How far the decision deviated, in tiles of acceptance
+}
+
+// reviewThreat is a player in Riichi at a decision, with the tiles that are genbutsu against them.
+type reviewThreat struct {
+	name   string
+	river  []Discard
+	passed []Tile // Tiles other players discarded after the Riichi without a Ron
+}
+
+// ReviewGame evaluates every decision in record and returns the deviations, largest first.
+func ReviewGame(record *GameRecord) []ReviewFinding {
+	findings := []ReviewFinding{}
+	for _, d := range record.Decisions {
+		if f, ok := reviewDecision(d); ok {
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Severity > findings[j].Severity })
+	return findings
+}
+
+func reviewDecision(d Decision) (ReviewFinding, bool) {
+	switch d.Kind {
+	case DecisionDiscard:
+		return reviewDiscard(d)
+	case DecisionRiichi:
+		if d.Accepted {
+			return reviewRiichi(d)
+		}
+	case ActionRon, ActionTsumo, ActionChankan:
+		if !d.Accepted {
+			return ReviewFinding{Decision: d, Severity: ReviewDeclinedWinWeight,
+				Chosen: fmt.Sprintf("skipped %s on %s", d.Kind, d.Tile.Name),
+				Better: fmt.Sprintf("declare %s", d.Kind),
+				Detail: "a winning hand was passed up"}, true
+		}
+	case ActionPon, ActionChi:
+		if d.Accepted {
+			return reviewCall(d)
+		}
+	}
+	return ReviewFinding{}, false
+}
+
+// reviewDiscard compares a discard with the most efficient one, or against a Riichi with the
+// safest one once the hand is too far from tenpai to push.
+func reviewDiscard(d Decision) (ReviewFinding, bool) {
+	options := AnalyzeDiscards(d.Hand, d.Melds)
+	if len(options) == 0 {
+		return ReviewFinding{}, false
+	}
+	_, chosen := GradeDiscard(options, d.Tile)
+	best := options[0]
+	threats := reviewThreats(d)
+	visible := visibleTileCounts(d)
+	finding := ReviewFinding{Decision: d, Chosen: fmt.Sprintf("cut %s (%s, %d tiles)", chosen.Tile.Name, ShantenLabel(chosen.Shanten), chosen.Count)}
+
+	if len(threats) > 0 && best.Shanten >= ReviewFoldShanten {
+		safest := best
+		for _, option := range options {
+			if combinedDealInRisk(option.Tile, threats, visible) < combinedDealInRisk(safest.Tile, threats, visible) {
+				safest = option
+			}
+		}
+		chosenRisk := combinedDealInRisk(chosen.Tile, threats, visible)
+		safestRisk := combinedDealInRisk(safest.Tile, threats, visible)
+		if chosenRisk <= safestRisk {
+			return ReviewFinding{}, false
+		}
+		finding.Better = fmt.Sprintf("fold with %s", safest.Tile.Name)
+		finding.Detail = fmt.Sprintf("pushed at %s against Riichi from %s: deal-in risk %.0f%% instead of %.0f%%",
+			ShantenLabel(best.Shanten), threatNames(threats), chosenRisk*100, safestRisk*100)
+		finding.Severity = (chosenRisk - safestRisk) * 100
+		return finding, true
+	}
+
+	if chosen.Shanten > best.Shanten {
+		finding.Severity = float64((chosen.Shanten - best.Shanten) * ReviewShantenWeight)
+	} else {
+		finding.Severity = float64(best.Count - chosen.Count)
+	}
+	if finding.Severity <= 0 {
+		return ReviewFinding{}, false
+	}
+	finding.Better = fmt.Sprintf("cut %s (%s, %d tiles)", best.Tile.Name, ShantenLabel(best.Shanten), best.Count)
+	finding.Detail = fmt.Sprintf("%d fewer tiles of acceptance", best.Count-chosen.Count)
+	if chosen.Shanten > best.Shanten {
+		finding.Detail = fmt.Sprintf("%d shanten backwards", chosen.Shanten-best.Shanten)
+	}
+	if len(threats) > 0 {
+		finding.Detail += fmt.Sprintf(" (deal-in risk %.0f%%, the better cut %.0f%%)",
+			combinedDealInRisk(chosen.Tile, threats, visible)*100, combinedDealInRisk(best.Tile, threats, visible)*100)
+	}
+	return finding, true
+}
+
+// reviewRiichi compares the Riichi discard with the one leaving the most winning tiles.
+func reviewRiichi(d Decision) (ReviewFinding, bool) {
+	options := FindRiichiOptions(d.Hand, d.Melds)
+	chosenCount, bestCount := -1, -1
+	var best RiichiOption
+	for _, option := range options {
+		count := unseenCount(removeTileByID(d.Hand, option.DiscardTile.ID), option.Waits)
+		if count > bestCount {
+			best, bestCount = option, count
+		}
+		if tileKind(option.DiscardTile) == tileKind(d.Tile) {
+			chosenCount = count
+		}
+	}
+	if chosenCount < 0 || bestCount <= chosenCount {
+		return ReviewFinding{}, false
+	}
+	return ReviewFinding{Decision: d, Severity: float64(bestCount - chosenCount),
+		Chosen: fmt.Sprintf("Riichi cutting %s (%d tiles to win)", d.Tile.Name, chosenCount),
+		Better: fmt.Sprintf("Riichi cutting %s (%d tiles to win on %s)", best.DiscardTile.Name, bestCount, strings.Join(TilesToNames(best.Waits), ", ")),
+		Detail: fmt.Sprintf("%d fewer winning tiles", bestCount-chosenCount)}, true
+}
+
+// reviewCall flags a Pon or Chi after which the best discard is no closer to tenpai.
+func reviewCall(d Decision) (ReviewFinding, bool) {
+	before := Shanten(d.Hand, d.Melds)
+	rest := append([]Tile{}, d.Hand...)
+	meld := Meld{Type: d.Kind, CalledOn: d.Tile, Tiles: d.Sequence}
+	if d.Kind == ActionPon {
+		meld.Tiles = []Tile{d.Tile}
+		for n := 0; n < 2; n++ {
+			for _, h := range rest {
+				if tileKind(h) == tileKind(d.Tile) {
+					meld.Tiles = append(meld.Tiles, h)
+					rest = removeTileByID(rest, h.ID)
+					break
+				}
+			}
+		}
+	} else {
+		for _, t := range d.Sequence {
+			if t.ID != d.Tile.ID {
+				rest = removeTileByID(rest, t.ID)
+			}
+		}
+	}
+	options := AnalyzeDiscards(rest, append(append([]Meld{}, d.Melds...), meld))
+	if len(options) == 0 || options[0].Shanten < before {
+		return ReviewFinding{}, false
+	}
+	return ReviewFinding{Decision: d, Severity: ReviewNoProgressCallWeight,
+		Chosen: fmt.Sprintf("%s on %s", d.Kind, d.Tile.Name),
+		Better: "skip the call",
+		Detail: fmt.Sprintf("still %s after the call, with the hand opened", ShantenLabel(options[0].Shanten))}, true
+}
+
+// reviewThreats lists the opponents in Riichi at d.
+func reviewThreats(d Decision) []reviewThreat {
+	threats := []reviewThreat{}
+	for i, opp := range d.Opponents {
+		if !opp.IsRiichi {
+			continue
+		}
+		riichiTurn := -1
+		for _, discard := range opp.River {
+			if discard.RiichiTile {
+				riichiTurn = discard.Turn
+			}
+		}
+		threat := reviewThreat{name: opp.Name, river: opp.River}
+		rivers := [][]Discard{d.River}
+		for j, other := range d.Opponents {
+			if j != i {
+				rivers = append(rivers, other.River)
+			}
+		}
+		for _, river := range rivers {
+			for _, discard := range river {
+				if riichiTurn >= 0 && discard.Turn > riichiTurn {
+					threat.passed = append(threat.passed, discard.Tile)
+				}
+			}
+		}
+		threats = append(threats, threat)
+	}
+	return threats
+}
+
+// combinedDealInRisk is the chance that tile deals into at least one of threats.
+func combinedDealInRisk(tile Tile, threats []reviewThreat, visible [34]int) float64 {
+	safe := 1.0
+	for _, threat := range threats {
+		safe *= 1 - DealInRisk(tile, threat.river, threat.passed, visible)
+	}
+	return 1 - safe
+}
+
+func threatNames(threats []reviewThreat) string {
+	names := []string{}
+	for _, threat := range threats {
+		names = append(names, threat.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// visibleTileCounts counts every tile the player could see at d: their hand and melds, the
+// dora indicators, every river and the other players' melds. Called tiles are counted once,
+// in the meld.
+func visibleTileCounts(d Decision) [34]int {
+	seen := append(append([]Tile{}, d.Hand...), d.DoraIndicators...)
+	melds := append([]Meld{}, d.Melds...)
+	rivers := [][]Discard{d.River}
+	for _, opp := range d.Opponents {
+		melds = append(melds, opp.Melds...)
+		rivers = append(rivers, opp.River)
+	}
+	for _, m := range melds {
+		seen = append(seen, m.Tiles...)
+	}
+	for _, river := range rivers {
+		for _, discard := range river {
+			if !discard.Called {
+				seen = append(seen, discard.Tile)
+			}
+		}
+	}
+	return tileKindCounts(seen)
+}
+
+// runReviewCommand handles "mahjong-go review": it reads the record of the last console game
+// and prints the decisions that deviated most from the bot's.
+func runReviewCommand(args []string) {
+	flags := flag.NewFlagSet("review", flag.ExitOnError)
+	file := flags.String("file", DefaultRecordFile, "game record saved by a console game")
+	top := flags.Int("top", 10, "number of decisions to show")
+	flags.Parse(args)
+
+	record, err := LoadGameRecord(*file)
+	if err != nil {
+		fmt.Println("Error loading game record:", err)
+		os.Exit(1)
+	}
+	findings := ReviewGame(record)
+	fmt.Printf("Reviewed %d decisions by %s; %d differ from the bot.\n", len(record.Decisions), record.Player, len(findings))
+	for i, f := range findings {
+		if i >= *top {
+			break
+		}
+		d := f.Decision
+		fmt.Printf("\n%d. %s, turn %d: %s\n", i+1, d.Round, d.Turn, d.Kind)
+		fmt.Printf("   Hand: %s", FormatHandForDisplay(d.Hand))
+		if len(d.Melds) > 0 {
+			fmt.Printf(" | Melds: %s", FormatMeldsForDisplay(d.Melds))
+		}
+		fmt.Printf("\n   You: %s\n   Bot: %s\n   %s\n", f.Chosen, f.Better, f.Detail)
+	}
+}
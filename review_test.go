@@ -0,0 +1,87 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// reviewDecisionFor builds a decision on an MPSZ hand, about the hand's first tile named tile.
+func reviewDecisionFor(t *testing.T, kind, hand, tile string) Decision {
+	d := Decision{Kind: kind, Round: "East 1, 0 Honba", Hand: mustParseMPSZ(t, hand), Accepted: true}
+	for _, h := range d.Hand {
+		if h.Name == tile {
+			d.Tile = h
+			return d
+		}
+	}
+	t.Fatalf("reviewDecisionFor: no %s in %s", tile, hand)
+	return d
+}
+
+func TestReviewGame_Efficiency(t *testing.T) {
+	d := reviewDecisionFor(t, DecisionDiscard, "123m456p789s11z35s9m", "Sou 3")
+	findings := ReviewGame(&GameRecord{Decisions: []Decision{d}})
+	if len(findings) != 1 {
+		t.Fatalf("TestReviewGame_Efficiency: Expected one finding, got %d", len(findings))
+	}
+	if f := findings[0]; f.Severity != ReviewShantenWeight || f.Better != "cut Man 9 (tenpai, 4 tiles)" {
+		t.Errorf("TestReviewGame_Efficiency: Expected cutting Man 9 for tenpai, got %q (severity %.0f)", f.Better, f.Severity)
+	}
+
+	d = reviewDecisionFor(t, DecisionDiscard, "123m456p789s11z35s9m", "Man 9") // The bot's choice
+	if findings := ReviewGame(&GameRecord{Decisions: []Decision{d}}); len(findings) != 0 {
+		t.Errorf("TestReviewGame_Efficiency: Expected no finding for the best cut, got %+v", findings)
+	}
+}
+
+func TestReviewGame_FoldAndDeclinedWin(t *testing.T) {
+	fold := reviewDecisionFor(t, DecisionDiscard, "123m48p37s123467z", "Pin 4")
+	river := riverFromString("r 1m")
+	river[1].RiichiTile = true
+	fold.Opponents = []OpponentState{{Name: "P2", IsRiichi: true, River: river}}
+
+	skipped := reviewDecisionFor(t, ActionRon, "123m456p789s11z3s", "Sou 3")
+	skipped.Accepted = false
+
+	findings := ReviewGame(&GameRecord{Decisions: []Decision{fold, skipped}})
+	if len(findings) != 2 {
+		t.Fatalf("TestReviewGame_FoldAndDeclinedWin: Expected two findings, got %d", len(findings))
+	}
+	if findings[0].Decision.Kind != ActionRon {
+		t.Errorf("TestReviewGame_FoldAndDeclinedWin: Expected the skipped Ron first, got %s", findings[0].Decision.Kind)
+	}
+	if f := findings[1]; f.Better != "fold with Red" || f.Severity != 13 {
+		t.Errorf("TestReviewGame_FoldAndDeclinedWin: Expected folding with the genbutsu Red (13 points of risk), got %q (severity %.1f)", f.Better, f.Severity)
+	}
+}
+
+func TestRecordingInput(t *testing.T) {
+	gs := createTestGameState(nil)
+	player := gs.Players[0]
+	player.Hand = mustParseMPSZ(t, "123m456p789s11z35s9m")
+	player.JustDrawnTile = &player.Hand[3]
+	gs.Players[1].IsRiichi = true
+	record := &GameRecord{Player: player.Name}
+	in := &RecordingInput{Input: AutoInput{}, Record: record}
+
+	if index := in.ChooseDiscard(gs, player); index != 3 {
+		t.Fatalf("TestRecordingInput: Expected AutoInput's tsumogiri (index 3), got %d", index)
+	}
+	in.Confirm(gs, player, ActionPon, TilesFromString("E")[0], "")
+
+	path := filepath.Join(t.TempDir(), "record.json")
+	if err := record.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadGameRecord(path)
+	if err != nil {
+		t.Fatalf("TestRecordingInput: %v", err)
+	}
+	if len(loaded.Decisions) != 2 || loaded.Decisions[0].Kind != DecisionDiscard || loaded.Decisions[1].Kind != ActionPon {
+		t.Fatalf("TestRecordingInput: Expected a Discard and a Pon decision, got %+v", loaded.Decisions)
+	}
+	d := loaded.Decisions[0]
+	if d.Tile.Name != "Man 9" || len(d.Hand) != 14 || len(d.Opponents) != 3 || !d.Opponents[0].IsRiichi {
+		t.Errorf("TestRecordingInput: Expected the 9m discard with the hand and opponents, got %+v", d)
+	}
+}
@@ -44,11 +44,11 @@ func UpdateFuritenStatus(player *Player, gs *GameState) {
 			// gs.AddToGameLog(fmt.Sprintf("Debug: %s is Tenpai, waits: %v", player.Name, TilesToNames(waits)))
 		}
 		for _, waitTile := range waits {
-			for _, discarded := range player.Discards {
+			for _, d := range player.Discards { // Called tiles count: they stay in the river
 				// Compare type (Suit and Value), ignoring IsRed for Furiten purposes
-				if discarded.Suit == waitTile.Suit && discarded.Value == waitTile.Value {
+				if d.Tile.Suit == waitTile.Suit && d.Tile.Value == waitTile.Value {
 					player.IsFuriten = true
-					// gs.AddToGameLog(fmt.Sprintf("%s is Furiten (wait %s found in own discards: %v).", player.Name, waitTile.Name, TilesToNames(player.Discards)))
+					// gs.AddToGameLog(fmt.Sprintf("%s is Furiten (wait %s found in own discards: %v).", player.Name, waitTile.Name, RiverNames(player)))
 					return // Found a reason for Furiten
 				}
 			}
@@ -77,73 +77,104 @@ func UpdateFuritenStatus(player *Player, gs *GameState) {
 // Payment represents the points transferred in a win.
 type Payment struct {
 	Description       string
-	RonValue          int // Total points paid by discarder on Ron
-	TsumoDealerPay    int // Points paid BY Dealer ON Non-Dealer Tsumo
-	TsumoNonDealerPay int // Points paid BY EACH Non-Dealer (for Dealer Tsumo, or to Non-Dealer Tsumo)
+	RonValue          int         // Total points paid by discarder on Ron
+	TsumoDealerPay    int         // Points paid BY Dealer ON Non-Dealer Tsumo
+	TsumoNonDealerPay int         // Points paid BY EACH Non-Dealer (for Dealer Tsumo, or to Non-Dealer Tsumo)
+	Honba             int         // Honba sticks included in the amounts above
+	Pao               *PaoPayment // Liability for part or all of the win (see pao.go); nil if nobody is liable
 }
 
 // CalculatePointPayment calculates point values based on Han, Fu, win conditions, and game state.
+// 13 Han or more counts as a single (kazoe) Yakuman; use CalculateWinPayment to score a hand's
+// Yaku with explicit Yakuman multiples and the table's limits.
 func CalculatePointPayment(han, fu int, isWinnerDealer, isTsumo bool, honba, riichiSticks int) Payment {
-	// Yakuman has fixed base points, Fu is generally not used for point table lookup.
-	// If han indicates Yakuman (e.g., >= 13, or specific Yakuman Yaku identified)
-	isYakumanScoreLevel := han >= 13 // Simplified: Kazoe Yakuman and above
-	// More robust would be if Yaku identification specifically marked a hand as "Yakuman Type"
-
-	basePoints := 0.0
-	limitName := ""
+	basePoints, limitName := regularBasePoints(han, fu, true)
+	return paymentFromBasePoints(basePoints, limitName, isWinnerDealer, isTsumo, honba)
+}
 
-	if isYakumanScoreLevel {
-		yakumanMultiplier := han / 13 // 1 for 13-25 Han, 2 for 26-38 Han (Double Yakuman), etc.
-		if yakumanMultiplier == 0 {
-			yakumanMultiplier = 1
-		} // Should not happen if han >= 13
-		basePoints = 8000.0 * float64(yakumanMultiplier)
-		limitName = fmt.Sprintf("%dx Yakuman", yakumanMultiplier)
-	} else {
-		// Standard Scoring: Base Points = Fu * 2^(Han + 2)
-		// Ensure minimum Fu, except for Chiitoitsu (25 Fu) and Pinfu (20/30 Fu) which are handled by CalculateFu.
-		if fu < 20 && fu != 0 {
-			fu = 20
-		} // Should be rare if CalculateFu is robust
-		if fu == 25 && han < 2 { /* Chiitoitsu should have at least 2 han from Yaku struct */
+// CalculateWinPayment scores a winning hand from its Yaku. Yakuman are scored by their explicit
+// multiples (limited by rules.YakumanCap), never by Han; other hands by Han and Fu, with 13+ Han
+// counted as kazoe Yakuman or capped at Sanbaiman according to rules.KazoeYakuman.
+func CalculateWinPayment(yaku []YakuResult, han, fu int, rules RuleSet, isWinnerDealer, isTsumo bool, honba int) Payment {
+	if multiple := YakumanMultiple(yaku); multiple > 0 {
+		if rules.YakumanCap > 0 && multiple > rules.YakumanCap {
+			multiple = rules.YakumanCap
 		}
+		return paymentFromBasePoints(8000.0*float64(multiple), fmt.Sprintf("%dx Yakuman", multiple), isWinnerDealer, isTsumo, honba)
+	}
+	basePoints, limitName := regularBasePoints(han, fu, rules.KazoeYakuman)
+	return paymentFromBasePoints(basePoints, limitName, isWinnerDealer, isTsumo, honba)
+}
+
+// YakumanMultiple returns how many Yakuman a hand's Yaku add up to (0 for a regular hand).
+func YakumanMultiple(yaku []YakuResult) int {
+	multiple := 0
+	for _, y := range yaku {
+		multiple += y.Yakuman
+	}
+	return multiple
+}
 
-		basePoints = float64(fu) * math.Pow(2, float64(han+2))
-
-		// Apply Score Limits (Mangan, Haneman, Baiman, Sanbaiman)
-		// These limits apply if the calculated basePoints EXCEED them, OR if Han count dictates them.
-		cappedBasePoints := 0.0
-		if han >= 11 {
-			cappedBasePoints = 6000.0
-			limitName = "Sanbaiman" // 11-12 Han
-		} else if han >= 8 {
-			cappedBasePoints = 4000.0
-			limitName = "Baiman" // 8-10 Han
-		} else if han >= 6 {
-			cappedBasePoints = 3000.0
-			limitName = "Haneman" // 6-7 Han
-		} else if han == 5 || (han == 4 && fu >= 40) || (han == 3 && fu >= 70) {
-			cappedBasePoints = 2000.0
-			limitName = "Mangan"
+// regularBasePoints returns the base points and limit name of a hand without Yakuman.
+// 13+ Han is kazoe Yakuman when kazoeYakuman is set, and Sanbaiman otherwise.
+func regularBasePoints(han, fu int, kazoeYakuman bool) (float64, string) {
+	if han >= 13 {
+		if kazoeYakuman {
+			return 8000.0, "Kazoe Yakuman"
 		}
+		return 6000.0, "Sanbaiman (Kazoe capped)"
+	}
 
-		if cappedBasePoints > 0 { // A limit applies based on Han/Fu combination
-			if basePoints > cappedBasePoints {
-				basePoints = cappedBasePoints
-			}
-			// If basePoints is less but Han dictates a limit (e.g. 5 Han but low Fu), it's still Mangan.
-			if limitName != "" && basePoints < cappedBasePoints && (han == 5 || han == 6 || han == 7 || han == 8 || han == 9 || han == 10 || han == 11 || han == 12) {
-				basePoints = cappedBasePoints // Mangan by Han count
-			}
-		} else if basePoints > 2000.0 { // Calculated points exceed Mangan, but doesn't hit higher limits by Han/Fu
-			basePoints = 2000.0
-			limitName = "Mangan (Capped by points)"
+	limitName := ""
+	// Standard Scoring: Base Points = Fu * 2^(Han + 2)
+	// Ensure minimum Fu, except for Chiitoitsu (25 Fu) and Pinfu (20/30 Fu) which are handled by CalculateFu.
+	if fu < 20 && fu != 0 {
+		fu = 20
+	} // Should be rare if CalculateFu is robust
+	if fu == 25 && han < 2 { /* Chiitoitsu should have at least 2 han from Yaku struct */
+	}
+
+	basePoints := float64(fu) * math.Pow(2, float64(han+2))
+
+	// Apply Score Limits (Mangan, Haneman, Baiman, Sanbaiman)
+	// These limits apply if the calculated basePoints EXCEED them, OR if Han count dictates them.
+	cappedBasePoints := 0.0
+	if han >= 11 {
+		cappedBasePoints = 6000.0
+		limitName = "Sanbaiman" // 11-12 Han
+	} else if han >= 8 {
+		cappedBasePoints = 4000.0
+		limitName = "Baiman" // 8-10 Han
+	} else if han >= 6 {
+		cappedBasePoints = 3000.0
+		limitName = "Haneman" // 6-7 Han
+	} else if han == 5 || (han == 4 && fu >= 40) || (han == 3 && fu >= 70) {
+		cappedBasePoints = 2000.0
+		limitName = "Mangan"
+	}
+
+	if cappedBasePoints > 0 { // A limit applies based on Han/Fu combination
+		if basePoints > cappedBasePoints {
+			basePoints = cappedBasePoints
 		}
-		if limitName == "" { // No specific limit name hit yet
-			limitName = fmt.Sprintf("%d Han, %d Fu", han, fu)
+		// If basePoints is less but Han dictates a limit (e.g. 5 Han but low Fu), it's still Mangan.
+		if limitName != "" && basePoints < cappedBasePoints && (han == 5 || han == 6 || han == 7 || han == 8 || han == 9 || han == 10 || han == 11 || han == 12) {
+			basePoints = cappedBasePoints // Mangan by Han count
 		}
+	} else if basePoints > 2000.0 { // Calculated points exceed Mangan, but doesn't hit higher limits by Han/Fu
+		basePoints = 2000.0
+		limitName = "Mangan (Capped by points)"
+	}
+	if limitName == "" { // No specific limit name hit yet
+		limitName = fmt.Sprintf("%d Han, %d Fu", han, fu)
 	}
 
+	return basePoints, limitName
+}
+
+// paymentFromBasePoints turns base points into what each player pays, rounding up to 100 and
+// adding Honba.
+func paymentFromBasePoints(basePoints float64, limitName string, isWinnerDealer, isTsumo bool, honba int) Payment {
 	// Calculate actual payment amounts, rounding up to nearest 100
 	var ronValue, tsumoDealerPayValue, tsumoNonDealerPayValue int
 
@@ -176,6 +207,7 @@ func CalculatePointPayment(han, fu int, isWinnerDealer, isTsumo bool, honba, rii
 		RonValue:          ronValue,
 		TsumoDealerPay:    tsumoDealerPayValue,
 		TsumoNonDealerPay: tsumoNonDealerPayValue,
+		Honba:             honba,
 	}
 }
 
@@ -183,7 +215,11 @@ func CalculatePointPayment(han, fu int, isWinnerDealer, isTsumo bool, honba, rii
 // discarder is only relevant for Ron.
 func TransferPoints(gs *GameState, winner, discarder *Player, isTsumo bool, payment Payment) {
 	winnerIndex := gs.GetPlayerIndex(winner)
-	isWinnerDealer := (gs.Players[gs.DealerIndexThisRound] == winner)
+
+	// 0. A Riichi declared on the winning discard never stood: its stick goes back to the discarder
+	if !isTsumo && discarder != nil {
+		RefundRiichiDeclaration(gs, discarder)
+	}
 
 	// 1. Collect Riichi Sticks (winner gets all Riichi sticks on the table)
 	if gs.RiichiSticks > 0 {
@@ -194,32 +230,10 @@ func TransferPoints(gs *GameState, winner, discarder *Player, isTsumo bool, paym
 	}
 
 	// 2. Transfer Win Points (Main payment from Yaku/Fu/Han)
-	if isTsumo {
-		totalPaymentReceivedByWinner := 0
-		for i, p := range gs.Players {
-			if i == winnerIndex {
-				continue
-			} // Winner doesn't pay self
-
-			var amountToPay int
-			if isWinnerDealer { // Winner is Dealer, all non-dealers pay TsumoNonDealerPay
-				amountToPay = payment.TsumoNonDealerPay
-			} else { // Winner is Non-Dealer
-				if gs.Players[gs.DealerIndexThisRound] == p { // This payer 'p' is the dealer
-					amountToPay = payment.TsumoDealerPay
-				} else { // This payer 'p' is another non-dealer
-					amountToPay = payment.TsumoNonDealerPay
-				}
-			}
-			gs.AddToGameLog(fmt.Sprintf("%s (P%d) pays %d to %s (P%d) for Tsumo.",
-				p.Name, i+1, amountToPay, winner.Name, winnerIndex+1))
-			p.Score -= amountToPay
-			totalPaymentReceivedByWinner += amountToPay
-			if p.Score < 0 {
-				CheckAndHandleBust(gs, p, winner)
-			}
-		}
-		winner.Score += totalPaymentReceivedByWinner
+	if payment.Pao != nil {
+		transferPaoPoints(gs, winner, discarder, isTsumo, payment)
+	} else if isTsumo {
+		transferTsumoPoints(gs, winner, payment)
 	} else { // Ron
 		if discarder != nil {
 			amountToPay := payment.RonValue
@@ -227,25 +241,217 @@ func TransferPoints(gs *GameState, winner, discarder *Player, isTsumo bool, paym
 				discarder.Name, gs.GetPlayerIndex(discarder)+1, amountToPay, winner.Name, winnerIndex+1))
 			discarder.Score -= amountToPay
 			winner.Score += amountToPay
-			if discarder.Score < 0 {
-				CheckAndHandleBust(gs, discarder, winner)
-			}
+			CheckAndHandleBust(gs, discarder, winner)
 		} else {
 			gs.AddToGameLog(fmt.Sprintf("Error: Discarder was nil during Ron point transfer to %s.", winner.Name))
 		}
 	}
 }
 
-// CheckAndHandleBust handles player busting (score < 0).
+// AcceptRiichiDeclaration completes a pending Riichi once its declaration tile has passed
+// without a Ron. The stick paid on declaration stays on the table.
+func AcceptRiichiDeclaration(gs *GameState, player *Player) {
+	if !player.IsRiichiPending {
+		return
+	}
+	player.IsRiichiPending = false
+	gs.AddToGameLog(fmt.Sprintf("%s's Riichi is accepted.", player.Name))
+}
+
+// RefundRiichiDeclaration cancels a pending Riichi whose declaration tile was Ronned (or
+// aborted the round by Sanchahou): the player gets the stick back and is no longer in Riichi.
+// With double Ron it only refunds once.
+func RefundRiichiDeclaration(gs *GameState, player *Player) {
+	if !player.IsRiichiPending {
+		return
+	}
+	player.IsRiichiPending = false
+	player.IsRiichi = false
+	player.IsIppatsu = false
+	player.Score += RiichiBet
+	if gs.RiichiSticks > 0 {
+		gs.RiichiSticks--
+	}
+	gs.AddToGameLog(fmt.Sprintf("%s's Riichi declaration tile was Ronned; the Riichi stick is returned. Score: %d -> %d.",
+		player.Name, player.Score-RiichiBet, player.Score))
+}
+
+// transferTsumoPoints makes every other player pay their share of a Tsumo payment to the winner.
+func transferTsumoPoints(gs *GameState, winner *Player, payment Payment) {
+	winnerIndex := gs.GetPlayerIndex(winner)
+	isWinnerDealer := (gs.Players[gs.DealerIndexThisRound] == winner)
+	totalPaymentReceivedByWinner := 0
+	for i, p := range gs.Players {
+		if i == winnerIndex {
+			continue
+		} // Winner doesn't pay self
+
+		var amountToPay int
+		if isWinnerDealer { // Winner is Dealer, all non-dealers pay TsumoNonDealerPay
+			amountToPay = payment.TsumoNonDealerPay
+		} else { // Winner is Non-Dealer
+			if gs.Players[gs.DealerIndexThisRound] == p { // This payer 'p' is the dealer
+				amountToPay = payment.TsumoDealerPay
+			} else { // This payer 'p' is another non-dealer
+				amountToPay = payment.TsumoNonDealerPay
+			}
+		}
+		gs.AddToGameLog(fmt.Sprintf("%s (P%d) pays %d to %s (P%d) for Tsumo.",
+			p.Name, i+1, amountToPay, winner.Name, winnerIndex+1))
+		p.Score -= amountToPay
+		totalPaymentReceivedByWinner += amountToPay
+		CheckAndHandleBust(gs, p, winner)
+	}
+	winner.Score += totalPaymentReceivedByWinner
+}
+
+// IsDealerWin reports whether the dealer won the round, alone or as one of several Ron winners.
+func IsDealerWin(gs *GameState) bool {
+	dealer := gs.Players[gs.DealerIndexThisRound]
+	if gs.RoundWinner == dealer {
+		return true
+	}
+	for _, winner := range gs.RoundWinners {
+		if winner == dealer {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBusted reports whether the player's score counts as a bust (tobi) under gs.Rules.
+func IsBusted(gs *GameState, player *Player) bool {
+	if gs.Rules.BustAtZero {
+		return player.Score <= 0
+	}
+	return player.Score < 0
+}
+
+// CheckAndHandleBust handles a player busting on a win payment. If the game ends on a bust,
+// the busted player pays the dobon bonus (if any) to the winner. The bonus is paid once per
+// hand: with double or triple Ron the later winners' payments find the game already ended.
+// handleWins still moves the hand to round end, where the main loop ends the game.
 func CheckAndHandleBust(gs *GameState, bustedPlayer *Player, winner *Player) {
+	if !IsBusted(gs, bustedPlayer) || gs.GamePhase == PhaseGameEnd {
+		return
+	}
 	gs.AddToGameLog(fmt.Sprintf("!!! Player %s (P%d) has busted (score: %d) !!!",
 		bustedPlayer.Name, gs.GetPlayerIndex(bustedPlayer)+1, bustedPlayer.Score))
-	// Basic bust handling: Game ends immediately.
-	// TODO: Implement Tobu/Dobon scoring (winner might get remaining points or a bonus from busting player).
-	// For now, just flag the game to end. The main loop will catch this.
+	if !gs.Rules.BustEndsGame {
+		gs.AddToGameLog(fmt.Sprintf("%s plays on with a negative score.", bustedPlayer.Name))
+		return
+	}
+	if gs.Rules.DobonBonus > 0 && winner != nil && winner != bustedPlayer {
+		bustedPlayer.Score -= gs.Rules.DobonBonus
+		winner.Score += gs.Rules.DobonBonus
+		gs.AddToGameLog(fmt.Sprintf("Dobon: %s pays a %d bonus to %s for the bust.", bustedPlayer.Name, gs.Rules.DobonBonus, winner.Name))
+	}
 	gs.GamePhase = PhaseGameEnd
 }
 
+// PrevalentWindForRound returns the prevalent wind of the given wind round (1 = East, 2 = South, ...).
+// Past North it wraps back to East.
+func PrevalentWindForRound(windRound int) string {
+	winds := []string{"East", "South", "West", "North"}
+	return winds[(windRound-1+len(winds))%len(winds)]
+}
+
+// targetScoreReached reports whether any player has reached gs.Rules.TargetScore.
+func targetScoreReached(gs *GameState) bool {
+	for _, p := range gs.Players {
+		if p.Score >= gs.Rules.TargetScore {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldExtendGame reports whether the game continues past its last programmed round
+// because nobody has reached the target score and an extension wind is allowed.
+func ShouldExtendGame(gs *GameState) bool {
+	return gs.Rules.TargetScore > 0 && gs.Rules.ExtensionWinds > 0 && !targetScoreReached(gs)
+}
+
+// ExtensionRoundEnds decides the end of a hand played in an extension wind (e.g. West).
+// The game ends as soon as anyone has the target score (sudden death), and in any case
+// after the fourth hand of the last extension wind.
+func ExtensionRoundEnds(gs *GameState) bool {
+	if targetScoreReached(gs) {
+		gs.AddToGameLog(fmt.Sprintf("A player has reached %d points in %s %d. Game Over.", gs.Rules.TargetScore, gs.PrevalentWind, gs.RoundNumber))
+		return true
+	}
+	if gs.CurrentWindRoundNumber >= gs.MaxWindRounds+gs.Rules.ExtensionWinds && gs.RoundNumber >= 4 {
+		gs.AddToGameLog(fmt.Sprintf("Extension cut off after %s %d without anyone reaching %d points. Game Over.", gs.PrevalentWind, gs.RoundNumber, gs.Rules.TargetScore))
+		return true
+	}
+	return false
+}
+
+// HandleNagashiMangan pays every Nagashi Mangan at an exhaustive draw, each as a mangan Tsumo,
+// and reports whether there was one. With NagashiAsWin the first such player also takes the
+// Honba and Riichi sticks and becomes the round winner; with NagashiAsDraw the round stays a
+// draw (DrawNagashiMangan) and the sticks stay on the table.
+func HandleNagashiMangan(gs *GameState) bool {
+	if !gs.Rules.DrawEnabled(DrawNagashiMangan) {
+		return false
+	}
+	asWin := gs.Rules.NagashiMode != NagashiAsDraw
+	found := false
+	for _, p := range gs.Players {
+		isNagashi, nagashiName, _ := checkNagashiMangan(p, gs)
+		if !isNagashi {
+			continue
+		}
+		gs.AddToGameLog(fmt.Sprintf("!!! %s achieves %s !!!", p.Name, nagashiName))
+		isWinnerDealer := (gs.Players[gs.DealerIndexThisRound] == p)
+		if asWin && gs.RoundWinner == nil {
+			payment := paymentFromBasePoints(2000, "Nagashi Mangan", isWinnerDealer, true, gs.Honba)
+			TransferPoints(gs, p, nil, true, payment) // Also collects the Riichi sticks
+			gs.RoundWinner = p                        // Nagashi Mangan is a form of win.
+		} else {
+			transferTsumoPoints(gs, p, paymentFromBasePoints(2000, "Nagashi Mangan", isWinnerDealer, true, 0))
+		}
+		found = true
+	}
+	if found && !asWin {
+		gs.DrawType = DrawNagashiMangan
+	}
+	return found
+}
+
+// DrawOutcome reports whether the dealer keeps the deal, and whether a Honba counter is added,
+// after a round drawn as gs.DrawType. p.IsTenpai must be set for DealerKeepsIfTenpai.
+func DrawOutcome(gs *GameState) (dealerKeeps, addHonba bool) {
+	rule := gs.Rules.DrawRule(gs.DrawType)
+	switch rule.Dealer {
+	case DealerKeeps:
+		dealerKeeps = true
+	case DealerKeepsIfTenpai:
+		dealerKeeps = gs.Players[gs.DealerIndexThisRound].IsTenpai
+	}
+	return dealerKeeps, rule.Honba
+}
+
+// RenchanAfterRound reports whether the dealer keeps the deal after the round, and the Honba
+// count for the next round: a dealer win keeps it with one more Honba, another win passes it
+// and resets Honba, a chombo redeals the round as it was, and a draw follows DrawOutcome.
+func RenchanAfterRound(gs *GameState) (dealerKeeps bool, honba int) {
+	if gs.DrawType == DrawChombo {
+		return true, gs.Honba
+	}
+	if IsDealerWin(gs) {
+		return true, gs.Honba + 1
+	}
+	if gs.RoundWinner != nil {
+		return false, 0
+	}
+	dealerKeeps, addHonba := DrawOutcome(gs)
+	if addHonba {
+		return dealerKeeps, gs.Honba + 1
+	}
+	return dealerKeeps, gs.Honba
+}
+
 // HandleNotenBappu processes point transfers for Ryuukyoku (exhaustive draw with no winner).
 func HandleNotenBappu(gs *GameState) {
 	tenpaiPlayers := []*Player{}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransferPoints_RonBustEndsGameWithDobon(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.GamePhase = PhasePlayerTurn
+	gs.Rules.DobonBonus = 10000
+	winner, discarder := gs.Players[1], gs.Players[2]
+	discarder.Score = 7000
+
+	TransferPoints(gs, winner, discarder, false, Payment{RonValue: 8000})
+
+	if discarder.Score != 7000-8000-10000 {
+		t.Errorf("TestTransferPoints_RonBustEndsGameWithDobon: Expected discarder score %d, got %d", 7000-8000-10000, discarder.Score)
+	}
+	if winner.Score != InitialScore+8000+10000 {
+		t.Errorf("TestTransferPoints_RonBustEndsGameWithDobon: Expected winner score %d, got %d", InitialScore+8000+10000, winner.Score)
+	}
+	if gs.GamePhase != PhaseGameEnd {
+		t.Errorf("TestTransferPoints_RonBustEndsGameWithDobon: Expected game to end on bust, got phase %s", gs.GamePhase)
+	}
+}
+
+func TestTransferPoints_DoubleRonPaysDobonOnce(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.GamePhase = PhasePlayerTurn
+	gs.Rules.DobonBonus = 10000
+	first, second, discarder := gs.Players[1], gs.Players[2], gs.Players[3]
+	discarder.Score = 5000
+
+	// handleWins transfers each winner's payment in turn
+	TransferPoints(gs, first, discarder, false, Payment{RonValue: 8000})
+	TransferPoints(gs, second, discarder, false, Payment{RonValue: 3900})
+
+	if discarder.Score != 5000-8000-3900-10000 {
+		t.Errorf("TestTransferPoints_DoubleRonPaysDobonOnce: Expected discarder score %d, got %d", 5000-8000-3900-10000, discarder.Score)
+	}
+	if first.Score != InitialScore+8000+10000 {
+		t.Errorf("TestTransferPoints_DoubleRonPaysDobonOnce: Expected first winner score %d, got %d", InitialScore+8000+10000, first.Score)
+	}
+	if second.Score != InitialScore+3900 {
+		t.Errorf("TestTransferPoints_DoubleRonPaysDobonOnce: Expected second winner score %d (no dobon), got %d", InitialScore+3900, second.Score)
+	}
+	if gs.GamePhase != PhaseGameEnd {
+		t.Errorf("TestTransferPoints_DoubleRonPaysDobonOnce: Expected game to end on bust, got phase %s", gs.GamePhase)
+	}
+}
+
+func TestTransferPoints_BustAtZero(t *testing.T) {
+	for _, bustAtZero := range []bool{false, true} {
+		gs := createTestGameState(nil)
+		gs.GamePhase = PhasePlayerTurn
+		gs.Rules.BustAtZero = bustAtZero
+		winner, discarder := gs.Players[0], gs.Players[3]
+		discarder.Score = 8000
+
+		TransferPoints(gs, winner, discarder, false, Payment{RonValue: 8000})
+
+		if ended := gs.GamePhase == PhaseGameEnd; ended != bustAtZero {
+			t.Errorf("TestTransferPoints_BustAtZero: With BustAtZero=%v, expected game end %v at 0 points, got %v", bustAtZero, bustAtZero, ended)
+		}
+	}
+}
+
+func TestTransferPoints_PlayOnWithNegativeScore(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.GamePhase = PhasePlayerTurn
+	gs.Rules.BustEndsGame = false
+	gs.Rules.DobonBonus = 10000
+	winner := gs.Players[0]
+	gs.DealerIndexThisRound = 0
+	for _, p := range gs.Players[1:] {
+		p.Score = 2000
+	}
+
+	TransferPoints(gs, winner, nil, true, Payment{TsumoNonDealerPay: 4000})
+
+	for _, p := range gs.Players[1:] {
+		if p.Score != -2000 {
+			t.Errorf("TestTransferPoints_PlayOnWithNegativeScore: Expected %s at -2000 with no dobon, got %d", p.Name, p.Score)
+		}
+	}
+	if gs.GamePhase != PhasePlayerTurn {
+		t.Errorf("TestTransferPoints_PlayOnWithNegativeScore: Expected play to continue, got phase %s", gs.GamePhase)
+	}
+}
+
+func TestCanDeclareRiichi_BelowZeroRule(t *testing.T) {
+	gs := createTestGameState(nil)
+	player := gs.Players[0]
+	player.Hand = TilesFromString("1m 2m 3m 4p 5p 6p 7s 8s 9s E E S S W")
+	player.Score = 500
+
+	if ok, _ := CanDeclareRiichi(player, gs); ok {
+		t.Errorf("TestCanDeclareRiichi_BelowZeroRule: Expected Riichi refused with 500 points")
+	}
+	gs.Rules.RiichiBelowZero = true
+	if ok, _ := CanDeclareRiichi(player, gs); !ok {
+		t.Errorf("TestCanDeclareRiichi_BelowZeroRule: Expected Riichi allowed with 500 points when RiichiBelowZero is set")
+	}
+}
+
+func TestShouldExtendGame_TargetScore(t *testing.T) {
+	gs := createTestGameState(nil) // Everyone on 25000, target 30000
+	if !ShouldExtendGame(gs) {
+		t.Errorf("TestShouldExtendGame_TargetScore: Expected the game to extend when nobody has 30000")
+	}
+	gs.Players[2].Score = 30000
+	if ShouldExtendGame(gs) {
+		t.Errorf("TestShouldExtendGame_TargetScore: Expected no extension once a player has 30000")
+	}
+	gs.Players[2].Score = 25000
+	gs.Rules.ExtensionWinds = 0
+	if ShouldExtendGame(gs) {
+		t.Errorf("TestShouldExtendGame_TargetScore: Expected no extension with ExtensionWinds 0")
+	}
+}
+
+func TestExtensionRoundEnds_SuddenDeathAndCutoff(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.CurrentWindRoundNumber = 3
+	gs.PrevalentWind = PrevalentWindForRound(3)
+	gs.RoundNumber = 2
+
+	if ExtensionRoundEnds(gs) {
+		t.Errorf("TestExtensionRoundEnds_SuddenDeathAndCutoff: Expected West 2 to continue when nobody has the target")
+	}
+	gs.Players[1].Score = 31000
+	if !ExtensionRoundEnds(gs) {
+		t.Errorf("TestExtensionRoundEnds_SuddenDeathAndCutoff: Expected the game to end once someone reaches the target")
+	}
+	gs.Players[1].Score = 25000
+	gs.RoundNumber = 4
+	if !ExtensionRoundEnds(gs) {
+		t.Errorf("TestExtensionRoundEnds_SuddenDeathAndCutoff: Expected the game to be cut off after West 4")
+	}
+	if gs.PrevalentWind != "West" || PrevalentWindForRound(5) != "East" {
+		t.Errorf("TestExtensionRoundEnds_SuddenDeathAndCutoff: Expected wind round 3 to be West and 5 to wrap to East, got %s and %s", gs.PrevalentWind, PrevalentWindForRound(5))
+	}
+}
+
+func TestCalculateWinPayment_ExplicitYakumanMultiples(t *testing.T) {
+	rules := DefaultRuleSet()
+	doubleYakuman := []YakuResult{{Name: "Daisangen", Han: 13, Yakuman: 1}, {Name: "Tsuuiisou", Han: 13, Yakuman: 1}}
+
+	if payment := CalculateWinPayment(doubleYakuman, 26, 0, rules, false, false, 0); payment.RonValue != 64000 {
+		t.Errorf("TestCalculateWinPayment_ExplicitYakumanMultiples: Expected a non-dealer double yakuman Ron to pay 64000, got %d (%s)", payment.RonValue, payment.Description)
+	}
+	rules.YakumanCap = 1
+	if payment := CalculateWinPayment(doubleYakuman, 26, 0, rules, false, false, 0); payment.RonValue != 32000 {
+		t.Errorf("TestCalculateWinPayment_ExplicitYakumanMultiples: Expected YakumanCap 1 to limit the Ron to 32000, got %d", payment.RonValue)
+	}
+}
+
+func TestCalculateWinPayment_KazoeLimit(t *testing.T) {
+	rules := DefaultRuleSet()
+	kazoe := []YakuResult{{Name: "Riichi", Han: 1}, {Name: "Chinitsu", Han: 6}, {Name: "Dora 6", Han: 6}}
+
+	if payment := CalculateWinPayment(kazoe, 13, 40, rules, false, false, 0); payment.RonValue != 32000 {
+		t.Errorf("TestCalculateWinPayment_KazoeLimit: Expected 13 Han to be a kazoe yakuman (32000), got %d (%s)", payment.RonValue, payment.Description)
+	}
+	rules.KazoeYakuman = false
+	if payment := CalculateWinPayment(kazoe, 13, 40, rules, false, false, 0); payment.RonValue != 24000 {
+		t.Errorf("TestCalculateWinPayment_KazoeLimit: Expected 13 Han capped at Sanbaiman (24000), got %d (%s)", payment.RonValue, payment.Description)
+	}
+}
+
+func TestCanDeclareRiichi_BustAtZeroNeedsMoreThan1000(t *testing.T) {
+	gs := createTestGameState(nil)
+	player := gs.Players[0]
+	player.Hand = TilesFromString("1m 2m 3m 4p 5p 6p 7s 8s 9s E E S S W")
+	player.Score = RiichiBet
+
+	if ok, _ := CanDeclareRiichi(player, gs); !ok {
+		t.Errorf("TestCanDeclareRiichi_BustAtZeroNeedsMoreThan1000: Expected Riichi allowed with exactly 1000 points")
+	}
+	gs.Rules.BustAtZero = true
+	if ok, _ := CanDeclareRiichi(player, gs); ok {
+		t.Errorf("TestCanDeclareRiichi_BustAtZeroNeedsMoreThan1000: Expected Riichi refused with exactly 1000 points when 0 is a bust")
+	}
+	gs.Rules.RiichiBelowZero = true
+	if ok, _ := CanDeclareRiichi(player, gs); !ok {
+		t.Errorf("TestCanDeclareRiichi_BustAtZeroNeedsMoreThan1000: Expected Riichi allowed with RiichiBelowZero")
+	}
+}
+
+func TestRenchanAfterRound_DrawRules(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.Honba = 2
+	gs.Players[0].IsTenpai = false // Dealer Noten
+
+	gs.DrawType = DrawExhaustive
+	if keeps, honba := RenchanAfterRound(gs); keeps || honba != 3 {
+		t.Errorf("TestRenchanAfterRound_DrawRules: Expected a Noten dealer to pass the deal with Honba 3 at Ryuukyoku, got keeps=%v honba=%d", keeps, honba)
+	}
+	gs.DrawType = DrawKyuushuuKyuuhai
+	if keeps, honba := RenchanAfterRound(gs); !keeps || honba != 3 {
+		t.Errorf("TestRenchanAfterRound_DrawRules: Expected the dealer to keep the deal with Honba 3 after Kyuushuu Kyuuhai, got keeps=%v honba=%d", keeps, honba)
+	}
+	gs.Rules.Draws[DrawKyuushuuKyuuhai] = DrawRule{Enabled: true, Dealer: DealerPasses, Honba: false}
+	if keeps, honba := RenchanAfterRound(gs); keeps || honba != 2 {
+		t.Errorf("TestRenchanAfterRound_DrawRules: Expected the configured rule to pass the deal without Honba, got keeps=%v honba=%d", keeps, honba)
+	}
+
+	gs.RoundWinner = gs.Players[1]
+	if keeps, honba := RenchanAfterRound(gs); keeps || honba != 0 {
+		t.Errorf("TestRenchanAfterRound_DrawRules: Expected a non-dealer win to pass the deal and reset Honba, got keeps=%v honba=%d", keeps, honba)
+	}
+}
+
+func TestHandleNagashiMangan_Modes(t *testing.T) {
+	for _, mode := range []string{NagashiAsWin, NagashiAsDraw} {
+		gs := createTestGameState(nil)
+		gs.Rules.NagashiMode = mode
+		gs.Honba = 1
+		gs.RiichiSticks = 1
+		gs.DrawType = DrawExhaustive
+		nagashi := gs.Players[1]
+		nagashi.Discards = riverFromString("1m 9p E S w")
+		gs.Players[2].Discards = riverFromString("1m 5p")
+
+		if !HandleNagashiMangan(gs) {
+			t.Fatalf("TestHandleNagashiMangan_Modes: Expected a Nagashi Mangan for %s (%s mode)", nagashi.Name, mode)
+		}
+		if mode == NagashiAsWin && (gs.RoundWinner != nagashi || nagashi.Score != InitialScore+8000+300+RiichiBet || gs.RiichiSticks != 0) {
+			t.Errorf("TestHandleNagashiMangan_Modes: Expected a mangan Tsumo win with Honba and Riichi stick, got winner=%v score=%d sticks=%d",
+				gs.RoundWinner != nil, nagashi.Score, gs.RiichiSticks)
+		}
+		if mode == NagashiAsDraw && (gs.RoundWinner != nil || gs.DrawType != DrawNagashiMangan || nagashi.Score != InitialScore+8000 || gs.RiichiSticks != 1) {
+			t.Errorf("TestHandleNagashiMangan_Modes: Expected a special draw paying 8000 and leaving the stick, got winner=%v draw=%q score=%d sticks=%d",
+				gs.RoundWinner != nil, gs.DrawType, nagashi.Score, gs.RiichiSticks)
+		}
+	}
+
+	gs := createTestGameState(nil)
+	gs.Players[1].Discards = riverFromString("1m 9p E S w")
+	gs.Rules.Draws[DrawNagashiMangan] = DrawRule{Enabled: false}
+	if HandleNagashiMangan(gs) {
+		t.Errorf("TestHandleNagashiMangan_Modes: Expected no Nagashi Mangan when it is switched off")
+	}
+}
+
+func TestCheckNagashiMangan_CalledDiscard(t *testing.T) {
+	gs := createTestGameState(nil)
+	player := gs.Players[1]
+	player.Discards = riverFromString("1m 9m E r")
+	if ok, _, _ := checkNagashiMangan(player, gs); !ok {
+		t.Fatalf("TestCheckNagashiMangan_CalledDiscard: Expected Nagashi Mangan with only terminal and honor discards")
+	}
+
+	player.Discards[2].Called = true // East taken by a Pon, still in the river
+	if ok, _, _ := checkNagashiMangan(player, gs); ok {
+		t.Errorf("TestCheckNagashiMangan_CalledDiscard: Expected no Nagashi Mangan once a discard was called")
+	}
+
+	player.Discards[2].Called = false
+	player.Discards = append(player.Discards, riverFromString("5s")...)
+	if ok, _, _ := checkNagashiMangan(player, gs); ok {
+		t.Errorf("TestCheckNagashiMangan_CalledDiscard: Expected no Nagashi Mangan with a simple in the river")
+	}
+}
+
+// riverFromString builds a river of tedashi discards, in the given order, for tests.
+func riverFromString(s string) []Discard {
+	river := []Discard{}
+	for i, token := range strings.Fields(s) {
+		tile := TilesFromString(token)[0]
+		tile.ID = 500 + i
+		river = append(river, Discard{Tile: tile, Turn: 4 * i})
+	}
+	return river
+}
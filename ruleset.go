@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// RuleSet collects the table rules that differ between clubs, leagues and tournaments.
+// NewGameState uses DefaultRuleSet(); pass a custom one to NewGameStateWithRules, or load one
+// from a JSON file with LoadRuleSet (the -rules flag of every game command).
+type RuleSet struct {
+	// --- Final Scoring ---
+	StartingPoints int    `json:"startingPoints"` // Points each player starts the game with
+	ReturnPoints   int    `json:"returnPoints"`   // Genten: points subtracted from each final score before uma is added
+	Oka            int    `json:"oka"`            // Bonus for first place, in points (normally 4 * (ReturnPoints - StartingPoints))
+	Uma            [4]int `json:"uma"`            // Placement bonus in points for 1st to 4th, e.g. {30000, 10000, -10000, -30000}
+
+	// --- Tobi (Bust) ---
+	BustEndsGame    bool `json:"bustEndsGame"`    // A busted player ends the game; false plays on with negative scores
+	BustAtZero      bool `json:"bustAtZero"`      // Exactly 0 points is a bust; otherwise only a negative score is
+	RiichiBelowZero bool `json:"riichiBelowZero"` // Allow Riichi with fewer than 1000 points: the bet takes the score below zero (or further below when playing on)
+	DobonBonus      int  `json:"dobonBonus"`      // Paid by a busted player to the winner who busted them (0 for none)
+
+	// --- Extension (West Round) ---
+	TargetScore    int `json:"targetScore"`    // If nobody has this many points after the last programmed round, play on (0 to disable)
+	ExtensionWinds int `json:"extensionWinds"` // How many extra prevalent winds may be played before the game is cut off
+
+	// --- Yaku ---
+	LocalYaku    []string             `json:"localYaku"`    // Enabled local yaku (see AllLocalYaku in local_yaku.go); none by default
+	DisabledYaku []string             `json:"disabledYaku"` // Yaku never awarded, by registry name (see yaku_registry.go)
+	YakuHan      map[string]YakuValue `json:"yakuHan"`      // Revalued yaku, by registry name, e.g. {"Chiitoitsu": {"closed": 1}}
+
+	// --- Yakuman ---
+	StackLuckYakuman bool     `json:"stackLuckYakuman"` // Tenhou/Chihou/Renhou combine with structural yakuman (Tenhou + Daisangen is a double)
+	DoubleYakuman    []string `json:"doubleYakuman"`    // Variants scored as double yakuman (see DoubleYakumanVariants); the rest count once
+	KazoeYakuman     bool     `json:"kazoeYakuman"`     // 13+ Han from regular yaku is a yakuman; false caps it at Sanbaiman
+	YakumanCap       int      `json:"yakumanCap"`       // Most yakuman a single hand is paid for (0 for no limit)
+
+	// --- Pao (Liability) ---
+	SuukantsuPao bool `json:"suukantsuPao"` // The discarder whose tile made the fourth Kan (Daiminkan) is liable for Suukantsu
+	RinshanPao   bool `json:"rinshanPao"`   // The discarder of a Daiminkan tile pays a Rinshan Kaihou won on its replacement draw
+
+	// --- Dora ---
+	KanDoraTiming string `json:"kanDoraTiming"` // When a Kan's dora indicator is turned: KanDoraImmediate, KanDoraDelayed or KanDoraAfterDiscard ("" is KanDoraImmediate)
+	KanUra        bool   `json:"kanUra"`        // A Riichi winner also gets ura dora under each Kan dora indicator; false reads only the first ura indicator
+
+	// --- Calls ---
+	ForbidGenbutsuKuikae bool `json:"forbidGenbutsuKuikae"` // After a Chi or Pon, the called tile type may not be discarded on the same turn
+	ForbidSujiKuikae     bool `json:"forbidSujiKuikae"`     // After a ryanmen Chi, the tile three away from the called one (e.g. 1m after calling 4m for 234m) may not be discarded either
+
+	// --- Ron ---
+	MultipleRon int `json:"multipleRon"` // Most players who win on one discard: 1 = atamahane (closest only), 2 = double Ron, 3 = triple Ron instead of the Sanchahou abort
+
+	// --- Draws ---
+	Draws       map[string]DrawRule `json:"draws"`       // Per draw type (DrawExhaustive, DrawKyuushuuKyuuhai, ...); missing types use DefaultDrawRules
+	NagashiMode string              `json:"nagashiMode"` // NagashiAsWin or NagashiAsDraw ("" is NagashiAsWin)
+
+	// --- Chombo (Penalties) ---
+	ManualDeclarations bool   `json:"manualDeclarations"` // The human's Ron, Tsumo and Riichi are offered unchecked; a wrong one is a chombo (see chombo.go)
+	ChomboPenalty      string `json:"chomboPenalty"`      // ChomboMangan or ChomboGameEnd ("" is ChomboMangan)
+	ChomboPoints       int    `json:"chomboPoints"`       // Placement points each chombo costs at the end of the game with ChomboGameEnd
+}
+
+// Kan dora timings (RuleSet.KanDoraTiming). An Ankan always turns its indicator at once.
+const (
+	KanDoraImmediate    = "immediate"     // Every Kan turns its indicator with the Rinshan draw
+	KanDoraDelayed      = "delayed"       // Daiminkan/Shouminkan: once the replacement discard is made, or before a Rinshan Kaihou is scored
+	KanDoraAfterDiscard = "after-discard" // Daiminkan/Shouminkan: only once the replacement discard is made, so a Rinshan Kaihou on one does not see it
+)
+
+// Draw types: how a round ended without a win. All but DrawExhaustive and DrawNagashiMangan are
+// abortive draws (tochuu ryuukyoku).
+const (
+	DrawExhaustive      = "Ryuukyoku"
+	DrawKyuushuuKyuuhai = "Kyuushuu Kyuuhai"
+	DrawSuufonRenda     = "Suufon Renda"
+	DrawSuuchaRiichi    = "Suucha Riichi"
+	DrawSuukaikan       = "Suukaikan"
+	DrawSanchahou       = "Sanchahou"
+	DrawNagashiMangan   = "Nagashi Mangan"
+)
+
+// What happens to the deal after a draw (DrawRule.Dealer).
+const (
+	DealerKeeps         = "keep"   // The dealer keeps the deal (renchan)
+	DealerKeepsIfTenpai = "tenpai" // The dealer keeps the deal only if tenpai
+	DealerPasses        = "pass"   // The deal passes to the next player
+)
+
+// Nagashi Mangan modes (RuleSet.NagashiMode).
+const (
+	NagashiAsWin  = "win"  // Paid like a mangan Tsumo with Honba and Riichi sticks, and scored as a win for renchan
+	NagashiAsDraw = "draw" // A yaku-less special draw: mangan Tsumo payments only, no Noten Bappu, DrawRule decides the deal
+)
+
+// DrawRule says whether a draw type is played and what follows it. Enabled cannot switch off
+// DrawExhaustive; Dealer and Honba do not apply to Nagashi Mangan played as a win.
+type DrawRule struct {
+	Enabled bool   `json:"enabled"` // The draw is declared (otherwise play goes on, e.g. a fourth Riichi is just a Riichi)
+	Dealer  string `json:"dealer"`  // DealerKeeps, DealerKeepsIfTenpai or DealerPasses
+	Honba   bool   `json:"honba"`   // A Honba counter is added, whoever deals next
+}
+
+// DefaultDrawRules returns the usual rules: every draw type is played, an exhaustive draw or
+// Nagashi Mangan keeps the deal with a tenpai dealer, and an abortive draw always keeps it.
+// A Honba counter is added after every draw.
+func DefaultDrawRules() map[string]DrawRule {
+	return map[string]DrawRule{
+		DrawExhaustive:      {Enabled: true, Dealer: DealerKeepsIfTenpai, Honba: true},
+		DrawKyuushuuKyuuhai: {Enabled: true, Dealer: DealerKeeps, Honba: true},
+		DrawSuufonRenda:     {Enabled: true, Dealer: DealerKeeps, Honba: true},
+		DrawSuuchaRiichi:    {Enabled: true, Dealer: DealerKeeps, Honba: true},
+		DrawSuukaikan:       {Enabled: true, Dealer: DealerKeeps, Honba: true},
+		DrawSanchahou:       {Enabled: true, Dealer: DealerKeeps, Honba: true},
+		DrawNagashiMangan:   {Enabled: true, Dealer: DealerKeepsIfTenpai, Honba: true},
+	}
+}
+
+// DefaultRuleSet returns the rules used by a normal game: 25000 start, 30000 return,
+// 20000 oka to first place, +30/+10/-10/-30 uma, the game ending when a score goes below zero,
+// a West round (ending as soon as someone has 30000) if nobody reaches 30000 by South 4,
+// Kan dora turned at once with kan ura, all double yakuman variants, kazoe yakuman, kuikae
+// (swap-calling) allowed, atamahane (one Ron winner per discard), and every draw type with
+// DefaultDrawRules and Nagashi Mangan paid as a win. Declarations are checked before they are
+// offered; with ManualDeclarations a chombo pays a mangan (or 20000 at game end).
+func DefaultRuleSet() RuleSet {
+	return RuleSet{
+		StartingPoints: InitialScore,
+		ReturnPoints:   30000,
+		Oka:            4 * (30000 - InitialScore),
+		Uma:            [4]int{30000, 10000, -10000, -30000},
+
+		BustEndsGame: true,
+
+		TargetScore:    30000,
+		ExtensionWinds: 1,
+
+		KanDoraTiming: KanDoraImmediate,
+		KanUra:        true,
+
+		DoubleYakuman: append([]string(nil), DoubleYakumanVariants...),
+		KazoeYakuman:  true,
+
+		MultipleRon: 1,
+
+		Draws:       DefaultDrawRules(),
+		NagashiMode: NagashiAsWin,
+
+		ChomboPenalty: ChomboMangan,
+		ChomboPoints:  20000,
+	}
+}
+
+// LoadRuleSet reads a RuleSet from a JSON file using the field names of the struct tags, e.g.
+// {"uma": [15000, 5000, -5000, -15000], "bustAtZero": true}. Fields the file leaves out keep
+// their DefaultRuleSet values, except that a missing "oka" follows the file's starting and return
+// points. An empty path gives DefaultRuleSet().
+func LoadRuleSet(path string) (RuleSet, error) {
+	rules := DefaultRuleSet()
+	if path == "" {
+		return rules, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rules, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields() // A misspelt rule would otherwise be silently left at its default
+	if err := decoder.Decode(&rules); err != nil {
+		return rules, fmt.Errorf("reading rules file %s: %w", path, err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return rules, fmt.Errorf("reading rules file %s: %w", path, err)
+	}
+	if _, ok := fields["oka"]; !ok {
+		rules.Oka = 4 * (rules.ReturnPoints - rules.StartingPoints)
+	}
+	if err := mergeDrawRules(&rules, fields["draws"]); err != nil {
+		return rules, fmt.Errorf("reading rules file %s: %w", path, err)
+	}
+	if err := rules.Validate(); err != nil {
+		return rules, fmt.Errorf("rules file %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// mergeDrawRules lays each draw in the file's "draws" object over its DefaultDrawRules entry, so
+// {"Kyuushuu Kyuuhai": {"dealer": "pass"}} changes only the dealer and keeps the draw enabled.
+func mergeDrawRules(rules *RuleSet, data json.RawMessage) error {
+	if data == nil {
+		return nil
+	}
+	var draws map[string]json.RawMessage
+	if err := json.Unmarshal(data, &draws); err != nil {
+		return err
+	}
+	defaults := DefaultDrawRules()
+	for name, raw := range draws {
+		rule := defaults[name]
+		if err := json.Unmarshal(raw, &rule); err != nil {
+			return fmt.Errorf("draw %q: %w", name, err)
+		}
+		rules.Draws[name] = rule
+	}
+	return nil
+}
+
+// Validate reports the first setting a game cannot be played with.
+func (r RuleSet) Validate() error {
+	total := 0
+	for _, u := range r.Uma {
+		total += u
+	}
+	if total != 0 {
+		return fmt.Errorf("uma must sum to zero, got %s", FormatPlacementPoints(total))
+	}
+	if r.MultipleRon < 1 || r.MultipleRon > 3 {
+		return fmt.Errorf("multipleRon must be 1, 2 or 3, got %d", r.MultipleRon)
+	}
+	defaultDraws := DefaultDrawRules()
+	for name, rule := range r.Draws {
+		if _, ok := defaultDraws[name]; !ok {
+			return fmt.Errorf("unknown draw %q", name)
+		}
+		if !contains([]string{DealerKeeps, DealerKeepsIfTenpai, DealerPasses}, rule.Dealer) {
+			return fmt.Errorf("draw %q: dealer must be %q, %q or %q, got %q", name, DealerKeeps, DealerKeepsIfTenpai, DealerPasses, rule.Dealer)
+		}
+	}
+	if !contains([]string{"", NagashiAsWin, NagashiAsDraw}, r.NagashiMode) {
+		return fmt.Errorf("nagashiMode must be %q or %q, got %q", NagashiAsWin, NagashiAsDraw, r.NagashiMode)
+	}
+	if !contains([]string{"", KanDoraImmediate, KanDoraDelayed, KanDoraAfterDiscard}, r.KanDoraTiming) {
+		return fmt.Errorf("kanDoraTiming must be %q, %q or %q, got %q", KanDoraImmediate, KanDoraDelayed, KanDoraAfterDiscard, r.KanDoraTiming)
+	}
+	if !contains([]string{"", ChomboMangan, ChomboGameEnd}, r.ChomboPenalty) {
+		return fmt.Errorf("chomboPenalty must be %q or %q, got %q", ChomboMangan, ChomboGameEnd, r.ChomboPenalty)
+	}
+	for _, name := range r.LocalYaku {
+		if !contains(AllLocalYaku, name) {
+			return fmt.Errorf("unknown local yaku %q (known: %s)", name, strings.Join(AllLocalYaku, ", "))
+		}
+	}
+	for _, name := range r.DoubleYakuman {
+		if !contains(DoubleYakumanVariants, name) {
+			return fmt.Errorf("unknown double yakuman variant %q (known: %s)", name, strings.Join(DoubleYakumanVariants, ", "))
+		}
+	}
+	return nil
+}
+
+// addRulesFlag adds the -rules flag shared by the commands that start games.
+func addRulesFlag(flags *flag.FlagSet) *string {
+	return flags.String("rules", "", "JSON file with the table rules (RuleSet fields; omitted ones keep their defaults)")
+}
+
+// mustLoadRuleSet loads the -rules file, exiting with the error if it cannot be used.
+func mustLoadRuleSet(path string) RuleSet {
+	rules, err := LoadRuleSet(path)
+	if err != nil {
+		fmt.Println("Rules error:", err)
+		os.Exit(2)
+	}
+	return rules
+}
+
+// DrawRule returns the rule for a draw type, falling back to DefaultDrawRules.
+func (r RuleSet) DrawRule(drawType string) DrawRule {
+	if rule, ok := r.Draws[drawType]; ok {
+		return rule
+	}
+	return DefaultDrawRules()[drawType]
+}
+
+// DrawEnabled reports whether the draw type is played.
+func (r RuleSet) DrawEnabled(drawType string) bool {
+	return drawType == DrawExhaustive || r.DrawRule(drawType).Enabled
+}
+
+// LocalYakuEnabled reports whether the named local yaku is switched on.
+func (r RuleSet) LocalYakuEnabled(name string) bool {
+	return contains(r.LocalYaku, name)
+}
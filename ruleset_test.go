@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeRulesFile saves content as a rules file in a temporary directory.
+func writeRulesFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writeRulesFile: %v", err)
+	}
+	return path
+}
+
+func TestLoadRuleSet_KeepsDefaultsForOmittedFields(t *testing.T) {
+	rules, err := LoadRuleSet(writeRulesFile(t, `{"uma": [15000, 5000, -5000, -15000], "startingPoints": 30000}`))
+	if err != nil {
+		t.Fatalf("TestLoadRuleSet_KeepsDefaultsForOmittedFields: Expected no error, got %v", err)
+	}
+	if rules.Uma != [4]int{15000, 5000, -5000, -15000} || rules.StartingPoints != 30000 {
+		t.Errorf("TestLoadRuleSet_KeepsDefaultsForOmittedFields: Expected the file's uma and starting points, got %v and %d", rules.Uma, rules.StartingPoints)
+	}
+	if rules.ReturnPoints != 30000 || rules.Oka != 0 {
+		t.Errorf("TestLoadRuleSet_KeepsDefaultsForOmittedFields: Expected the default 30000 return and no oka with a 30000 start, got %d and %d", rules.ReturnPoints, rules.Oka)
+	}
+
+	rules, err = LoadRuleSet("")
+	if err != nil || rules.Oka != DefaultRuleSet().Oka {
+		t.Errorf("TestLoadRuleSet_KeepsDefaultsForOmittedFields: Expected DefaultRuleSet without a file, got %+v (err %v)", rules, err)
+	}
+}
+
+func TestLoadRuleSet_RejectsBadFiles(t *testing.T) {
+	for _, content := range []string{
+		`{"uma": [30000, 10000, -10000, -20000]}`,      // Does not sum to zero
+		`{"returnPoint": 30000}`,                       // Misspelt field
+		`{"doubleYakuman": ["Daisangen"]}`,             // Not a double yakuman variant
+		`{"multipleRon": 4}`,                           // At most triple Ron
+		`{"draws": {"Suukantsu": {"enabled": false}}}`, // Not a draw type (Suukaikan is)
+		`{"draws": {"Sanchahou": {"dealer": "stay"}}}`, // Unknown dealer rule
+		`{"nagashiMode": "mangan"}`,
+		`{"kanDoraTiming": "later"}`,
+		`{"chomboPenalty": "yakuman"}`,
+		`{"uma": [30000, 10000]`, // Not JSON
+	} {
+		if _, err := LoadRuleSet(writeRulesFile(t, content)); err == nil {
+			t.Errorf("TestLoadRuleSet_RejectsBadFiles: Expected an error for %s", content)
+		}
+	}
+	if _, err := LoadRuleSet(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("TestLoadRuleSet_RejectsBadFiles: Expected an error for a missing file")
+	}
+}
+
+func TestLoadRuleSet_MergesDrawSettings(t *testing.T) {
+	path := writeRulesFile(t, `{"draws": {"Suucha Riichi": {"enabled": false}, "Kyuushuu Kyuuhai": {"dealer": "pass", "honba": false}}}`)
+	rules, err := LoadRuleSet(path)
+	if err != nil {
+		t.Fatalf("TestLoadRuleSet_MergesDrawSettings: Expected no error, got %v", err)
+	}
+	if got := rules.DrawRule(DrawSuuchaRiichi); got.Enabled || got.Dealer != DealerKeeps || !got.Honba {
+		t.Errorf("TestLoadRuleSet_MergesDrawSettings: Expected Suucha Riichi off with its default dealer and Honba, got %+v", got)
+	}
+	if got := rules.DrawRule(DrawKyuushuuKyuuhai); !got.Enabled || got.Dealer != DealerPasses || got.Honba {
+		t.Errorf("TestLoadRuleSet_MergesDrawSettings: Expected Kyuushuu Kyuuhai played with the deal passing and no Honba, got %+v", got)
+	}
+	if got := rules.DrawRule(DrawExhaustive); got != DefaultDrawRules()[DrawExhaustive] {
+		t.Errorf("TestLoadRuleSet_MergesDrawSettings: Expected the exhaustive draw left at its default, got %+v", got)
+	}
+}
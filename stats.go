@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+// DefaultStatsFile is the statistics file the stats command reads. Games only add to statistics
+// when their command is given a -stats file.
+const DefaultStatsFile = "stats.json"
+
+// ErrGameCounted is returned by UpdateStats for a game record that is already in the statistics.
+var ErrGameCounted = errors.New("game record already counted in the statistics")
+
+// PlayerStats are one player's totals over every recorded game.
+type PlayerStats struct {
+	Name         string         `json:"name"`
+	Games        int            `json:"games"`
+	Hands        int            `json:"hands"`
+	Wins         int            `json:"wins"`
+	DealIns      int            `json:"dealIns"`
+	CallHands    int            `json:"callHands"`   // Hands with at least one open call
+	RiichiHands  int            `json:"riichiHands"` // Hands with a Riichi declaration
+	WinPoints    int            `json:"winPoints"`   // Points gained over the hands won
+	DealInPoints int            `json:"dealInPoints"`
+	RiichiTurns  int            `json:"riichiTurns"` // Sum of the Riichi turns, for the average
+	Places       [4]int         `json:"places"`      // How many games finished 1st to 4th
+	Yaku         map[string]int `json:"yaku"`        // How many wins had each yaku
+}
+
+// Stats maps player names to their statistics.
+type Stats struct {
+	Players map[string]*PlayerStats `json:"players"`
+	GameIDs []string                `json:"gameIds"` // IDs of the game records added, so none is counted twice
+}
+
+// LoadStats reads a stats file saved by Save; a missing file gives empty statistics.
+func LoadStats(path string) (*Stats, error) {
+	stats := &Stats{Players: make(map[string]*PlayerStats)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, fmt.Errorf("reading stats file %s: %w", path, err)
+	}
+	return stats, nil
+}
+
+// Save writes the statistics as JSON.
+func (s *Stats) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// player returns the named player's statistics, adding them if new.
+func (s *Stats) player(name string) *PlayerStats {
+	p, ok := s.Players[name]
+	if !ok {
+		p = &PlayerStats{Name: name, Yaku: make(map[string]int)}
+		s.Players[name] = p
+	}
+	return p
+}
+
+// AddGame adds every seat's hands and placement in record. It returns false, adding nothing, if
+// a record with the same ID was added before (records without an ID are always added).
+func (s *Stats) AddGame(record *GameRecord) bool {
+	if record.ID != "" {
+		for _, id := range s.GameIDs {
+			if id == record.ID {
+				return false
+			}
+		}
+		s.GameIDs = append(s.GameIDs, record.ID)
+	}
+	for _, hand := range record.Hands {
+		for _, seat := range hand.Seats {
+			p := s.player(seat.Name)
+			p.Hands++
+			if seat.Won {
+				p.Wins++
+				p.WinPoints += seat.ScoreChange
+				for _, yaku := range seat.Yaku {
+					p.Yaku[yaku]++
+				}
+			}
+			if seat.DealtIn {
+				p.DealIns++
+				p.DealInPoints -= seat.ScoreChange
+			}
+			if seat.Called {
+				p.CallHands++
+			}
+			if seat.Riichi {
+				p.RiichiHands++
+				p.RiichiTurns += seat.RiichiTurn
+			}
+		}
+	}
+	for _, placement := range record.Placements {
+		p := s.player(placement.Name)
+		p.Games++
+		if placement.Place >= 1 && placement.Place <= 4 {
+			p.Places[placement.Place-1]++
+		}
+	}
+	return true
+}
+
+// UpdateStats adds record to the statistics in path, or returns ErrGameCounted if it is
+// already there.
+func UpdateStats(path string, record *GameRecord) error {
+	stats, err := LoadStats(path)
+	if err != nil {
+		return err
+	}
+	if !stats.AddGame(record) {
+		return ErrGameCounted
+	}
+	return stats.Save(path)
+}
+
+// ratio is n/d, or 0 when d is 0.
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+func (p *PlayerStats) WinRate() float64    { return ratio(p.Wins, p.Hands) }
+func (p *PlayerStats) DealInRate() float64 { return ratio(p.DealIns, p.Hands) }
+func (p *PlayerStats) CallRate() float64   { return ratio(p.CallHands, p.Hands) }
+func (p *PlayerStats) RiichiRate() float64 { return ratio(p.RiichiHands, p.Hands) }
+func (p *PlayerStats) AverageWin() float64 { return ratio(p.WinPoints, p.Wins) }
+func (p *PlayerStats) AverageDealIn() float64 {
+	return ratio(p.DealInPoints, p.DealIns)
+}
+func (p *PlayerStats) AverageRiichiTurn() float64 { return ratio(p.RiichiTurns, p.RiichiHands) }
+
+// AveragePlace is the mean placement over the games played (0 with none).
+func (p *PlayerStats) AveragePlace() float64 {
+	sum := 0
+	for i, n := range p.Places {
+		sum += (i + 1) * n
+	}
+	return ratio(sum, p.Games)
+}
+
+// SortedYaku lists the player's yaku from most to least frequent (ties by name).
+func (p *PlayerStats) SortedYaku() []string {
+	return sortedCounts(p.Yaku)
+}
+
+// sortedCounts lists the keys of counts from the largest count down (ties by name).
+func sortedCounts(counts map[string]int) []string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// PrintPlayerStats prints one player's statistics.
+func PrintPlayerStats(p *PlayerStats) {
+	fmt.Printf("\n%s: %d games, %d hands\n", p.Name, p.Games, p.Hands)
+	fmt.Printf("  Win rate: %.1f%% (average %.0f points)\n", 100*p.WinRate(), p.AverageWin())
+	fmt.Printf("  Deal-in rate: %.1f%% (average %.0f points)\n", 100*p.DealInRate(), p.AverageDealIn())
+	fmt.Printf("  Call rate: %.1f%%\n", 100*p.CallRate())
+	fmt.Printf("  Riichi rate: %.1f%% (average turn %.1f)\n", 100*p.RiichiRate(), p.AverageRiichiTurn())
+	fmt.Printf("  Places: 1st %d, 2nd %d, 3rd %d, 4th %d (average %.2f)\n", p.Places[0], p.Places[1], p.Places[2], p.Places[3], p.AveragePlace())
+	if len(p.Yaku) > 0 {
+		fmt.Print("  Yaku:")
+		for _, name := range p.SortedYaku() {
+			fmt.Printf(" %s %d;", name, p.Yaku[name])
+		}
+		fmt.Println()
+	}
+}
+
+// runStatsCommand handles "mahjong-go stats": it prints the statistics of every player (or
+// one), optionally adding a saved game record first.
+func runStatsCommand(args []string) {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	file := flags.String("file", DefaultStatsFile, "statistics file")
+	add := flags.String("add", "", "game record to add to the statistics first (a record already added is skipped)")
+	name := flags.String("player", "", "only show this player")
+	flags.Parse(args)
+
+	if *add != "" {
+		record, err := LoadGameRecord(*add)
+		if err == nil {
+			err = UpdateStats(*file, record)
+		}
+		if errors.Is(err, ErrGameCounted) {
+			fmt.Printf("%s is already in %s; not adding it again.\n", *add, *file)
+		} else if err != nil {
+			fmt.Println("Error adding game record:", err)
+			os.Exit(1)
+		}
+	}
+	stats, err := LoadStats(*file)
+	if err != nil {
+		fmt.Println("Error loading stats:", err)
+		os.Exit(1)
+	}
+
+	names := make([]string, 0, len(stats.Players))
+	for n := range stats.Players {
+		if *name == "" || n == *name {
+			names = append(names, n)
+		}
+	}
+	if len(names) == 0 {
+		fmt.Printf("No statistics in %s.\n", *file)
+		return
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		PrintPlayerStats(stats.Players[n])
+	}
+}
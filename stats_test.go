@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStats_AddGame(t *testing.T) {
+	record := &GameRecord{
+		Hands: []HandResult{
+			{Round: "East 1, 0 Honba", Seats: []SeatResult{
+				{Name: "A", Won: true, Riichi: true, RiichiTurn: 6, ScoreChange: 8000, Yaku: []string{"Riichi", "Pinfu"}},
+				{Name: "B", DealtIn: true, Called: true, ScoreChange: -8000},
+			}},
+			{Round: "East 2, 0 Honba", Seats: []SeatResult{
+				{Name: "A", Riichi: true, RiichiTurn: 10, ScoreChange: -1000},
+				{Name: "B", Won: true, Called: true, ScoreChange: 2000, Yaku: []string{"Tanyao"}},
+			}},
+		},
+		Placements: []Placement{{Name: "A", Place: 1}, {Name: "B", Place: 4}},
+	}
+	stats := &Stats{Players: make(map[string]*PlayerStats)}
+	stats.AddGame(record)
+	stats.AddGame(record)
+
+	a, b := stats.Players["A"], stats.Players["B"]
+	if a.Games != 2 || a.Hands != 4 || a.Wins != 2 || a.RiichiHands != 4 || a.Places != [4]int{2, 0, 0, 0} {
+		t.Errorf("TestStats_AddGame: Expected A with 2 games, 4 hands, 2 wins, 4 Riichi and two 1st places, got %+v", a)
+	}
+	if a.WinRate() != 0.5 || a.AverageWin() != 8000 || a.AverageRiichiTurn() != 8 || a.AveragePlace() != 1 {
+		t.Errorf("TestStats_AddGame: Expected A win rate 0.5, average win 8000, Riichi turn 8 and place 1, got %v, %v, %v, %v",
+			a.WinRate(), a.AverageWin(), a.AverageRiichiTurn(), a.AveragePlace())
+	}
+	if b.DealInRate() != 0.5 || b.AverageDealIn() != 8000 || b.CallRate() != 1 || b.AveragePlace() != 4 {
+		t.Errorf("TestStats_AddGame: Expected B deal-in rate 0.5 at 8000, call rate 1 and place 4, got %v, %v, %v, %v",
+			b.DealInRate(), b.AverageDealIn(), b.CallRate(), b.AveragePlace())
+	}
+	if got := a.SortedYaku(); !reflect.DeepEqual(got, []string{"Pinfu", "Riichi"}) || a.Yaku["Riichi"] != 2 {
+		t.Errorf("TestStats_AddGame: Expected yaku Pinfu, Riichi counted twice each, got %v %v", got, a.Yaku)
+	}
+}
+
+func TestStats_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	stats, err := LoadStats(path)
+	if err != nil || len(stats.Players) != 0 {
+		t.Fatalf("TestStats_SaveAndLoad: Expected empty stats for a missing file, got %v, %v", stats, err)
+	}
+	record := &GameRecord{
+		Hands:      []HandResult{{Seats: []SeatResult{{Name: "A", Won: true, ScoreChange: 3900, Yaku: []string{"Tanyao"}}}}},
+		Placements: []Placement{{Name: "A", Place: 2}},
+	}
+	if err := UpdateStats(path, record); err != nil {
+		t.Fatalf("TestStats_SaveAndLoad: Expected no error updating, got %v", err)
+	}
+	if err := UpdateStats(path, record); err != nil {
+		t.Fatalf("TestStats_SaveAndLoad: Expected no error updating again, got %v", err)
+	}
+	loaded, err := LoadStats(path)
+	if err != nil {
+		t.Fatalf("TestStats_SaveAndLoad: Expected no error loading, got %v", err)
+	}
+	a := loaded.Players["A"]
+	if a == nil || a.Games != 2 || a.Wins != 2 || a.WinPoints != 7800 || a.Places[1] != 2 || a.Yaku["Tanyao"] != 2 {
+		t.Errorf("TestStats_SaveAndLoad: Expected two games added, got %+v", a)
+	}
+}
+
+func TestUpdateStats_CountsEachRecordOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	record := NewGameRecord("A")
+	record.Placements = []Placement{{Name: "A", Place: 1}}
+	if err := UpdateStats(path, record); err != nil {
+		t.Fatalf("TestUpdateStats_CountsEachRecordOnce: Expected no error updating, got %v", err)
+	}
+	if err := UpdateStats(path, record); !errors.Is(err, ErrGameCounted) {
+		t.Errorf("TestUpdateStats_CountsEachRecordOnce: Expected ErrGameCounted adding the record again, got %v", err)
+	}
+	next := NewGameRecord("A")
+	next.Placements = record.Placements
+	if err := UpdateStats(path, next); err != nil {
+		t.Errorf("TestUpdateStats_CountsEachRecordOnce: Expected another game to be added, got %v", err)
+	}
+	loaded, err := LoadStats(path)
+	if err != nil {
+		t.Fatalf("TestUpdateStats_CountsEachRecordOnce: Expected no error loading, got %v", err)
+	}
+	if a := loaded.Players["A"]; a == nil || a.Games != 2 {
+		t.Errorf("TestUpdateStats_CountsEachRecordOnce: Expected 2 games counted, got %+v", a)
+	}
+}
+
+func TestRecordingInput_RoundEnded(t *testing.T) {
+	gs := createTestGameState(nil)
+	record := &GameRecord{}
+	in := &RecordingInput{Input: AutoInput{}, Record: record}
+	winner, discarder := gs.Players[1], gs.Players[2]
+	winner.Score = gs.Rules.StartingPoints + 2000
+	discarder.Score = gs.Rules.StartingPoints - 2000
+	winner.Melds = []Meld{{Type: "Pon", Tiles: TilesFromString("E E E")}}
+	discarder.Discards = riverFromString("1m 9p 5s")
+	discarder.Discards[1].RiichiTile = true
+	gs.WinResults = []*WinResult{{Winner: winner, Discarder: discarder, Yaku: []YakuResult{{Name: "Yakuhai (East)", Han: 1}, {Name: "Dora 1", Han: 1}}}}
+	in.RoundEnded(gs)
+
+	if len(record.Hands) != 1 || len(record.Hands[0].Seats) != 4 {
+		t.Fatalf("TestRecordingInput_RoundEnded: Expected one hand with 4 seats, got %+v", record.Hands)
+	}
+	w, d := record.Hands[0].Seats[1], record.Hands[0].Seats[2]
+	if !w.Won || !w.Called || w.ScoreChange != 2000 || !reflect.DeepEqual(w.Yaku, []string{"Yakuhai (East)"}) {
+		t.Errorf("TestRecordingInput_RoundEnded: Expected an open win of 2000 with Yakuhai only, got %+v", w)
+	}
+	if !d.DealtIn || !d.Riichi || d.RiichiTurn != 2 || d.ScoreChange != -2000 {
+		t.Errorf("TestRecordingInput_RoundEnded: Expected a deal-in for 2000 after Riichi on turn 2, got %+v", d)
+	}
+
+	gs.WinResults = nil
+	in.RoundEnded(gs)
+	if w := record.Hands[1].Seats[1]; w.Won || w.ScoreChange != 0 {
+		t.Errorf("TestRecordingInput_RoundEnded: Expected the next hand measured from the new scores, got %+v", w)
+	}
+}
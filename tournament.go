@@ -0,0 +1,47 @@
+package main
+
+import "fmt"
+
+// Placement is one player's final result with uma and oka applied.
+type Placement struct {
+	Name    string `json:"name"`
+	Place   int    `json:"place"`             // 1 to 4
+	Score   int    `json:"score"`             // Raw points at the end of the game
+	Points  int    `json:"points"`            // Score - ReturnPoints + Uma, plus Oka for first place, less any chombo penalty
+	Chombos int    `json:"chombos,omitempty"` // Chombo penalties taken during the game
+}
+
+// initialSeatOrder returns how many seats after the first dealer (East 1) the player sat.
+func initialSeatOrder(gs *GameState, player *Player) int {
+	n := len(gs.Players)
+	return (player.InitialTurnOrder - gs.InitialDealerIndex + n) % n
+}
+
+// FinalPlacements scores the finished game with gs.Rules, in FinalStandings order.
+// Tied players are separated by seat, so each place gets its own uma.
+func FinalPlacements(gs *GameState) []Placement {
+	standings := FinalStandings(gs)
+	placements := make([]Placement, len(standings))
+	for i, p := range standings {
+		points := p.Score - gs.Rules.ReturnPoints
+		if i < len(gs.Rules.Uma) {
+			points += gs.Rules.Uma[i]
+		}
+		if i == 0 {
+			points += gs.Rules.Oka
+		}
+		points -= chomboPenaltyPoints(gs.Rules, p.Chombos)
+		placements[i] = Placement{Name: p.Name, Place: i + 1, Score: p.Score, Points: points, Chombos: p.Chombos}
+	}
+	return placements
+}
+
+// FormatPlacementPoints shows placement points the way score sheets do, in thousands: "+45.0", "-32.5".
+func FormatPlacementPoints(points int) string {
+	sign := "+"
+	if points < 0 {
+		sign = "-"
+		points = -points
+	}
+	return fmt.Sprintf("%s%d.%d", sign, points/1000, points%1000/100)
+}
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Nanikiru (what-to-discard) trainer: the player is shown a 14-tile hand, picks a discard,
+// and is graded against the discard that keeps the lowest shanten with the most tiles of
+// acceptance (ukeire).
+
+// Puzzle is one trainer hand: 14 concealed tiles and an optional note shown with it.
+type Puzzle struct {
+	Hand []Tile
+	Note string
+}
+
+// DiscardOption is the outcome of cutting one tile type from a 14-tile hand.
+type DiscardOption struct {
+	Tile    Tile   // The tile discarded (the first copy in the hand)
+	Shanten int    // Shanten of the 13 tiles left (0 is tenpai)
+	Accepts []Tile // Tile types that lower the shanten (the waits once tenpai)
+	Count   int    // Unseen copies of Accepts, counting only the hand as seen
+}
+
+// ParseMPSZ reads a hand in MPSZ notation, e.g. "123m406p789s1122z": digits followed by
+// their suit letter (m, p, s, or z for honors 1-7 = East South West North White Green Red).
+// A 0 is a red five. Spaces are ignored.
+func ParseMPSZ(s string) ([]Tile, error) {
+	var tiles []Tile
+	pending := ""
+	for _, r := range strings.ReplaceAll(s, " ", "") {
+		if r >= '0' && r <= '9' {
+			pending += string(r)
+			continue
+		}
+		if pending == "" {
+			return nil, fmt.Errorf("suit %q has no tiles before it in %q", r, s)
+		}
+		for _, d := range pending {
+			value := int(d - '0')
+			var tile Tile
+			switch r {
+			case 'm', 'p', 's':
+				suit := map[rune]string{'m': "Man", 'p': "Pin", 's': "Sou"}[r]
+				isRed := value == 0
+				if isRed {
+					value = 5
+				}
+				name := fmt.Sprintf("%s %d", suit, value)
+				if isRed {
+					name = "Red " + name
+				}
+				tile = NewTile(suit, value, name, isRed, len(tiles))
+			case 'z':
+				if value < 1 || value > 7 {
+					return nil, fmt.Errorf("honor tile %dz out of range (1-7) in %q", value, s)
+				}
+				tile = kindTile(27 + value - 1)
+				tile.ID = len(tiles)
+			default:
+				return nil, fmt.Errorf("unknown suit %q in %q", r, s)
+			}
+			tiles = append(tiles, tile)
+		}
+		pending = ""
+	}
+	if pending != "" {
+		return nil, fmt.Errorf("tiles %q have no suit in %q", pending, s)
+	}
+
+	counts := tileKindCounts(tiles)
+	for kind, n := range counts {
+		if n > 4 {
+			return nil, fmt.Errorf("%d copies of %s in %q", n, kindTile(kind).Name, s)
+		}
+	}
+	sort.Sort(BySuitValue(tiles))
+	return tiles, nil
+}
+
+// LoadPuzzles reads a puzzle file: one hand per line in MPSZ notation, optionally followed
+// by a note after "#". Blank lines and lines starting with "#" are skipped.
+func LoadPuzzles(path string) ([]Puzzle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	puzzles := []Puzzle{}
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		handText, note, _ := strings.Cut(line, "#")
+		hand, err := ParseMPSZ(handText)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n+1, err)
+		}
+		if len(hand) != HandSize+1 {
+			return nil, fmt.Errorf("%s:%d: a puzzle needs %d tiles, got %d", path, n+1, HandSize+1, len(hand))
+		}
+		puzzles = append(puzzles, Puzzle{Hand: hand, Note: strings.TrimSpace(note)})
+	}
+	return puzzles, nil
+}
+
+// GeneratePuzzle deals a hand and plays the dealer's first few turns alone, always cutting
+// the best tile, so the puzzle shows a mid-game shape rather than a raw starting hand.
+// Hands that are already complete are dealt again.
+func GeneratePuzzle() Puzzle {
+	for {
+		gs := NewGameState(defaultPlayerNames)
+		gs.DealInitialHands()
+		dealer := gs.Players[gs.DealerIndexThisRound]
+		turns := rand.Intn(6)
+		for turn := 0; ; turn++ {
+			gs.DrawTile()
+			if turn == turns {
+				break
+			}
+			best := AnalyzeDiscards(dealer.Hand, nil)[0]
+			dealer.Hand = removeTileByID(dealer.Hand, best.Tile.ID)
+		}
+		if Shanten(dealer.Hand, nil) < 0 {
+			continue
+		}
+		return Puzzle{Hand: dealer.Hand, Note: fmt.Sprintf("Dealt hand after %d draws", turns+1)}
+	}
+}
+
+// AnalyzeDiscards lists one DiscardOption per distinct tile type in a hand about to discard
+// (14 tiles counting melds), best first: lowest shanten, then most acceptance. Tenpai waits
+// come from FindTenpaiWaits.
+func AnalyzeDiscards(hand []Tile, melds []Meld) []DiscardOption {
+	options := []DiscardOption{}
+	seen := make(map[int]bool)
+	for _, tile := range hand {
+		kind := tileKind(tile)
+		if seen[kind] {
+			continue
+		}
+		seen[kind] = true
+
+		rest := removeTileByID(hand, tile.ID)
+		option := DiscardOption{Tile: tile}
+		if IsTenpai(rest, melds) {
+			option.Accepts = FindTenpaiWaits(rest, melds)
+		} else {
+			option.Shanten = Shanten(rest, melds)
+			option.Accepts = improvingTiles(rest, melds, option.Shanten)
+		}
+		option.Count = unseenCount(rest, option.Accepts)
+		options = append(options, option)
+	}
+	sort.SliceStable(options, func(i, j int) bool {
+		if options[i].Shanten != options[j].Shanten {
+			return options[i].Shanten < options[j].Shanten
+		}
+		return options[i].Count > options[j].Count
+	})
+	return options
+}
+
+// GradeDiscard reports whether discarding tile is as good as the best option (ties count),
+// and the option it matched.
+func GradeDiscard(options []DiscardOption, tile Tile) (bool, DiscardOption) {
+	for _, option := range options {
+		if tileKind(option.Tile) == tileKind(tile) {
+			best := options[0]
+			return option.Shanten == best.Shanten && option.Count == best.Count, option
+		}
+	}
+	return false, DiscardOption{}
+}
+
+// Shanten is how many tiles a hand (13 or 14 tiles counting melds) is from tenpai, taking the
+// best of a standard hand, Chiitoitsu and Kokushi Musou. Tenpai is 0 and a complete hand -1.
+func Shanten(hand []Tile, melds []Meld) int {
+	counts := tileKindCounts(hand)
+	best := standardShanten(counts, len(melds))
+	if len(melds) > 0 {
+		return best
+	}
+	if s := chiitoitsuShanten(counts); s < best {
+		best = s
+	}
+	if s := kokushiShanten(counts); s < best {
+		best = s
+	}
+	return best
+}
+
+// standardShanten searches every split into sets, partial sets (taatsu) and a pair, using
+// 8 - 2*sets - taatsu - pair with at most four sets and taatsu counted. calledSets are melds
+// already on the table. Splits that cannot beat the best one found so far are cut short.
+func standardShanten(counts [34]int, calledSets int) int {
+	best := 8
+	var search func(i, sets, taatsu, pairs int)
+	search = func(i, sets, taatsu, pairs int) {
+		for i < 34 && counts[i] == 0 {
+			i++
+		}
+		remaining := 0
+		for _, n := range counts[i:] {
+			remaining += n
+		}
+		if best < 0 || standardShantenBound(sets, taatsu, pairs, remaining) >= best {
+			return
+		}
+		if i == 34 {
+			if sets+taatsu > 4 {
+				taatsu = 4 - sets
+			}
+			if s := 8 - 2*sets - taatsu - pairs; s < best {
+				best = s
+			}
+			return
+		}
+		suited := i < 27
+		pos := i % 9
+		if counts[i] >= 3 {
+			counts[i] -= 3
+			search(i, sets+1, taatsu, pairs)
+			counts[i] += 3
+		}
+		if suited && pos <= 6 && counts[i+1] > 0 && counts[i+2] > 0 {
+			counts[i]--
+			counts[i+1]--
+			counts[i+2]--
+			search(i, sets+1, taatsu, pairs)
+			counts[i]++
+			counts[i+1]++
+			counts[i+2]++
+		}
+		if counts[i] >= 2 {
+			counts[i] -= 2
+			if pairs == 0 {
+				search(i, sets, taatsu, 1)
+			} else {
+				search(i, sets, taatsu+1, pairs)
+			}
+			counts[i] += 2
+		}
+		if suited && pos <= 7 && counts[i+1] > 0 {
+			counts[i]--
+			counts[i+1]--
+			search(i, sets, taatsu+1, pairs)
+			counts[i]++
+			counts[i+1]++
+		}
+		if suited && pos <= 6 && counts[i+2] > 0 {
+			counts[i]--
+			counts[i+2]--
+			search(i, sets, taatsu+1, pairs)
+			counts[i]++
+			counts[i+2]++
+		}
+		counts[i]-- // Leave the tile isolated
+		search(i, sets, taatsu, pairs)
+		counts[i]++
+	}
+	search(0, calledSets, 0, 0)
+	return best
+}
+
+// standardShantenBound is the lowest shanten a partial split can still reach with remaining
+// tiles to place: no tile gains more than two thirds of a step (a set), and at most the four
+// sets and taatsu not yet formed and the pair are left to gain.
+func standardShantenBound(sets, taatsu, pairs, remaining int) int {
+	if sets+taatsu > 4 {
+		taatsu = 4 - sets
+	}
+	gain := min((2*remaining+2)/3, 2*(4-sets-taatsu)+1-pairs)
+	return 8 - 2*sets - taatsu - pairs - gain
+}
+
+// chiitoitsuShanten counts pairs toward seven distinct pairs.
+func chiitoitsuShanten(counts [34]int) int {
+	pairs, kinds := 0, 0
+	for _, n := range counts {
+		if n > 0 {
+			kinds++
+		}
+		if n >= 2 {
+			pairs++
+		}
+	}
+	s := 6 - pairs
+	if kinds < 7 {
+		s += 7 - kinds
+	}
+	return s
+}
+
+// kokushiShanten counts distinct terminals and honors, plus one for a pair among them.
+func kokushiShanten(counts [34]int) int {
+	kinds, hasPair := 0, false
+	for kind, n := range counts {
+		if n == 0 || (kind < 27 && kind%9 != 0 && kind%9 != 8) {
+			continue
+		}
+		kinds++
+		if n >= 2 {
+			hasPair = true
+		}
+	}
+	s := 13 - kinds
+	if hasPair {
+		s--
+	}
+	return s
+}
+
+// improvingTiles lists the tile types that lower a 13-tile hand's shanten from current.
+func improvingTiles(hand []Tile, melds []Meld, current int) []Tile {
+	accepts := []Tile{}
+	for _, candidate := range GetAllPossibleTiles() {
+		if Shanten(append(append([]Tile{}, hand...), candidate), melds) < current {
+			accepts = append(accepts, candidate)
+		}
+	}
+	return accepts
+}
+
+// unseenCount is how many copies of tiles are left when only hand has been seen.
+func unseenCount(hand []Tile, tiles []Tile) int {
+	counts := tileKindCounts(hand)
+	total := 0
+	for _, t := range tiles {
+		total += 4 - counts[tileKind(t)]
+	}
+	return total
+}
+
+// tileKind maps a tile to 0-33: Man, Pin, Sou 1-9, then East to North, then White, Green, Red.
+func tileKind(t Tile) int {
+	switch t.Suit {
+	case "Man":
+		return t.Value - 1
+	case "Pin":
+		return 9 + t.Value - 1
+	case "Sou":
+		return 18 + t.Value - 1
+	case "Wind":
+		return 27 + t.Value - 1
+	default:
+		return 31 + t.Value - 1
+	}
+}
+
+// kindTile is the GetAllPossibleTiles tile for a tileKind.
+func kindTile(kind int) Tile {
+	return GetAllPossibleTiles()[kind]
+}
+
+func tileKindCounts(tiles []Tile) [34]int {
+	var counts [34]int
+	for _, t := range tiles {
+		counts[tileKind(t)]++
+	}
+	return counts
+}
+
+func removeTileByID(tiles []Tile, id int) []Tile {
+	rest := make([]Tile, 0, len(tiles))
+	for _, t := range tiles {
+		if t.ID != id {
+			rest = append(rest, t)
+		}
+	}
+	return rest
+}
+
+// ShantenLabel names a shanten count for display.
+func ShantenLabel(shanten int) string {
+	switch {
+	case shanten < 0:
+		return "complete"
+	case shanten == 0:
+		return "tenpai"
+	default:
+		return fmt.Sprintf("%d-shanten", shanten)
+	}
+}
+
+// DescribeDiscardOption is one line of the trainer's explanation.
+func DescribeDiscardOption(option DiscardOption) string {
+	what := "tiles"
+	if option.Shanten == 0 {
+		what = "tiles to win"
+	}
+	return fmt.Sprintf("Cut %s: %s, %d %s (%s)", option.Tile.Name, ShantenLabel(option.Shanten),
+		option.Count, what, strings.Join(TilesToNames(option.Accepts), ", "))
+}
+
+// runTrainCommand handles "mahjong-go train": it asks for a discard on each puzzle and
+// explains the acceptance counts of every choice.
+func runTrainCommand(args []string) {
+	flags := flag.NewFlagSet("train", flag.ExitOnError)
+	file := flags.String("file", "", "puzzle file, one MPSZ hand per line (default: generate hands)")
+	count := flags.Int("count", 5, "number of generated puzzles when no file is given")
+	flags.Parse(args)
+
+	puzzles := []Puzzle{}
+	if *file != "" {
+		loaded, err := LoadPuzzles(*file)
+		if err != nil {
+			fmt.Println("Error loading puzzles:", err)
+			os.Exit(1)
+		}
+		puzzles = loaded
+	} else {
+		for i := 0; i < *count; i++ {
+			puzzles = append(puzzles, GeneratePuzzle())
+		}
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	correct := 0
+	for i, puzzle := range puzzles {
+		fmt.Printf("\n--- Puzzle %d of %d ---\n", i+1, len(puzzles))
+		if puzzle.Note != "" {
+			fmt.Println(puzzle.Note)
+		}
+		player := &Player{Name: "Trainee", Hand: puzzle.Hand}
+		options := AnalyzeDiscards(player.Hand, nil)
+		fmt.Printf("Hand is %s.\n", ShantenLabel(Shanten(player.Hand, nil)))
+
+		choice := GetPlayerDiscardChoice(reader, player)
+		if choice < 0 {
+			return
+		}
+		ok, picked := GradeDiscard(options, player.Hand[choice])
+		if ok {
+			correct++
+			fmt.Printf("Correct! %s\n", DescribeDiscardOption(picked))
+		} else {
+			fmt.Printf("Not the best cut. You chose: %s\n", DescribeDiscardOption(picked))
+		}
+		fmt.Println("All choices, best first:")
+		for _, option := range options {
+			fmt.Println("  " + DescribeDiscardOption(option))
+		}
+	}
+	fmt.Printf("\nScore: %d of %d\n", correct, len(puzzles))
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func mustParseMPSZ(t *testing.T, s string) []Tile {
+	t.Helper()
+	tiles, err := ParseMPSZ(s)
+	if err != nil {
+		t.Fatalf("ParseMPSZ(%q): %v", s, err)
+	}
+	return tiles
+}
+
+func TestParseMPSZ(t *testing.T) {
+	tiles := mustParseMPSZ(t, "11z 406p")
+	names := TilesToNames(tiles)
+	expected := []string{"Pin 4", "Red Pin 5", "Pin 6", "East", "East"}
+	if len(names) != len(expected) {
+		t.Fatalf("TestParseMPSZ: Expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("TestParseMPSZ: Expected %v, got %v", expected, names)
+			break
+		}
+	}
+	if !tiles[1].IsRed {
+		t.Errorf("TestParseMPSZ: Expected 0p to be a red five")
+	}
+
+	for _, bad := range []string{"123", "m", "8z", "11111m", "12x"} {
+		if _, err := ParseMPSZ(bad); err == nil {
+			t.Errorf("TestParseMPSZ: Expected an error for %q", bad)
+		}
+	}
+}
+
+func TestShanten(t *testing.T) {
+	cases := []struct {
+		hand     string
+		expected int
+	}{
+		{"123m456p789s11122z", -1}, // Complete
+		{"123m456p789s1112z", 0},   // Tanki on South
+		{"1122m3344p5566s7z", 0},   // Chiitoitsu tanki
+		{"19m19p19s1234567z", 0},   // Kokushi 13-sided
+		{"147m258p369s1234z", 6},
+	}
+	for _, c := range cases {
+		hand := mustParseMPSZ(t, c.hand)
+		if got := Shanten(hand, nil); got != c.expected {
+			t.Errorf("TestShanten: Expected %d for %s, got %d", c.expected, c.hand, got)
+		}
+	}
+}
+
+func TestAnalyzeDiscards_Tenpai(t *testing.T) {
+	// Cutting the 9s leaves 23m ryanmen (1m, 4m: 8 tiles); cutting 2m or 3m keeps a worse shape.
+	hand := mustParseMPSZ(t, "23m456p789s11z 555s 9s")
+	options := AnalyzeDiscards(hand, nil)
+	best := options[0]
+	if best.Tile.Name != "Sou 9" || best.Shanten != 0 || best.Count != 8 {
+		t.Errorf("TestAnalyzeDiscards_Tenpai: Expected cutting Sou 9 for tenpai on 8 tiles, got %s", DescribeDiscardOption(best))
+	}
+	waits := TilesToNames(best.Accepts)
+	if len(waits) != 2 || waits[0] != "Man 1" || waits[1] != "Man 4" {
+		t.Errorf("TestAnalyzeDiscards_Tenpai: Expected waits Man 1, Man 4, got %v", waits)
+	}
+
+	if ok, _ := GradeDiscard(options, mustParseMPSZ(t, "9s")[0]); !ok {
+		t.Errorf("TestAnalyzeDiscards_Tenpai: Expected cutting Sou 9 to be graded correct")
+	}
+	ok, picked := GradeDiscard(options, mustParseMPSZ(t, "1z")[0])
+	if ok || picked.Shanten != 1 {
+		t.Errorf("TestAnalyzeDiscards_Tenpai: Expected cutting East to be graded wrong at 1-shanten, got ok=%v %s", ok, DescribeDiscardOption(picked))
+	}
+}
+
+func TestAnalyzeDiscards_Ukeire(t *testing.T) {
+	// Three sets, the 45m ryanmen and three floating tiles: cutting a floater stays 1-shanten,
+	// breaking the ryanmen does not.
+	hand := mustParseMPSZ(t, "45m123p456p789s4z 1s 2z")
+	options := AnalyzeDiscards(hand, nil)
+	best := options[0]
+	if best.Shanten != 1 {
+		t.Fatalf("TestAnalyzeDiscards_Ukeire: Expected the best cut to be 1-shanten, got %s", DescribeDiscardOption(best))
+	}
+	if ok, picked := GradeDiscard(options, mustParseMPSZ(t, "4m")[0]); ok {
+		t.Errorf("TestAnalyzeDiscards_Ukeire: Expected breaking the 45m ryanmen to be graded wrong, got %s", DescribeDiscardOption(picked))
+	}
+	for i := 1; i < len(options); i++ {
+		prev, cur := options[i-1], options[i]
+		if cur.Shanten < prev.Shanten || (cur.Shanten == prev.Shanten && cur.Count > prev.Count) {
+			t.Errorf("TestAnalyzeDiscards_Ukeire: Expected options best first, got %s before %s", DescribeDiscardOption(prev), DescribeDiscardOption(cur))
+		}
+	}
+}
+
+func TestLoadPuzzles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "puzzles.txt")
+	content := "# Trainer set\n\n23m456p789s11z5559s # Take the ryanmen\n123m456p789s12345z\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	puzzles, err := LoadPuzzles(path)
+	if err != nil {
+		t.Fatalf("TestLoadPuzzles: %v", err)
+	}
+	if len(puzzles) != 2 {
+		t.Fatalf("TestLoadPuzzles: Expected 2 puzzles, got %d", len(puzzles))
+	}
+	if puzzles[0].Note != "Take the ryanmen" || len(puzzles[0].Hand) != 14 {
+		t.Errorf("TestLoadPuzzles: Expected 14 tiles and the note, got %d tiles and %q", len(puzzles[0].Hand), puzzles[0].Note)
+	}
+
+	if err := os.WriteFile(path, []byte("123m\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPuzzles(path); err == nil {
+		t.Errorf("TestLoadPuzzles: Expected an error for a 3-tile puzzle")
+	}
+}
+
+func TestGeneratePuzzle(t *testing.T) {
+	puzzle := GeneratePuzzle()
+	if len(puzzle.Hand) != 14 {
+		t.Errorf("TestGeneratePuzzle: Expected 14 tiles, got %d", len(puzzle.Hand))
+	}
+	if Shanten(puzzle.Hand, nil) < 0 {
+		t.Errorf("TestGeneratePuzzle: Expected an incomplete hand")
+	}
+}
@@ -47,6 +47,15 @@ type Tile struct {
 	ID    int    // Unique ID (0-135) for exact tile instance comparison
 }
 
+// Discard is one tile in a player's river, with how it was discarded.
+type Discard struct {
+	Tile       Tile
+	Turn       int  // gs.TurnNumber when it was discarded (discards within the round, from 0)
+	Tsumogiri  bool // The tile just drawn was discarded; otherwise it came from the hand (tedashi)
+	RiichiTile bool // The Riichi declaration tile, laid sideways
+	Called     bool // Taken by another player's call; it stays in the river
+}
+
 // Meld represents an open or closed set of tiles (Chi, Pon, Kan)
 type Meld struct {
 	Type        string // "Chi", "Pon", "Ankan", "Daiminkan", "Shouminkan"
@@ -59,13 +68,15 @@ type Meld struct {
 // Player represents a mahjong player
 type Player struct {
 	Name                         string
-	Hand                         []Tile // Concealed part of the hand (should be kept sorted)
-	Discards                     []Tile // Tiles discarded by this player (in order of discard)
-	Melds                        []Meld // Array of melded tile sets
+	Hand                         []Tile    // Concealed part of the hand (should be kept sorted)
+	Discards                     []Discard // This player's river in order of discard, called tiles included
+	Melds                        []Meld    // Array of melded tile sets
 	Score                        int
 	SeatWind                     string  // Player's current seat wind ("East", "South", "West", "North")
 	IsRiichi                     bool    // True if player has declared Riichi
+	IsOpenRiichi                 bool    // True if the Riichi was declared with the hand shown (Open Riichi local yaku)
 	RiichiTurn                   int     // Turn number (within the round) Riichi was declared (-1 if not in Riichi)
+	IsRiichiPending              bool    // Riichi declared and its stick paid, but the declaration tile has not yet passed without a Ron
 	IsIppatsu                    bool    // True if eligible for Ippatsu (win within one turn cycle of Riichi, no interruptions)
 	IsFuriten                    bool    // General Furiten status (due to own discards matching waits, or recently missed Ron)
 	IsPermanentRiichiFuriten     bool    // True if in Riichi and missed a Ron on a declared wait tile
@@ -76,10 +87,13 @@ type Player struct {
 	HasMadeFirstDiscardThisRound bool    // True if player has made their first discard in the current round (for Renhou/Chihou)
 	HasDrawnFirstTileThisRound   bool    // True if player has drawn their first tile in the current round (for Tenhou/Chihou/Kyuushuu)
 	HasHadDiscardCalledThisRound bool    // True if any of this player's discards in the current round were called for an open meld (for Nagashi Mangan)
+	Chombos                      int     // Chombo penalties this game (see chombo.go)
 	JustDrawnTile                *Tile   // Pointer to the tile most recently drawn by this player (nil otherwise)
+	KuikaeForbidden              []Tile  // Tile types this player may not discard right after a Chi or Pon (kuikae); nil otherwise
 	IsTenpai                     bool    // Status at Ryuukyoku (exhaustive draw)
 	PaoTargetFor                 *Player // If this player's call caused another player (`PaoTargetFor`) to win a Yakuman (Pao liability)
 	PaoSourcePlayerIndex         int     // Index of player who is Pao for this player's Yakuman (-1 if none this player is the target)
+	PaoYakuman                   string  // The yakuman PaoSourcePlayerIndex is liable for ("Daisangen", "Daisuushii" or "Suukantsu")
 	InitialTurnOrder             int     // Player's fixed turn order index at the start of the game (0-3), used for Ssuufon Renda.
 }
 
@@ -90,6 +104,18 @@ type RiichiOption struct {
 	Waits        []Tile // List of tile *types* the hand will wait on after this discard
 }
 
+// WinResult records how a win was scored, for end-of-hand displays.
+type WinResult struct {
+	Winner      *Player
+	Discarder   *Player // Who dealt in; nil on Tsumo
+	WinningTile Tile
+	IsTsumo     bool
+	Yaku        []YakuResult
+	Han         int
+	Fu          int // 0 for Yakuman hands
+	Payment     Payment
+}
+
 // GameState represents the current game state
 type GameState struct {
 	Wall                 []Tile        // Remaining drawable tiles in the live wall
@@ -97,34 +123,46 @@ type GameState struct {
 	Players              []*Player     // Slice of all players in the game
 	CurrentPlayerIndex   int           // Index of the player whose turn it is currently
 	DealerIndexThisRound int           // Index of the player who is the dealer for the current round
+	InitialDealerIndex   int           // Index of the player who was dealer in East 1 (seat order for tie-breaks)
+	Rules                RuleSet       // Table rules that vary between clubs and tournaments (see ruleset.go)
+	YakuRegistry         *YakuRegistry // Yaku scored by IdentifyYaku; nil uses StandardYaku (see yaku_registry.go)
 	DiscardPile          []Tile        // All discarded tiles in order across all players (rarely used directly now, player.Discards is primary)
 	DoraIndicators       []Tile        // Revealed Dora indicators (initial + Kan Doras)
 	UraDoraIndicators    []Tile        // Revealed Ura Dora indicators (only on Riichi win)
+	PendingKanDora       int           // Kan dora indicators owed by open Kans but not yet turned (RuleSet.KanDoraTiming)
 	PrevalentWind        string        // Current prevalent wind ("East", "South", "West", "North")
 	RoundNumber          int           // Round number within the current Prevalent Wind (e.g., East 1, East 2, ..., South 1)
 	DealerRoundCount     int           // How many consecutive rounds the current dealer has held dealership (for Renchan display)
+	DealerWinStreak      int           // Consecutive wins by the current dealer (Paarenchan); dealer tenpai draws leave it unchanged
 	Honba                int           // Number of repeat rounds/counters on the table (adds to win value)
 	RiichiSticks         int           // Number of 1000-point Riichi sticks on the table
 	TurnNumber           int           // Overall turn number *within the current round* (increments on each discard)
 	LastDiscard          *Tile         // Pointer to the very last tile discarded by any player
 	GamePhase            string        // Current phase of the game (e.g., PhaseDealing, PhasePlayerTurn)
 	InputReader          *bufio.Reader // For reading user input from console
+	Input                PlayerInput   // Decisions for the human seat (console by default, GUI or web client otherwise)
 
 	// Flags for specific Yaku conditions and game state tracking
 	IsChankanOpportunity        bool         // True if a Shouminkan is declared and available for Chankan Ron
 	IsRinshanWin                bool         // True if the current Tsumo check is for a Rinshan tile draw
+	RinshanPaoSourceIndex       int          // Discarder of the tile claimed by the Daiminkan whose Rinshan tile was drawn last (-1 after Ankan/Shouminkan)
+	IsKanburiDiscard            bool         // True while the discard made after a Kan's Rinshan draw is open to Ron (Kanburi)
 	IsHouteiDiscard             bool         // True if the current discard is the one immediately after the last wall tile was drawn (Haitei)
 	AnyCallMadeThisRound        bool         // True if any player has made a Chi, Pon, Daiminkan, or Shouminkan this round
 	IsFirstGoAround             bool         // True until a player completes their first discard OR a call is made this round
 	RoundWinner                 *Player      // Tracks the winner of the round, nil if draw/abort
-	FirstTurnDiscards           [4]Tile      // Stores the first un-interrupted discard of each player (by InitialTurnOrder index) for Ssuufon Renda
-	FirstTurnDiscardCount       int          // Count of players who have made their first un-interrupted discard
+	RoundWinners                []*Player    // Every winner of the round, closest to the discarder first (several on a double/triple Ron)
+	LastWinResult               *WinResult   // Scoring breakdown of the round's win (nil for draws and Nagashi Mangan)
+	WinResults                  []*WinResult // Scoring breakdown for each of RoundWinners; LastWinResult is the first
+	DrawType                    string       // How a drawn round ended (DrawExhaustive, DrawKyuushuuKyuuhai, ...; see ruleset.go), "" otherwise
 	DeclaredRiichiPlayerIndices map[int]bool // Tracks which player indices have declared Riichi this round (for Suu Riichi)
 	TotalKansDeclaredThisRound  int          // Total number of Kans (any type) declared in the current round (for Suukaikan)
 	MaxWindRounds               int          // Number of prevalent winds to play (e.g., 2 for East-South game, 1 for East-only)
 	CurrentWindRoundNumber      int          // Tracks which wind round it is (1 for East, 2 for South, etc.)
 	SanchahouRonners            []*Player    // Stores players who declared Ron on the same discard (for Sanchahou check)
 	GameLog                     []string     // Log of major game events
+	QuietLog                    bool         // Keep log entries without printing them (for hands scored behind the scenes)
+	Stopped                     bool         // Set when the human's client has gone away; RunGame returns before the next turn
 }
 
 // --- Sorting Tiles ---
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Wait shapes. A wait tile can complete the hand in more than one shape (shanpon on one
+// reading, ryanmen on another); the whole wait can form a larger pattern.
+const (
+	WaitRyanmen = "Ryanmen" // Two-sided: 45 on 3 or 6
+	WaitKanchan = "Kanchan" // Closed: 46 on 5
+	WaitPenchan = "Penchan" // Edge: 12 on 3, 89 on 7
+	WaitShanpon = "Shanpon" // Two pairs, either becoming a triplet
+	WaitTanki   = "Tanki"   // A single tile waiting for its pair (also each Chiitoitsu wait)
+	WaitKokushi = "Kokushi" // The missing terminal or honor of Kokushi Musou
+
+	WaitSanmenchan = "Sanmenchan"       // Three-sided: 23456 on 1, 4 or 7
+	WaitNobetan    = "Nobetan"          // 1234 as tanki on 1 or 4
+	WaitEntotsu    = "Entotsu"          // 66678 beside a pair: 6 and 9 as ryanmen, 6 and the pair as shanpon
+	WaitKokushi13  = "Kokushi 13-sided" // All thirteen terminals and honors, waiting on any of them
+	WaitMultiSided = "Multi-sided"      // Any other wait on three tiles or more
+)
+
+// WaitTile is one tile type a tenpai hand waits on.
+type WaitTile struct {
+	Tile        Tile
+	Shapes      []string // The shapes it completes (WaitRyanmen, WaitTanki, ...)
+	Unseen      int      // Copies the player has not seen
+	Furiten     bool     // The player has discarded this tile type, so Ron is not allowed
+	NoYakuRon   bool     // A Ron on it would have no yaku
+	NoYakuTsumo bool     // A Tsumo on it would have no yaku
+}
+
+// WaitAnalysis describes the wait of a tenpai hand.
+type WaitAnalysis struct {
+	Tiles    []WaitTile
+	Patterns []string // Larger patterns of the whole wait (WaitSanmenchan, WaitNobetan, ...)
+	Unseen   int      // Copies of every wait tile the player has not seen
+	Furiten  bool     // Any wait is in the player's river: no Ron on any of them
+}
+
+// AnalyzeWaits reports the wait of player's 13-tile hand (no waits if it is not tenpai): the
+// shapes, how many of each tile the player has not seen, furiten waits and waits that would
+// win without a yaku. The yaku checks run on a quiet copy of gs.
+func AnalyzeWaits(gs *GameState, player *Player) WaitAnalysis {
+	analysis := WaitAnalysis{}
+	visible := visibleTileCounts(newDecision(gs, player, ""))
+	discarded := tileKindCounts(DiscardedTiles(player.Discards))
+	counts := tileKindCounts(player.Hand)
+	for _, wait := range FindTenpaiWaits(player.Hand, player.Melds) {
+		kind := tileKind(wait)
+		wait.ID = TotalTiles // Past every wall tile ID
+		w := WaitTile{Tile: wait, Unseen: max(0, 4-visible[kind]), Furiten: discarded[kind] > 0}
+		w.Shapes = waitShapes(counts, len(player.Melds), kind)
+
+		sim, p := estimateGameState(gs, player)
+		_, ronHan := IdentifyYaku(p, wait, false, sim)
+		p.Hand = append(p.Hand, wait)
+		p.JustDrawnTile = &wait
+		_, tsumoHan := IdentifyYaku(p, wait, true, sim)
+		w.NoYakuRon, w.NoYakuTsumo = ronHan == 0, tsumoHan == 0
+
+		analysis.Tiles = append(analysis.Tiles, w)
+		analysis.Unseen += w.Unseen
+		analysis.Furiten = analysis.Furiten || w.Furiten
+	}
+	analysis.Patterns = waitPatterns(analysis.Tiles)
+	return analysis
+}
+
+// waitShapes lists the shapes in which a tile of kind completes a hand with counts in the
+// concealed part and calledSets melds, over every reading of the completed hand.
+func waitShapes(counts [34]int, calledSets int, kind int) []string {
+	counts[kind]++
+	found := make(map[string]bool)
+
+	if calledSets == 0 {
+		pairs, kokushi := 0, 0
+		for k, n := range counts {
+			if n == 2 {
+				pairs++
+			}
+			if n > 0 && (k >= 27 || k%9 == 0 || k%9 == 8) {
+				kokushi++
+			}
+		}
+		if pairs == 7 {
+			found[WaitTanki] = true
+		}
+		if kokushi == 13 && pairs == 1 {
+			found[WaitKokushi] = true
+		}
+	}
+
+	// Each reading is a pair and sets, as the first kind of each group and its shape
+	type group struct {
+		start int
+		shape string // "pair", "triplet" or "sequence"
+	}
+	groups := []group{}
+	var search func(i, pairs int)
+	search = func(i, pairs int) {
+		for i < 34 && counts[i] == 0 {
+			i++
+		}
+		if i == 34 {
+			if pairs == 1 {
+				for _, g := range groups {
+					if shape := groupWaitShape(g.start, g.shape, kind); shape != "" {
+						found[shape] = true
+					}
+				}
+			}
+			return
+		}
+		if counts[i] >= 3 {
+			counts[i] -= 3
+			groups = append(groups, group{i, "triplet"})
+			search(i, pairs)
+			groups = groups[:len(groups)-1]
+			counts[i] += 3
+		}
+		if i < 27 && i%9 <= 6 && counts[i+1] > 0 && counts[i+2] > 0 {
+			counts[i]--
+			counts[i+1]--
+			counts[i+2]--
+			groups = append(groups, group{i, "sequence"})
+			search(i, pairs)
+			groups = groups[:len(groups)-1]
+			counts[i]++
+			counts[i+1]++
+			counts[i+2]++
+		}
+		if pairs == 0 && counts[i] >= 2 {
+			counts[i] -= 2
+			groups = append(groups, group{i, "pair"})
+			search(i, 1)
+			groups = groups[:len(groups)-1]
+			counts[i] += 2
+		}
+	}
+	search(0, 0)
+
+	shapes := []string{}
+	for _, shape := range []string{WaitRyanmen, WaitKanchan, WaitPenchan, WaitShanpon, WaitTanki, WaitKokushi} {
+		if found[shape] {
+			shapes = append(shapes, shape)
+		}
+	}
+	return shapes
+}
+
+// groupWaitShape is the shape in which kind completes a group of a reading, or "" if the
+// group does not hold that kind.
+func groupWaitShape(start int, shape string, kind int) string {
+	switch {
+	case shape == "pair" && kind == start:
+		return WaitTanki
+	case shape == "triplet" && kind == start:
+		return WaitShanpon
+	case shape != "sequence" || kind < start || kind > start+2:
+		return ""
+	case kind == start+1:
+		return WaitKanchan
+	case kind == start && start%9 == 6, kind == start+2 && start%9 == 0: // 89 on 7, 12 on 3
+		return WaitPenchan
+	default:
+		return WaitRyanmen
+	}
+}
+
+// waitPatterns names the larger patterns among waits: three ryanmen waits three apart in a suit
+// (sanmenchan), two tanki waits three apart (nobetan), a tile waited on as both shanpon and
+// ryanmen (entotsu), and the 13-sided Kokushi Musou.
+func waitPatterns(waits []WaitTile) []string {
+	patterns := []string{}
+	has := func(kind int, shape string) bool {
+		for _, w := range waits {
+			if tileKind(w.Tile) == kind {
+				for _, s := range w.Shapes {
+					if s == shape {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+	sameSuit := func(a, b int) bool { return a < 27 && b < 27 && a/9 == b/9 }
+
+	kokushi := 0
+	sanmenchan, nobetan, entotsu := false, false, false
+	for _, w := range waits {
+		k := tileKind(w.Tile)
+		if has(k, WaitKokushi) {
+			kokushi++
+		}
+		if sameSuit(k, k+6) && has(k, WaitRyanmen) && has(k+3, WaitRyanmen) && has(k+6, WaitRyanmen) {
+			sanmenchan = true
+		}
+		if sameSuit(k, k+3) && has(k, WaitTanki) && has(k+3, WaitTanki) {
+			nobetan = true
+		}
+		if has(k, WaitShanpon) && has(k, WaitRyanmen) {
+			entotsu = true
+		}
+	}
+	switch {
+	case kokushi == 13:
+		patterns = append(patterns, WaitKokushi13)
+	case sanmenchan:
+		patterns = append(patterns, WaitSanmenchan)
+	}
+	if nobetan {
+		patterns = append(patterns, WaitNobetan)
+	}
+	if entotsu {
+		patterns = append(patterns, WaitEntotsu)
+	}
+	if len(patterns) == 0 && len(waits) >= 3 {
+		patterns = append(patterns, WaitMultiSided)
+	}
+	return patterns
+}
+
+// FormatWaitAnalysis describes a wait in one line, e.g.
+// "Waits: Man 3 (Ryanmen, 2 left), Man 6 (Ryanmen, 4 left, furiten) - 6 left, furiten".
+func FormatWaitAnalysis(analysis WaitAnalysis) string {
+	if len(analysis.Tiles) == 0 {
+		return "Not tenpai"
+	}
+	parts := []string{}
+	for _, w := range analysis.Tiles {
+		notes := append(append([]string{}, w.Shapes...), fmt.Sprintf("%d left", w.Unseen))
+		if w.Furiten {
+			notes = append(notes, "furiten")
+		}
+		switch {
+		case w.NoYakuRon && w.NoYakuTsumo:
+			notes = append(notes, "no yaku")
+		case w.NoYakuRon:
+			notes = append(notes, "no yaku on Ron")
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", kindTile(tileKind(w.Tile)).Name, strings.Join(notes, ", ")))
+	}
+	summary := append(append([]string{}, analysis.Patterns...), fmt.Sprintf("%d left", analysis.Unseen))
+	if analysis.Furiten {
+		summary = append(summary, "furiten")
+	}
+	return fmt.Sprintf("Waits: %s - %s", strings.Join(parts, ", "), strings.Join(summary, ", "))
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// waitTestState seats hand (MPSZ) at South, East 1, with a dora indicator away from the waits.
+func waitTestState(t *testing.T, hand string) (*GameState, *Player) {
+	t.Helper()
+	gs := createTestGameState(nil)
+	gs.DoraIndicators = mustParseMPSZ(t, "9p")
+	gs.DoraIndicators[0].ID = 200
+	player := gs.Players[1]
+	player.Hand = mustParseMPSZ(t, hand)
+	return gs, player
+}
+
+func TestAnalyzeWaits_Shapes(t *testing.T) {
+	tests := []struct {
+		hand     string
+		shapes   map[string][]string // Wait tile (MPSZ) to shapes
+		patterns []string
+	}{
+		{"123m456p789s11z45s", map[string][]string{"3s": {WaitRyanmen}, "6s": {WaitRyanmen}}, []string{}},
+		{"13m456p789s111z55s", map[string][]string{"2m": {WaitKanchan}}, []string{}},
+		{"12m456p789s123s11z", map[string][]string{"3m": {WaitPenchan}}, []string{}},
+		{"123m456p789s11z55s", map[string][]string{"1z": {WaitShanpon}, "5s": {WaitShanpon}}, []string{}},
+		{"1234m456p789s111z", map[string][]string{"1m": {WaitTanki}, "4m": {WaitTanki}}, []string{WaitNobetan}},
+		{"23456m456p789s11z", map[string][]string{"1m": {WaitRyanmen}, "4m": {WaitRyanmen}, "7m": {WaitRyanmen}}, []string{WaitSanmenchan}},
+		{"66678m456p789s11z", map[string][]string{"1z": {WaitShanpon}, "6m": {WaitRyanmen, WaitShanpon}, "9m": {WaitRyanmen}}, []string{WaitEntotsu}},
+		{"1122m3344p5566s7z", map[string][]string{"7z": {WaitTanki}}, []string{}},
+	}
+	for _, tt := range tests {
+		gs, player := waitTestState(t, tt.hand)
+		analysis := AnalyzeWaits(gs, player)
+		got := map[string][]string{}
+		for _, w := range analysis.Tiles {
+			got[mpszName(w.Tile)] = w.Shapes
+		}
+		if !reflect.DeepEqual(got, tt.shapes) {
+			t.Errorf("TestAnalyzeWaits_Shapes: Expected %v for %s, got %v", tt.shapes, tt.hand, got)
+		}
+		if !reflect.DeepEqual(analysis.Patterns, tt.patterns) {
+			t.Errorf("TestAnalyzeWaits_Shapes: Expected patterns %v for %s, got %v", tt.patterns, tt.hand, analysis.Patterns)
+		}
+	}
+}
+
+func TestIsChiitoitsu_FourOfAKindIsNotTwoPairs(t *testing.T) {
+	if IsChiitoitsu(mustParseMPSZ(t, "1111m2233p4455s66z")) {
+		t.Errorf("TestIsChiitoitsu_FourOfAKindIsNotTwoPairs: Expected 1111m plus five pairs not to be Chiitoitsu")
+	}
+	if hand := mustParseMPSZ(t, "1111m2233p4455s6z"); IsTenpai(hand, nil) {
+		t.Errorf("TestIsChiitoitsu_FourOfAKindIsNotTwoPairs: Expected 1111m plus five pairs and a single not to be tenpai")
+	}
+	if !IsChiitoitsu(mustParseMPSZ(t, "1122m2233p4455s66z")) {
+		t.Errorf("TestIsChiitoitsu_FourOfAKindIsNotTwoPairs: Expected seven distinct pairs to be Chiitoitsu")
+	}
+}
+
+func TestAnalyzeWaits_UnseenFuritenAndYaku(t *testing.T) {
+	gs, player := waitTestState(t, "123m456p789s11z45s")
+	player.Discards = riverFromString("6s")
+	gs.Players[2].Discards = riverFromString("3s 3s")
+	logLength := len(gs.GameLog)
+	analysis := AnalyzeWaits(gs, player)
+	if len(analysis.Tiles) != 2 {
+		t.Fatalf("TestAnalyzeWaits_UnseenFuritenAndYaku: Expected 2 waits, got %+v", analysis.Tiles)
+	}
+	three, six := analysis.Tiles[0], analysis.Tiles[1]
+	if three.Unseen != 2 || six.Unseen != 3 || analysis.Unseen != 5 {
+		t.Errorf("TestAnalyzeWaits_UnseenFuritenAndYaku: Expected 2 + 3 unseen, got %d + %d = %d", three.Unseen, six.Unseen, analysis.Unseen)
+	}
+	if three.Furiten || !six.Furiten || !analysis.Furiten {
+		t.Errorf("TestAnalyzeWaits_UnseenFuritenAndYaku: Expected furiten on 6s only (hand furiten), got %v, %v, %v", three.Furiten, six.Furiten, analysis.Furiten)
+	}
+	// The East pair is a yakuhai pair, so no Pinfu: only Menzen Tsumo is left
+	if !three.NoYakuRon || three.NoYakuTsumo {
+		t.Errorf("TestAnalyzeWaits_UnseenFuritenAndYaku: Expected no yaku on Ron but Menzen Tsumo on Tsumo, got Ron %v, Tsumo %v", three.NoYakuRon, three.NoYakuTsumo)
+	}
+
+	text := FormatWaitAnalysis(analysis)
+	for _, want := range []string{"Sou 3 (Ryanmen, 2 left, no yaku on Ron)", "Sou 6 (Ryanmen, 3 left, furiten, no yaku on Ron)", "5 left, furiten"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("TestAnalyzeWaits_UnseenFuritenAndYaku: Expected %q in %q", want, text)
+		}
+	}
+	if len(gs.GameLog) != logLength {
+		t.Errorf("TestAnalyzeWaits_UnseenFuritenAndYaku: Expected the yaku checks not to log to the game, got %v", gs.GameLog[logLength:])
+	}
+}
+
+func TestAnalyzeWaits_OpenHandWithoutYaku(t *testing.T) {
+	gs, player := waitTestState(t, "456p789s11z45s")
+	pon := mustParseMPSZ(t, "999m")
+	for i := range pon {
+		pon[i].ID = 100 + i // Apart from the hand's IDs
+	}
+	player.Melds = []Meld{{Type: "Pon", Tiles: pon, CalledOn: pon[0], FromPlayer: 0}}
+	analysis := AnalyzeWaits(gs, player)
+	for _, w := range analysis.Tiles {
+		if !w.NoYakuRon || !w.NoYakuTsumo {
+			t.Errorf("TestAnalyzeWaits_OpenHandWithoutYaku: Expected no yaku on %s either way, got Ron %v, Tsumo %v", w.Tile.Name, w.NoYakuRon, w.NoYakuTsumo)
+		}
+	}
+	if text := FormatWaitAnalysis(analysis); !strings.Contains(text, "no yaku)") {
+		t.Errorf("TestAnalyzeWaits_OpenHandWithoutYaku: Expected a no yaku note, got %q", text)
+	}
+}
+
+func TestNewPlayerView_Waits(t *testing.T) {
+	gs, player := waitTestState(t, "123m456p789s11z45s")
+	if view := NewPlayerView(gs, 1); !strings.HasPrefix(view.Waits, "Waits: Sou 3") {
+		t.Errorf("TestNewPlayerView_Waits: Expected the seat's waits, got %q", view.Waits)
+	}
+	player.Hand = mustParseMPSZ(t, "123m456p789s15z35s")
+	if view := NewPlayerView(gs, 1); view.Waits != "" {
+		t.Errorf("TestNewPlayerView_Waits: Expected no waits for a hand that is not tenpai, got %q", view.Waits)
+	}
+}
+
+// mpszName writes a tile as MPSZ, e.g. "3s" or "1z".
+func mpszName(tile Tile) string {
+	kind := tileKind(tile)
+	if kind >= 27 {
+		return string(rune('1'+kind-27)) + "z"
+	}
+	return string(rune('1'+kind%9)) + string("mps"[kind/9])
+}
+
+func TestDiscardTile_KeepsWaitsOutOfGameLog(t *testing.T) {
+	gs := createTestGameState(nil)
+	for _, p := range gs.Players {
+		p.Hand = []Tile{}
+	}
+	human := gs.Players[0]
+	human.Hand = mustParseMPSZ(t, "123m456p789s11z45s9m")
+	gs.CurrentPlayerIndex = 0
+	for i, tile := range human.Hand {
+		if tile.Suit == "Man" && tile.Value == 9 {
+			DiscardTile(gs, human, i)
+			break
+		}
+	}
+	for _, entry := range gs.GameLog {
+		if strings.Contains(entry, "Waits: Sou 3") {
+			t.Errorf("TestDiscardTile_KeepsWaitsOutOfGameLog: Expected the human's waits only in their own view, got log entry %q", entry)
+		}
+	}
+	if view := NewPlayerView(gs, 0); !strings.HasPrefix(view.Waits, "Waits: Sou 3 (Ryanmen") {
+		t.Errorf("TestDiscardTile_KeepsWaitsOutOfGameLog: Expected the waits in the human's view, got %q", view.Waits)
+	}
+}
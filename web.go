@@ -0,0 +1,205 @@
+package main
+
+import (
+	"embed"
+	"flag"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/net/websocket"
+)
+
+//go:embed web
+var webAssets embed.FS
+
+// Request types sent to the browser.
+const (
+	WebRequestDiscard  = "discard"
+	WebRequestRiichi   = "riichi"
+	WebRequestChi      = "chi"
+	WebRequestConfirm  = "confirm"
+	WebRequestResult   = "result"
+	WebRequestGameOver = "gameover"
+)
+
+// WebRequest asks the browser for one decision; the browser answers with a
+// WebReply holding the index of one of Options. Options only ever contain
+// legal choices, built from the same checks the engine uses.
+type WebRequest struct {
+	Type    string     `json:"type"`
+	Prompt  string     `json:"prompt"`
+	Options []string   `json:"options"`
+	Legal   []string   `json:"legal,omitempty"` // LegalCallsOnDiscard for the asking seat, on call prompts
+	Text    string     `json:"text,omitempty"`  // Hand result or final standings
+	Error   string     `json:"error,omitempty"` // Set when the previous reply was rejected
+	View    PlayerView `json:"view"`
+}
+
+// WebReply is the browser's answer to a WebRequest.
+type WebReply struct {
+	Choice int `json:"choice"`
+}
+
+// WebInput is the PlayerInput for a browser connected over WebSocket.
+// Each call sends a WebRequest and blocks until a valid WebReply arrives.
+type WebInput struct {
+	conn         *websocket.Conn
+	seat         int
+	game         *GameState // Stopped when the browser goes away; nil outside a served game
+	disconnected bool
+}
+
+// ask sends req and returns the chosen option index, or -1 once the browser has gone away.
+// Replies outside the offered options are rejected and the request is sent again.
+func (in *WebInput) ask(req WebRequest) int {
+	for !in.disconnected {
+		if err := websocket.JSON.Send(in.conn, req); err != nil {
+			in.disconnect(err)
+			break
+		}
+		if len(req.Options) == 0 { // Notification only
+			return -1
+		}
+		var reply WebReply
+		if err := websocket.JSON.Receive(in.conn, &reply); err != nil {
+			in.disconnect(err)
+			break
+		}
+		if reply.Choice >= 0 && reply.Choice < len(req.Options) {
+			return reply.Choice
+		}
+		req.Error = fmt.Sprintf("Choice %d is not one of the offered options.", reply.Choice)
+	}
+	return -1
+}
+
+// disconnect stops the game: the current call answers with its default choice and RunGame
+// returns before the next turn.
+func (in *WebInput) disconnect(err error) {
+	in.disconnected = true
+	if in.game != nil {
+		in.game.Stopped = true
+		in.game.AddToGameLog("Web client disconnected, the game is stopped.")
+	}
+	fmt.Println("Web client disconnected, stopping the game:", err)
+}
+
+func (in *WebInput) request(gs *GameState, requestType, prompt string, options []string) WebRequest {
+	return WebRequest{Type: requestType, Prompt: prompt, Options: options, View: NewPlayerView(gs, in.seat)}
+}
+
+// ChooseDiscard offers the hand tiles by index. Without a client it makes the AI's choice.
+func (in *WebInput) ChooseDiscard(gs *GameState, player *Player) int {
+	choice := in.ask(in.request(gs, WebRequestDiscard, "Choose a tile to discard.", TilesToNames(player.Hand)))
+	if choice >= 0 {
+		return choice
+	}
+	return DefaultDiscardIndex(player)
+}
+
+func (in *WebInput) ChooseRiichi(gs *GameState, player *Player, options []RiichiOption) (int, bool) {
+	choice := in.ask(in.request(gs, WebRequestRiichi, "Declare Riichi?", riichiChoiceLabels(options)))
+	if choice < 0 || choice >= len(options) {
+		return -1, false
+	}
+	return choice, true
+}
+
+func (in *WebInput) ChooseChi(gs *GameState, player *Player, discardedTile Tile) (int, []Tile) {
+	legalCalls := LegalCallsOnDiscard(gs, player, discardedTile, gs.CurrentPlayerIndex)
+	if !contains(legalCalls, ActionChi) {
+		return 0, nil
+	}
+	handTilePairs := FindPossibleChiSequences(player, discardedTile, gs)
+	req := in.request(gs, WebRequestChi, chiPrompt(gs, player, discardedTile), chiChoiceLabels(discardedTile, handTilePairs))
+	req.Legal = legalCalls
+	return chiChoiceResult(discardedTile, handTilePairs, in.ask(req))
+}
+
+func (in *WebInput) Confirm(gs *GameState, player *Player, action string, tile Tile, prompt string) bool {
+	label := confirmButtonLabel(gs, player, action, tile)
+	if label == "" {
+		gs.AddToGameLog(fmt.Sprintf("Web: %s is not legal for %s on %s, skipping.", action, player.Name, tile.Name))
+		return false
+	}
+	req := in.request(gs, WebRequestConfirm, strings.TrimSuffix(prompt, " (y/n): "), []string{label, "Skip"})
+	if action == ActionRon || action == ActionKan || action == ActionPon {
+		req.Legal = LegalCallsOnDiscard(gs, player, tile, gs.CurrentPlayerIndex)
+	}
+	return in.ask(req) == 0
+}
+
+// RoundEnded shows the hand result and waits for the browser to continue.
+func (in *WebInput) RoundEnded(gs *GameState) {
+	req := in.request(gs, WebRequestResult, "Hand over.", []string{"Next Hand"})
+	req.Text = roundResultText(gs)
+	in.ask(req)
+}
+
+// serveWebGame plays one game under rules for the browser on ws, seating it at index 0, and adds
+// it to the statistics in statsFile if set and the game was finished.
+func serveWebGame(ws *websocket.Conn, rules RuleSet, statsFile string) {
+	defer ws.Close()
+	gs := NewGameStateWithRules(defaultPlayerNames, rules)
+	input := &WebInput{conn: ws, seat: 0, game: gs}
+	gs.Input = input
+	PlayRecordedGame(gs, statsFile)
+	if gs.Stopped {
+		return
+	}
+
+	lines := []string{}
+	for i, p := range FinalStandings(gs) {
+		lines = append(lines, fmt.Sprintf("%d. %s: %d points", i+1, p.Name, p.Score))
+	}
+	req := input.request(gs, WebRequestGameOver, "Game over.", nil)
+	req.Text = strings.Join(lines, "\n")
+	input.ask(req)
+}
+
+// NewWebServer serves the web UI at "/" and one game under rules per WebSocket connection at
+// "/ws", recording finished games in statsFile if set.
+func NewWebServer(rules RuleSet, statsFile string) http.Handler {
+	static, err := fs.Sub(webAssets, "web")
+	if err != nil {
+		panic(err) // The embedded directory is fixed at build time
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.FS(static)))
+	play := func(ws *websocket.Conn) { serveWebGame(ws, rules, statsFile) }
+	mux.Handle("/ws", websocket.Server{Handler: play, Handshake: checkWebOrigin})
+	return mux
+}
+
+// checkWebOrigin only accepts WebSocket connections opened by a page from this server, so that
+// another site cannot start a game in a visitor's browser.
+func checkWebOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+	if origin == nil || origin.Host != req.Host {
+		return fmt.Errorf("websocket: origin %q does not match host %q", req.Header.Get("Origin"), req.Host)
+	}
+	config.Origin = origin
+	return nil
+}
+
+// runServeCommand starts the HTTP server ("serve" command).
+func runServeCommand(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	rulesFile := addRulesFlag(flags)
+	statsFile := flags.String("stats", "", "add each finished game to the statistics in this file")
+	flags.Parse(args)
+	rules := mustLoadRuleSet(*rulesFile)
+
+	fmt.Printf("Serving Riichi Mahjong at http://%s/\n", *addr)
+	if err := http.ListenAndServe(*addr, NewWebServer(rules, *statsFile)); err != nil {
+		fmt.Println("Server error:", err)
+		os.Exit(1)
+	}
+}
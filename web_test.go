@@ -0,0 +1,155 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// dialTestWebInput starts a server whose single WebSocket handler runs play with a
+// WebInput for the connection, and returns the client end.
+func dialTestWebInput(t *testing.T, play func(in *WebInput)) *websocket.Conn {
+	t.Helper()
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		play(&WebInput{conn: ws, seat: 0})
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), "", "http://localhost/")
+	if err != nil {
+		t.Fatalf("dialTestWebInput: could not connect: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestWebServer_ServesIndex(t *testing.T) {
+	srv := httptest.NewServer(NewWebServer(DefaultRuleSet(), ""))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/")
+	if err != nil {
+		t.Fatalf("TestWebServer_ServesIndex: GET / failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "new WebSocket") {
+		t.Errorf("TestWebServer_ServesIndex: Expected 200 with the web UI, got %d", resp.StatusCode)
+	}
+}
+
+func TestWebInputConfirm_OffersOnlyLegalCallAndRejectsBadReply(t *testing.T) {
+	gs := createTestGameState(nil)
+	caller := gs.Players[0]
+	caller.Hand = TilesFromString("5p 5p 1m 2m 3m 7s 8s 9s E S W N")
+	gs.CurrentPlayerIndex = 3
+	fivePin := TilesFromString("5p")[0]
+
+	result := make(chan bool, 1)
+	client := dialTestWebInput(t, func(in *WebInput) {
+		result <- in.Confirm(gs, caller, ActionPon, fivePin, "P1, declare PON on Pin 5? (y/n): ")
+	})
+
+	var req WebRequest
+	if err := websocket.JSON.Receive(client, &req); err != nil {
+		t.Fatalf("TestWebInputConfirm_OffersOnlyLegalCallAndRejectsBadReply: receive failed: %v", err)
+	}
+	if req.Type != WebRequestConfirm || len(req.Options) != 2 || req.Options[0] != "Pon Pin 5" {
+		t.Errorf("TestWebInputConfirm_OffersOnlyLegalCallAndRejectsBadReply: Expected confirm with [Pon Pin 5, Skip], got %s %v", req.Type, req.Options)
+	}
+	if !contains(req.Legal, ActionPon) || contains(req.Legal, ActionChi) {
+		t.Errorf("TestWebInputConfirm_OffersOnlyLegalCallAndRejectsBadReply: Expected legal calls [Pon], got %v", req.Legal)
+	}
+	if len(req.View.Hand) != 12 || len(req.View.Players) != 4 {
+		t.Errorf("TestWebInputConfirm_OffersOnlyLegalCallAndRejectsBadReply: Expected own 12-tile hand and 4 seats in view, got %d tiles, %d seats", len(req.View.Hand), len(req.View.Players))
+	}
+
+	websocket.JSON.Send(client, WebReply{Choice: 7}) // Not an offered option
+	if err := websocket.JSON.Receive(client, &req); err != nil {
+		t.Fatalf("TestWebInputConfirm_OffersOnlyLegalCallAndRejectsBadReply: receive after bad reply failed: %v", err)
+	}
+	if req.Error == "" {
+		t.Errorf("TestWebInputConfirm_OffersOnlyLegalCallAndRejectsBadReply: Expected the request to be re-sent with an error")
+	}
+
+	websocket.JSON.Send(client, WebReply{Choice: 0})
+	select {
+	case ok := <-result:
+		if !ok {
+			t.Errorf("TestWebInputConfirm_OffersOnlyLegalCallAndRejectsBadReply: Expected Confirm to return true")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("TestWebInputConfirm_OffersOnlyLegalCallAndRejectsBadReply: Confirm did not return")
+	}
+}
+
+func TestWebInputConfirm_IllegalCallNeverSent(t *testing.T) {
+	gs := createTestGameState(nil)
+	caller := gs.Players[0]
+	caller.Hand = TilesFromString("5p 1m 2m 3m 7s 8s 9s E S W N 1p 9p")
+	gs.CurrentPlayerIndex = 3
+
+	in := &WebInput{} // No connection: an illegal call must be refused before anything is sent
+	if in.Confirm(gs, caller, ActionPon, TilesFromString("5p")[0], "") {
+		t.Errorf("TestWebInputConfirm_IllegalCallNeverSent: Expected illegal Pon to be refused")
+	}
+}
+
+func TestWebInputChooseDiscard_DisconnectedDiscardsDrawnTile(t *testing.T) {
+	gs := createTestGameState(nil)
+	player := gs.Players[0]
+	player.Hand = TilesFromString("1m 2m 3m 4p 5p 6p 7s 8s 9s E E S S W")
+	drawn := player.Hand[3]
+	player.JustDrawnTile = &drawn
+
+	in := &WebInput{disconnected: true}
+	if got := in.ChooseDiscard(gs, player); got != 3 {
+		t.Errorf("TestWebInputChooseDiscard_DisconnectedDiscardsDrawnTile: Expected drawn tile index 3, got %d", got)
+	}
+}
+
+func TestWebServer_RejectsForeignOrigin(t *testing.T) {
+	srv := httptest.NewServer(NewWebServer(DefaultRuleSet(), ""))
+	defer srv.Close()
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+
+	if client, err := websocket.Dial(wsURL, "", "http://evil.example/"); err == nil {
+		client.Close()
+		t.Errorf("TestWebServer_RejectsForeignOrigin: Expected a connection from another origin to be refused")
+	}
+	client, err := websocket.Dial(wsURL, "", srv.URL+"/")
+	if err != nil {
+		t.Fatalf("TestWebServer_RejectsForeignOrigin: Expected a connection from the served page, got %v", err)
+	}
+	client.Close()
+}
+
+func TestWebInput_DisconnectStopsGame(t *testing.T) {
+	done := make(chan *GameState, 1)
+	client := dialTestWebInput(t, func(in *WebInput) {
+		gs := NewGameState(defaultPlayerNames)
+		in.game = gs
+		gs.Input = in
+		RunGame(gs)
+		done <- gs
+	})
+	var req WebRequest
+	if err := websocket.JSON.Receive(client, &req); err != nil {
+		t.Fatalf("TestWebInput_DisconnectStopsGame: Expected a first request, got %v", err)
+	}
+	client.Close()
+
+	select {
+	case gs := <-done:
+		if !gs.Stopped || gs.GamePhase == PhaseGameEnd {
+			t.Errorf("TestWebInput_DisconnectStopsGame: Expected the game stopped before its end, got stopped %v, phase %v", gs.Stopped, gs.GamePhase)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("TestWebInput_DisconnectStopsGame: Expected RunGame to return after the client left")
+	}
+}
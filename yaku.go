@@ -8,241 +8,59 @@ import (
 )
 
 // YakuResult holds the name and Han value of an identified Yaku.
+// Yakuman is the Yakuman multiple (1, or 2 for a double Yakuman), 0 for regular Yaku;
+// scoring uses it rather than Han.
 type YakuResult struct {
-	Name string
-	Han  int
+	Name    string
+	Han     int
+	Yakuman int
 }
 
 // IdentifyYaku analyzes the winning hand and conditions to determine all applicable Yaku and total Han.
+// The yaku come from gs.YakuRegistry (StandardYaku if unset), filtered and revalued by gs.Rules.
 func IdentifyYaku(player *Player, agariHai Tile, isTsumo bool, gs *GameState) ([]YakuResult, int) {
-	var results []YakuResult // Use var for initial empty slice
-
 	isMenzen := isMenzenchin(player, isTsumo, agariHai)
 	allTiles := getAllTilesInHand(player, agariHai, isTsumo)
 
-	if len(allTiles) != 14 {
-		gs.AddToGameLog(fmt.Sprintf("Error in IdentifyYaku: Hand for %s has %d tiles, expected 14. Cannot evaluate Yaku.", player.Name, len(allTiles)))
-		return []YakuResult{}, 0
-	}
-
-	// --- 1. Yakuman Checks ---
-	var yakumanResults []YakuResult
-
-	// Luck-based Yakuman first
-	if ok, name, han := checkTenhou(player, gs, isTsumo); ok {
-		addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-	}
-	// Check next only if previous luck yakuman not found (they are mutually exclusive)
-	if len(yakumanResults) == 0 {
-		if ok, name, han := checkChihou(player, gs, isTsumo); ok {
-			addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-		}
-	}
-	if len(yakumanResults) == 0 && !isTsumo { // Renhou is Ron only
-		if ok, name, han := checkRenhou(player, gs, isTsumo); ok {
-			addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-		}
-	}
-
-	// If a luck-based Yakuman was found, we typically stop and don't check structural ones,
-	// unless specific rules allow stacking (e.g. Tenhou + Daisangen).
-	// For now, if a luck yakuman is found, it is *the* yakuman.
-	if len(yakumanResults) > 0 {
-		finalLuckYakumanHan := 0
-		for _, r := range yakumanResults {finalLuckYakumanHan += r.Han}
-		gs.AddToGameLog(fmt.Sprintf("Luck-Based Yakuman Identified: %v. Total Han: %d", yakumanResults, finalLuckYakumanHan))
-		return yakumanResults, finalLuckYakumanHan
-	}
-
-
-	// Structural Yakuman - if no luck yakuman found
-	if ok, name, han := checkKokushiMusou(allTiles, agariHai); ok {
-		addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-	}
-	if ok, name, han := checkSuuankou(player, agariHai, isTsumo, isMenzen, allTiles); ok {
-		addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-	}
-	if ok, name, han := checkDaisangen(player, allTiles); ok {
-		addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-	}
-
-	daisuushiiFound := false // Flag to manage Daisuushii/Shousuushii precedence
-	for _, yr := range yakumanResults { if yr.Name == "Daisuushii" { daisuushiiFound = true; break } }
-
-	if !daisuushiiFound {
-		if ok, name, han := checkDaisuushii(player, allTiles); ok {
-			// If Daisuushii is found, remove Shousuushii if it was somehow added (shouldn't happen if checks are ordered)
-			var tempResults []YakuResult
-			for _, yr := range yakumanResults {
-				if yr.Name != "Shousuushii" { tempResults = append(tempResults, yr) }
-			}
-			yakumanResults = tempResults
-			addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-			daisuushiiFound = true
-		}
-	}
-	if !daisuushiiFound { // Only check Shousuushii if Daisuushii was NOT found
-		if ok, name, han := checkShousuushii(player, allTiles); ok {
-			addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-		}
-	}
-
-	if ok, name, han := checkTsuuiisou(player, allTiles); ok {
-		addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-	}
-	if ok, name, han := checkChinroutou(player, allTiles); ok {
-		addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-	}
-	if ok, name, han := checkRyuuiisou(player, allTiles); ok {
-		addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-	}
-	if ok, name, han := checkChuurenPoutou(isMenzen, allTiles, agariHai); ok {
-		addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-	}
-	if ok, name, han := checkSuukantsu(player); ok {
-		addUniqueYakuman(&yakumanResults, YakuResult{name, han})
-	}
-
-	if len(yakumanResults) > 0 {
-		finalYakumanHan := 0
-		// Sum Han for multiple distinct Yakuman (e.g. Daisangen + Tsuuiisou if allowed by ruleset)
-		for _, r := range yakumanResults { finalYakumanHan += r.Han }
-		gs.AddToGameLog(fmt.Sprintf("Structural Yakuman(s) Identified: %v. Total Han: %d", yakumanResults, finalYakumanHan))
-		return yakumanResults, finalYakumanHan
-	}
-
-	// --- 2. Regular Yaku Checks (Only if NO Yakuman was found) ---
-	var regularResults []YakuResult
-
-	// --- 1 Han Yaku ---
-	if ok, han := checkRiichi(player, gs); ok {
-		regularResults = append(regularResults, YakuResult{"Riichi", han})
-		if okDR, nameDR, hanDR := checkDoubleRiichi(player, gs); okDR {
-			regularResults = append(regularResults, YakuResult{nameDR, hanDR})
-		}
-		if okIp, hanIp := checkIppatsu(player, gs); okIp {
-			regularResults = append(regularResults, YakuResult{"Ippatsu", hanIp})
-		}
-	}
-
-	if ok, han := checkMenzenTsumo(isTsumo, isMenzen); ok {
-		regularResults = append(regularResults, YakuResult{"Menzen Tsumo", han})
-	}
-
-	pinfuAwarded := false
-	if isMenzen {
-		if ok, han := checkPinfu(player, agariHai, isMenzen, allTiles, gs); ok {
-			regularResults = append(regularResults, YakuResult{"Pinfu", han})
-			pinfuAwarded = true
+	expectedTiles := 14 // Plus one per Kan: its fourth tile is kept in AllTiles for Dora
+	for _, meld := range player.Melds {
+		if IsKanMeld(meld) {
+			expectedTiles++
 		}
 	}
-
-	if ok, han := checkTanyao(allTiles); ok {
-		regularResults = append(regularResults, YakuResult{"Tanyao", han})
-	}
-
-	if yakuhaiRes, _ := checkYakuhai(player, gs, allTiles); len(yakuhaiRes) > 0 {
-		regularResults = append(regularResults, yakuhaiRes...)
-	}
-
-	if ok, name, han := checkHaiteiHoutei(gs, isTsumo); ok {
-		regularResults = append(regularResults, YakuResult{name, han})
+	if len(allTiles) != expectedTiles {
+		gs.AddToGameLog(fmt.Sprintf("Error in IdentifyYaku: Hand for %s has %d tiles, expected %d. Cannot evaluate Yaku.", player.Name, len(allTiles), expectedTiles))
+		return []YakuResult{}, 0
 	}
 
-	if ok, han := checkRinshanKaihou(gs, isTsumo); ok {
-		regularResults = append(regularResults, YakuResult{"Rinshan Kaihou", han})
+	registry := gs.YakuRegistry
+	if registry == nil {
+		registry = StandardYaku
 	}
+	ctx := &YakuContext{Player: player, AgariHai: agariHai, IsTsumo: isTsumo, IsMenzen: isMenzen, AllTiles: allTiles, GS: gs}
+	results, category := registry.Identify(ctx, gs.Rules)
 
-	if !isTsumo { if ok, han := checkChankan(gs); ok { regularResults = append(regularResults, YakuResult{"Chankan", han}) } }
-
-	// --- 2 Han Yaku ---
-	chiitoitsuFound := false
-	if !pinfuAwarded { // Pinfu and Chiitoitsu are mutually exclusive
-		if ok, han := checkChiitoitsu(player, allTiles, isMenzen); ok {
-			regularResults = append(regularResults, YakuResult{"Chiitoitsu", han})
-			chiitoitsuFound = true
-		}
-	}
-
-	if !chiitoitsuFound {
-		if ok, han := checkToitoi(player, allTiles); ok {
-			regularResults = append(regularResults, YakuResult{"Toitoi", han})
-		}
-		if ok, han := checkSanankou(player, agariHai, isTsumo, allTiles); ok {
-			regularResults = append(regularResults, YakuResult{"Sanankou", han})
-		}
-		if ok, name, han := checkSanshokuDoukou(player, allTiles); ok {
-			regularResults = append(regularResults, YakuResult{name, han})
-		}
-		if ok, han := checkShousangen(player, allTiles); ok {
-			regularResults = append(regularResults, YakuResult{"Shousangen", han})
-		}
-	}
+	totalHan := 0
+	for _, r := range results { totalHan += r.Han }
 
-	if ok, name, han := checkSankantsu(player); ok {
-		regularResults = append(regularResults, YakuResult{name, han})
+	// --- 1. Yakuman: luck-based ones are never combined with structural ones ---
+	switch category {
+	case YakuCategoryLuck:
+		gs.AddToGameLog(fmt.Sprintf("Luck-Based Yakuman Identified: %v. Total Han: %d", results, totalHan))
+		return results, totalHan
+	case YakuCategoryYakuman:
+		gs.AddToGameLog(fmt.Sprintf("Structural Yakuman(s) Identified: %v. Total Han: %d", results, totalHan))
+		return results, totalHan
 	}
-	
-	if okHonro, hanHonro := checkHonroutou(allTiles); okHonro {
-		if !chiitoitsuFound {
-			regularResults = append(regularResults, YakuResult{"Honroutou", hanHonro})
-		} else {
-			// gs.AddToGameLog("Note: Hand is Chiitoitsu and also Honroutou structure; Honroutou not added as separate Yaku.")
-		}
-	}
-
-	// --- 3+ Han Yaku ---
-	if !chiitoitsuFound {
-		if ok, name, han := checkSanshokuDoujun(player, isMenzen, allTiles); ok {
-			regularResults = append(regularResults, YakuResult{name, han})
-		}
-		if ok, name, han := checkIttsuu(player, isMenzen, allTiles); ok {
-			regularResults = append(regularResults, YakuResult{name, han})
-		}
-
-		ryanpeikouFound := false
-		if isMenzen {
-			if okRyan, hanRyan := checkRyanpeikou(player, isMenzen, allTiles); okRyan {
-				regularResults = append(regularResults, YakuResult{"Ryanpeikou", hanRyan})
-				ryanpeikouFound = true
-			}
-			if !ryanpeikouFound {
-				if okIipe, hanIipe := checkIipeikou(player, isMenzen, allTiles); okIipe {
-					regularResults = append(regularResults, YakuResult{"Iipeikou", hanIipe})
-				}
-			}
-		}
-		
-		// Junchan Taiyou vs Honroutou: Mutually exclusive by definition
-		// (Junchan requires simples, Honroutou forbids simples)
-		// No explicit check needed if individual Yaku are correct.
-		if ok, han := checkJunchan(player, isMenzen, allTiles); ok {
-			regularResults = append(regularResults, YakuResult{"Junchan Taiyou", han})
-		}
 
-		chinitsuFound := false
-		if okC, hanC := checkChinitsu(allTiles, isMenzen); okC {
-			regularResults = append(regularResults, YakuResult{"Chinitsu", hanC})
-			chinitsuFound = true
-		}
-		if !chinitsuFound {
-			if okH, hanH := checkHonitsu(allTiles, isMenzen); okH {
-				regularResults = append(regularResults, YakuResult{"Honitsu", hanH})
-			}
-		}
-	}
-	
 	// Nagashi Mangan check: Typically scores at Ryuukyoku, not as a winning Yaku.
 	// If rules allow it as a win:
 	// if ok, name, han := checkNagashiMangan(player, gs); ok {
-	// 	regularResults = []YakuResult{YakuResult{name, han}} // Nagashi often overrides other regular yaku
+	// 	results = []YakuResult{{Name: name, Han: han}} // Nagashi often overrides other regular yaku
 	// }
 
-	// --- 3. Dora Calculation ---
-	currentRegularHan := 0
-	for _, r := range regularResults { currentRegularHan += r.Han }
-
-	if currentRegularHan > 0 {
+	// --- 2. Dora Calculation (only with at least one regular Yaku) ---
+	if totalHan > 0 {
 		doraCount := 0
 		doraCount += countDora(allTiles, gs.DoraIndicators)
 		doraCount += countRedDora(allTiles)
@@ -250,70 +68,16 @@ func IdentifyYaku(player *Player, agariHai Tile, isTsumo bool, gs *GameState) ([
 			doraCount += countDora(allTiles, gs.UraDoraIndicators)
 		}
 		if doraCount > 0 {
-			regularResults = append(regularResults, YakuResult{fmt.Sprintf("Dora %d", doraCount), doraCount})
+			results = append(results, YakuResult{Name: fmt.Sprintf("Dora %d", doraCount), Han: doraCount})
+			totalHan += doraCount
 		}
 	}
 
-	// --- Final Han Summation & Result Consolidation ---
-	finalHanSum := 0
-	finalResultsToReturn := []YakuResult{}
-	seenNames := make(map[string]int)
-
-	for _, r := range regularResults {
-		isUniqueOrAllowedMultiple := true
-		if seenNames[r.Name] > 0 { // If name already seen
-			if !(strings.HasPrefix(r.Name, "Yakuhai")) { // Non-Yakuhai Yaku should be unique by name
-				isUniqueOrAllowedMultiple = false
-			} else { // For Yakuhai, ensure it's a different specific Yakuhai
-				alreadyAddedExactYakuhai := false
-				for _, fr := range finalResultsToReturn {
-					if fr.Name == r.Name { alreadyAddedExactYakuhai = true; break }
-				}
-				if alreadyAddedExactYakuhai { isUniqueOrAllowedMultiple = false }
-			}
-		}
-
-		if isUniqueOrAllowedMultiple {
-			finalResultsToReturn = append(finalResultsToReturn, r)
-			finalHanSum += r.Han
-			seenNames[r.Name]++
-		}
-	}
-	
-	if finalHanSum == 0 && len(finalResultsToReturn) > 0 && strings.HasPrefix(finalResultsToReturn[0].Name, "Dora") {
-		gs.AddToGameLog(fmt.Sprintf("IdentifyYaku for %s resulted in only Dora (%v), no other actual Yaku. Invalid win.", player.Name, finalResultsToReturn))
-		return []YakuResult{}, 0
-	}
-	if finalHanSum == 0 && len(finalResultsToReturn) == 0 {
+	if results == nil {
 		// This means no Yaku were found at all. CanDeclareRon/Tsumo should prevent this.
-		// gs.AddToGameLog(fmt.Sprintf("IdentifyYaku for %s resulted in 0 Han and no Yaku.", player.Name))
-	}
-
-	return finalResultsToReturn, finalHanSum
-}
-
-// addUniqueYakuman adds a yakuman to the list only if its specific name isn't already present,
-// or if the new one is a higher-valued version of an existing one (e.g. double yakuman).
-func addUniqueYakuman(yakumanList *[]YakuResult, newYakuman YakuResult) {
-	for i, existingYakuman := range *yakumanList {
-		if existingYakuman.Name == newYakuman.Name {
-			if newYakuman.Han > existingYakuman.Han { // New one is stronger version
-				(*yakumanList)[i] = newYakuman // Replace
-			}
-			return // Either replaced or already have same/stronger
-		}
-		// Handle cases like "Kokushi Musou" vs "Kokushi Musou Juusanmenmachi"
-		if (strings.HasPrefix(newYakuman.Name, existingYakuman.Name) && newYakuman.Han > existingYakuman.Han) ||
-		   (strings.HasPrefix(existingYakuman.Name, newYakuman.Name) && existingYakuman.Han > newYakuman.Han) {
-			// Complex case: one is a prefix of the other (e.g. base vs double version)
-			// Keep the one with higher Han. If new is higher, replace. If existing is higher, do nothing.
-			if newYakuman.Han > existingYakuman.Han {
-				(*yakumanList)[i] = newYakuman
-			}
-			return
-		}
+		return []YakuResult{}, 0
 	}
-	*yakumanList = append(*yakumanList, newYakuman) // Add if truly new
+	return results, totalHan
 }
 
 // --- Helper Functions ---
@@ -386,11 +150,6 @@ func countRedDora(handTiles []Tile) int {
 	count := 0; for _, tile := range handTiles { if tile.IsRed { count++ } }; return count
 }
 
-func groupContainsTileID(group DecomposedGroup, tileID int) bool {
-	for _, t := range group.Tiles { if t.ID == tileID { return true } }
-	return false
-}
-
 func sequencesAreEqual(seq1Tiles, seq2Tiles []Tile) bool {
 	if len(seq1Tiles) != 3 || len(seq2Tiles) != 3 { return false }
 	for i := 0; i < 3; i++ {
@@ -406,8 +165,50 @@ func WindValueFromName(windName string) int {
 	return 0
 }
 
+// isYakuhai reports whether a pair or triplet of tile would be worth Yakuhai to player: a Dragon,
+// the seat wind or the prevalent wind.
+func isYakuhai(tile Tile, player *Player, gs *GameState) bool {
+	if tile.Suit == "Dragon" {
+		return true
+	}
+	return tile.Suit == "Wind" && (tile.Value == WindValueFromName(player.SeatWind) || tile.Value == WindValueFromName(gs.PrevalentWind))
+}
+
 // --- Individual Yaku Check Functions ---
 
+// == LUCK YAKUMAN ==
+// Tenhou, Chihou and Renhou need an uninterrupted first go-around: no call by anyone and no
+// meld (an Ankan counts) in the winner's hand.
+
+// checkTenhou: the dealer wins on their first draw, before any discard.
+func checkTenhou(player *Player, gs *GameState, isTsumo bool) (bool, string, int) {
+	isDealer := gs.Players[gs.DealerIndexThisRound] == player
+	if isTsumo && isDealer && gs.TurnNumber == 0 && !gs.AnyCallMadeThisRound && len(player.Melds) == 0 {
+		return true, "Tenhou", 13
+	}
+	return false, "", 0
+}
+
+// checkChihou: a non-dealer wins on their first draw, before their first discard.
+func checkChihou(player *Player, gs *GameState, isTsumo bool) (bool, string, int) {
+	isDealer := gs.Players[gs.DealerIndexThisRound] == player
+	if isTsumo && !isDealer && !player.HasMadeFirstDiscardThisRound && gs.TurnNumber < len(gs.Players) &&
+		!gs.AnyCallMadeThisRound && len(player.Melds) == 0 {
+		return true, "Chihou", 13
+	}
+	return false, "", 0
+}
+
+// checkRenhou: a non-dealer wins by Ron before their first draw.
+func checkRenhou(player *Player, gs *GameState, isTsumo bool) (bool, string, int) {
+	isDealer := gs.Players[gs.DealerIndexThisRound] == player
+	if !isTsumo && !isDealer && !player.HasMadeFirstDiscardThisRound && gs.IsFirstGoAround &&
+		!gs.AnyCallMadeThisRound && len(player.Melds) == 0 {
+		return true, "Renhou", 13
+	}
+	return false, "", 0
+}
+
 // == YAKUMAN ==
 func checkKokushiMusou(allTiles []Tile, agariHai Tile) (bool, string, int) {
 	if !IsKokushiMusou(allTiles) { return false, "", 0 }
@@ -445,8 +246,7 @@ func checkSuuankou(player *Player, agariHai Tile, isTsumo bool, isMenzen bool, a
 		isEffectiveConcealedMeld := false
 		if group.Type == TypeTriplet && group.IsConcealed {
 			if !isTsumo && groupContainsTileID(group, agariHai.ID) {} else { isEffectiveConcealedMeld = true }
-		} else if group.Type == TypeQuad && group.IsConcealed { isEffectiveConcealedMeld = true }
-		else if group.Type == TypePair { if foundPairInDecomp { return false, "", 0 }; pairGroup = group; foundPairInDecomp = true }
+		} else if group.Type == TypeQuad && group.IsConcealed { isEffectiveConcealedMeld = true } else if group.Type == TypePair { if foundPairInDecomp { return false, "", 0 }; pairGroup = group; foundPairInDecomp = true }
 		if isEffectiveConcealedMeld { concealedPungKanCount++ }
 	}
 	if concealedPungKanCount == 4 && foundPairInDecomp {
@@ -565,6 +365,16 @@ func checkSuukantsu(player *Player) (bool, string, int) { // Yakuman
 }
 
 // == 1 HAN YAKU ==
+func checkRiichi(player *Player, gs *GameState) (bool, int) {
+	if player.IsRiichi { return true, 1 }
+	return false, 0
+}
+
+func checkIppatsu(player *Player, gs *GameState) (bool, int) {
+	if player.IsRiichi && player.IsIppatsu { return true, 1 }
+	return false, 0
+}
+
 func checkDoubleRiichi(player *Player, gs *GameState) (bool, string, int) {
 	if player.IsRiichi && player.DeclaredDoubleRiichi { return true, "Double Riichi Bonus", 1 }
 	return false, "", 0
@@ -581,9 +391,7 @@ func checkPinfu(player *Player, agariHai Tile, isMenzen bool, allTiles []Tile, g
 	if !success || decomp == nil { return false, 0 }
 	seqCount := 0; var pairGrp DecomposedGroup; pairFound := false
 	for _, grp := range decomp {
-		if grp.Type == TypeSequence { seqCount++ }
-		else if grp.Type == TypePair { if pairFound {return false,0}; pairGrp = grp; pairFound=true }
-		else { return false, 0 }
+		if grp.Type == TypeSequence { seqCount++ } else if grp.Type == TypePair { if pairFound {return false,0}; pairGrp = grp; pairFound=true } else { return false, 0 }
 	}
 	if seqCount != 4 || !pairFound || len(pairGrp.Tiles) == 0 { return false, 0 }
 	if isYakuhai(pairGrp.Tiles[0], player, gs) { return false, 0 }
@@ -618,15 +426,15 @@ func checkYakuhai(player *Player, gs *GameState, allTiles []Tile) ([]YakuResult,
 			if len(group.Tiles) == 0 { continue }
 			tile := group.Tiles[0]
 			if tile.Suit == "Dragon" {
-				results = append(results, YakuResult{fmt.Sprintf("Yakuhai (%s)", tile.Name), 1}); totalHan++
+				results = append(results, YakuResult{Name: fmt.Sprintf("Yakuhai (%s)", tile.Name), Han: 1}); totalHan++
 			} else if tile.Suit == "Wind" {
 				isSeat := (tile.Value == seatWindVal); isPrev := (tile.Value == prevWindVal)
 				if isSeat && isPrev {
-					results = append(results, YakuResult{fmt.Sprintf("Yakuhai (Seat & Prevalent %s)", tile.Name), 2}); totalHan += 2
+					results = append(results, YakuResult{Name: fmt.Sprintf("Yakuhai (Seat & Prevalent %s)", tile.Name), Han: 2}); totalHan += 2
 				} else if isSeat {
-					results = append(results, YakuResult{fmt.Sprintf("Yakuhai (Seat Wind %s)", tile.Name), 1}); totalHan++
+					results = append(results, YakuResult{Name: fmt.Sprintf("Yakuhai (Seat Wind %s)", tile.Name), Han: 1}); totalHan++
 				} else if isPrev {
-					results = append(results, YakuResult{fmt.Sprintf("Yakuhai (Prevalent Wind %s)", tile.Name), 1}); totalHan++
+					results = append(results, YakuResult{Name: fmt.Sprintf("Yakuhai (Prevalent Wind %s)", tile.Name), Han: 1}); totalHan++
 				}
 			}
 		}
@@ -662,9 +470,7 @@ func checkToitoi(player *Player, allTiles []Tile) (bool, int) {
 	if !success || decomp == nil { return false, 0 }
 	pungKanCount, pairCount := 0,0
 	for _, group := range decomp {
-		if group.Type == TypeTriplet || group.Type == TypeQuad { pungKanCount++ }
-		else if group.Type == TypePair { pairCount++ }
-		else { return false, 0 }
+		if group.Type == TypeTriplet || group.Type == TypeQuad { pungKanCount++ } else if group.Type == TypePair { pairCount++ } else { return false, 0 }
 	}
 	if pungKanCount == 4 && pairCount == 1 { return true, 2 }
 	return false, 0
@@ -704,7 +510,7 @@ func checkSanshokuDoukou(player *Player, allTiles []Tile) (bool, string, int) {
 }
 
 func checkSankantsu(player *Player) (bool, string, int) {
-	kanCount := 0; for _, meld := range player.Melds { if strings.Contains(meld.Type, "Kan") { kanCount++ } }
+	kanCount := 0; for _, meld := range player.Melds { if strings.Contains(meld.Type, "kan") { kanCount++ } }
 	if kanCount == 3 { return true, "Sankantsu", 2 }
 	return false, "", 0
 }
@@ -779,9 +585,7 @@ func checkRyanpeikou(player *Player, isMenzen bool, allTiles []Tile) (bool, int)
 	if !success || decomp == nil { return false, 0 }
 	sequences := []DecomposedGroup{}; pairCount := 0
 	for _, grp := range decomp {
-		if grp.Type == TypeSequence { sequences = append(sequences, grp) }
-		else if grp.Type == TypePair { pairCount++ }
-		else { return false, 0 }
+		if grp.Type == TypeSequence { sequences = append(sequences, grp) } else if grp.Type == TypePair { pairCount++ } else { return false, 0 }
 	}
 	if len(sequences) != 4 || pairCount != 1 { return false, 0 }
 	sort.Slice(sequences, func(i, j int) bool { /* complex sort needed or map approach */
@@ -830,11 +634,9 @@ func checkJunchan(player *Player, isMenzen bool, allTiles []Tile) (bool, int) {
 func checkHonitsu(allTiles []Tile, isMenzen bool) (bool, int) {
 	targetSuit := ""; hasHonors := false; hasNumbers := false
 	for _, tile := range allTiles {
-		if IsHonor(tile) { hasHonors = true }
-		else {
+		if IsHonor(tile) { hasHonors = true } else {
 			hasNumbers = true
-			if targetSuit == "" { targetSuit = tile.Suit }
-			else if tile.Suit != targetSuit { return false, 0 }
+			if targetSuit == "" { targetSuit = tile.Suit } else if tile.Suit != targetSuit { return false, 0 }
 		}
 	}
 	if targetSuit != "" && hasHonors && hasNumbers {
@@ -853,19 +655,5 @@ func checkChinitsu(allTiles []Tile, isMenzen bool) (bool, int) {
 	return false, 0
 }
 
-// checkNagashiMangan is defined in checks.go as it's an abortive draw / special condition,
-// but if treated as a winning Yaku, its check would be:
-/*
-func checkNagashiMangan(player *Player, gs *GameState) (bool, string, int) {
-	if player.HasHadDiscardCalledThisRound { return false, "", 0 }
-	if len(player.Discards) == 0 { return false, "", 0 }
-	for _, tile := range player.Discards {
-		if !IsTerminalOrHonor(tile) { return false, "", 0 }
-	}
-	// This Yaku is typically only awarded at Ryuukyoku (exhaustive draw)
-	if gs.RoundWinner == nil && len(gs.Wall) == 0 { // Check if it's an exhaustive draw
-		return true, "Nagashi Mangan", 5 // Mangan-level Yaku
-	}
-	return false, "", 0
-}
-*/
\ No newline at end of file
+// checkNagashiMangan is defined in checks.go as it's an abortive draw / special condition
+// paid at Ryuukyoku rather than a winning Yaku.
\ No newline at end of file
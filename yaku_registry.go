@@ -0,0 +1,442 @@
+package main
+
+import "fmt"
+
+// YakuCategory decides when a yaku is evaluated. Categories are checked in order and the
+// first one that awards anything ends the search: a luck yakuman is never combined with a
+// structural one, and regular yaku only count when no yakuman applies.
+type YakuCategory int
+
+const (
+	YakuCategoryLuck    YakuCategory = iota // Tenhou, Chihou, Renhou
+	YakuCategoryYakuman                     // Structural yakuman
+	YakuCategoryRegular                     // Everything else; Dora is added afterwards by IdentifyYaku
+)
+
+// YakuContext is the winning hand as seen by a yaku evaluator.
+type YakuContext struct {
+	Player   *Player
+	AgariHai Tile
+	IsTsumo  bool
+	IsMenzen bool
+	AllTiles []Tile // All 14 tiles, melds included
+	GS       *GameState
+}
+
+// YakuMatch is one occurrence of a yaku found by an evaluator.
+type YakuMatch struct {
+	Name  string // Result name; empty uses the definition's name (Yakuhai reports "Yakuhai (Red)" etc.)
+	Count int    // How many times the definition's han is scored (a double wind Yakuhai counts twice)
+}
+
+// YakuValue is the han of a yaku with a concealed and an open hand. An Open value of 0
+// means the yaku requires a concealed hand.
+type YakuValue struct {
+	Closed int `json:"closed"`
+	Open   int `json:"open"`
+}
+
+// YakuDefinition describes one yaku in a YakuRegistry.
+type YakuDefinition struct {
+	Name       string
+	Value      YakuValue // Han; 13 per Yakuman for yakuman, shown with the result but not used for scoring
+	Yakuman    int       // Yakuman multiple (2 for the double variants); 0 for regular yaku
+	Category   YakuCategory
+	LocalYaku  string   // If set, only evaluated when this local yaku is enabled in the RuleSet
+	Supersedes []string // Yaku dropped when this one applies (Chinitsu supersedes Honitsu)
+	Requires   []string // Yaku that must also apply (Ippatsu requires Riichi)
+	Replaces   []string // Yaku switched off entirely while this one is enabled (Renhou Mangan replaces Renhou)
+	Evaluate   func(ctx *YakuContext) []YakuMatch
+}
+
+// Han returns the definition's han for a concealed or open hand.
+func (d *YakuDefinition) Han(isMenzen bool) int {
+	if isMenzen {
+		return d.Value.Closed
+	}
+	return d.Value.Open
+}
+
+// YakuRegistry is the ordered list of yaku that IdentifyYaku evaluates. Results are reported
+// in registration order. House rules can Register, Remove or Revalue yaku on their own registry
+// (set as GameState.YakuRegistry), or disable and revalue them through the RuleSet.
+type YakuRegistry struct {
+	definitions []*YakuDefinition
+}
+
+// StandardYaku is the registry used when a game has no YakuRegistry of its own.
+var StandardYaku = NewStandardYakuRegistry()
+
+// Register adds a yaku, replacing any existing one with the same name in place.
+func (r *YakuRegistry) Register(def YakuDefinition) {
+	for i, existing := range r.definitions {
+		if existing.Name == def.Name {
+			r.definitions[i] = &def
+			return
+		}
+	}
+	r.definitions = append(r.definitions, &def)
+}
+
+// Remove deletes a yaku from the registry. It reports whether the yaku was registered.
+func (r *YakuRegistry) Remove(name string) bool {
+	for i, def := range r.definitions {
+		if def.Name == name {
+			r.definitions = append(r.definitions[:i], r.definitions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Revalue changes the han of a registered yaku.
+func (r *YakuRegistry) Revalue(name string, value YakuValue) error {
+	def := r.Get(name)
+	if def == nil {
+		return fmt.Errorf("unknown yaku %q", name)
+	}
+	def.Value = value
+	return nil
+}
+
+// Get returns the named yaku definition, or nil.
+func (r *YakuRegistry) Get(name string) *YakuDefinition {
+	for _, def := range r.definitions {
+		if def.Name == name {
+			return def
+		}
+	}
+	return nil
+}
+
+// Names lists the registered yaku in evaluation order.
+func (r *YakuRegistry) Names() []string {
+	names := make([]string, 0, len(r.definitions))
+	for _, def := range r.definitions {
+		names = append(names, def.Name)
+	}
+	return names
+}
+
+// Clone returns an independent copy, so house rules can change it without touching StandardYaku.
+func (r *YakuRegistry) Clone() *YakuRegistry {
+	clone := &YakuRegistry{}
+	for _, def := range r.definitions {
+		copied := *def
+		clone.definitions = append(clone.definitions, &copied)
+	}
+	return clone
+}
+
+// Identify evaluates the registry category by category and returns the yaku of the first
+// category that awards any, with that category. With rules.StackLuckYakuman, luck yakuman are
+// combined with structural ones (Tenhou + Daisangen) and reported as YakuCategoryYakuman.
+func (r *YakuRegistry) Identify(ctx *YakuContext, rules RuleSet) ([]YakuResult, YakuCategory) {
+	luck := r.identifyCategory(ctx, rules, YakuCategoryLuck)
+	if len(luck) > 0 && !rules.StackLuckYakuman {
+		return luck, YakuCategoryLuck
+	}
+	if structural := r.identifyCategory(ctx, rules, YakuCategoryYakuman); len(structural) > 0 {
+		return append(luck, structural...), YakuCategoryYakuman
+	}
+	if len(luck) > 0 {
+		return luck, YakuCategoryLuck
+	}
+	return r.identifyCategory(ctx, rules, YakuCategoryRegular), YakuCategoryRegular
+}
+
+// enabled reports whether a yaku takes part under the given rules.
+func (r *YakuRegistry) enabled(def *YakuDefinition, rules RuleSet) bool {
+	if def.LocalYaku != "" && !rules.LocalYakuEnabled(def.LocalYaku) {
+		return false
+	}
+	if contains(rules.DisabledYaku, def.Name) {
+		return false
+	}
+	for _, other := range r.definitions {
+		if other != def && contains(other.Replaces, def.Name) && (other.LocalYaku == "" || rules.LocalYakuEnabled(other.LocalYaku)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *YakuRegistry) identifyCategory(ctx *YakuContext, rules RuleSet, category YakuCategory) []YakuResult {
+	matches := make(map[string][]YakuMatch)
+	var found []*YakuDefinition
+	for _, def := range r.definitions {
+		if def.Category != category || !r.enabled(def, rules) || yakuHan(def, rules, ctx.IsMenzen) == 0 {
+			continue
+		}
+		if m := def.Evaluate(ctx); len(m) > 0 {
+			matches[def.Name] = m
+			found = append(found, def)
+		}
+	}
+
+	awarded := make(map[string]bool)
+	for _, def := range found {
+		awarded[def.Name] = true
+		for _, required := range def.Requires {
+			if _, ok := matches[required]; !ok {
+				awarded[def.Name] = false
+			}
+		}
+	}
+	for _, def := range found {
+		if !awarded[def.Name] {
+			continue
+		}
+		for _, superseded := range def.Supersedes {
+			awarded[superseded] = false
+		}
+	}
+
+	var results []YakuResult
+	for _, def := range found {
+		if !awarded[def.Name] {
+			continue
+		}
+		han, multiple := yakuHan(def, rules, ctx.IsMenzen), def.Yakuman
+		if multiple > 1 && !contains(rules.DoubleYakuman, def.Name) { // Double variant scored as a single yakuman
+			han, multiple = han/multiple, 1
+		}
+		for _, m := range matches[def.Name] {
+			name := m.Name
+			if name == "" {
+				name = def.Name
+			}
+			results = append(results, YakuResult{Name: name, Han: han * m.Count, Yakuman: multiple * m.Count})
+		}
+	}
+	return results
+}
+
+// yakuHan is the definition's han, unless the RuleSet revalues it.
+func yakuHan(def *YakuDefinition, rules RuleSet, isMenzen bool) int {
+	if value, ok := rules.YakuHan[def.Name]; ok {
+		if isMenzen {
+			return value.Closed
+		}
+		return value.Open
+	}
+	return def.Han(isMenzen)
+}
+
+// matchIf turns a check function's verdict into an evaluator result.
+func matchIf(ok bool) []YakuMatch {
+	if ok {
+		return []YakuMatch{{Count: 1}}
+	}
+	return nil
+}
+
+// matchNamed matches a check that reports several variants (e.g. Suuankou and Suuankou Tanki)
+// only when it found the wanted one.
+func matchNamed(ok bool, name, want string) []YakuMatch {
+	return matchIf(ok && name == want)
+}
+
+// DoubleYakumanVariants are the yakuman that RuleSet.DoubleYakuman can score as double.
+var DoubleYakumanVariants = []string{"Kokushi Musou Juusanmenmachi", "Suuankou Tanki", "Junsei Chuuren Poutou", "Daisuushii"}
+
+// Values shared by many definitions.
+var (
+	yakuman       = YakuValue{Closed: 13, Open: 13}
+	yakumanClosed = YakuValue{Closed: 13}
+	doubleYakuman = YakuValue{Closed: 26, Open: 26}
+	oneHan        = YakuValue{Closed: 1, Open: 1}
+	oneHanClosed  = YakuValue{Closed: 1}
+	twoHan        = YakuValue{Closed: 2, Open: 2}
+)
+
+// NewStandardYakuRegistry returns the standard yaku followed by the opt-in local yaku, with
+// the precedence rules between them.
+func NewStandardYakuRegistry() *YakuRegistry {
+	r := &YakuRegistry{}
+	for _, def := range standardYakuDefinitions() {
+		r.Register(def)
+	}
+	for _, def := range localYakuDefinitions() {
+		r.Register(def)
+	}
+	return r
+}
+
+func standardYakuDefinitions() []YakuDefinition {
+	// Chiitoitsu hands are not scored with yaku that need four groups and a pair. Honitsu,
+	// Chinitsu and Honroutou only look at the tiles, so they still combine with it.
+	notWithChiitoitsu := []string{
+		"Toitoi", "Sanankou", "Sanshoku Doukou", "Shousangen", "Sanshoku Doujun", "Ittsuu",
+		"Ryanpeikou", "Iipeikou", "Junchan Taiyou",
+	}
+	return []YakuDefinition{
+		// == LUCK YAKUMAN ==
+		{Name: "Tenhou", Value: yakumanClosed, Yakuman: 1, Category: YakuCategoryLuck, Supersedes: []string{"Chihou", "Renhou"},
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkTenhou(c.Player, c.GS, c.IsTsumo)
+				return matchIf(ok)
+			}},
+		{Name: "Chihou", Value: yakumanClosed, Yakuman: 1, Category: YakuCategoryLuck, Supersedes: []string{"Renhou"},
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkChihou(c.Player, c.GS, c.IsTsumo)
+				return matchIf(ok)
+			}},
+		{Name: "Renhou", Value: yakumanClosed, Yakuman: 1, Category: YakuCategoryLuck,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkRenhou(c.Player, c.GS, c.IsTsumo)
+				return matchIf(ok && !c.IsTsumo) // Renhou is Ron only
+			}},
+
+		// == YAKUMAN ==
+		{Name: "Kokushi Musou", Value: yakumanClosed, Yakuman: 1, Category: YakuCategoryYakuman,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkKokushiMusou(c.AllTiles, c.AgariHai)
+				return matchIf(ok)
+			}},
+		{Name: "Kokushi Musou Juusanmenmachi", Value: YakuValue{Closed: 26}, Yakuman: 2, Category: YakuCategoryYakuman, Supersedes: []string{"Kokushi Musou"},
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, name, _ := checkKokushiMusou(c.AllTiles, c.AgariHai)
+				return matchNamed(ok, name, "Kokushi Musou Juusanmenmachi")
+			}},
+		{Name: "Suuankou", Value: yakumanClosed, Yakuman: 1, Category: YakuCategoryYakuman,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkSuuankou(c.Player, c.AgariHai, c.IsTsumo, c.IsMenzen, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: "Suuankou Tanki", Value: YakuValue{Closed: 26}, Yakuman: 2, Category: YakuCategoryYakuman, Supersedes: []string{"Suuankou"},
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, name, _ := checkSuuankou(c.Player, c.AgariHai, c.IsTsumo, c.IsMenzen, c.AllTiles)
+				return matchNamed(ok, name, "Suuankou Tanki")
+			}},
+		{Name: "Daisangen", Value: yakuman, Yakuman: 1, Category: YakuCategoryYakuman,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkDaisangen(c.Player, c.AllTiles); return matchIf(ok) }},
+		{Name: "Daisuushii", Value: doubleYakuman, Yakuman: 2, Category: YakuCategoryYakuman, Supersedes: []string{"Shousuushii"},
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkDaisuushii(c.Player, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: "Shousuushii", Value: yakuman, Yakuman: 1, Category: YakuCategoryYakuman,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkShousuushii(c.Player, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: "Tsuuiisou", Value: yakuman, Yakuman: 1, Category: YakuCategoryYakuman,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkTsuuiisou(c.Player, c.AllTiles); return matchIf(ok) }},
+		{Name: "Chinroutou", Value: yakuman, Yakuman: 1, Category: YakuCategoryYakuman,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkChinroutou(c.Player, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: "Ryuuiisou", Value: yakuman, Yakuman: 1, Category: YakuCategoryYakuman,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkRyuuiisou(c.Player, c.AllTiles); return matchIf(ok) }},
+		{Name: "Chuuren Poutou", Value: yakumanClosed, Yakuman: 1, Category: YakuCategoryYakuman,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkChuurenPoutou(c.IsMenzen, c.AllTiles, c.AgariHai)
+				return matchIf(ok)
+			}},
+		{Name: "Junsei Chuuren Poutou", Value: YakuValue{Closed: 26}, Yakuman: 2, Category: YakuCategoryYakuman, Supersedes: []string{"Chuuren Poutou"},
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, name, _ := checkChuurenPoutou(c.IsMenzen, c.AllTiles, c.AgariHai)
+				return matchNamed(ok, name, "Junsei Chuuren Poutou")
+			}},
+		{Name: "Suukantsu", Value: yakuman, Yakuman: 1, Category: YakuCategoryYakuman,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkSuukantsu(c.Player); return matchIf(ok) }},
+
+		// == 1 HAN ==
+		{Name: "Riichi", Value: oneHanClosed, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkRiichi(c.Player, c.GS); return matchIf(ok) }},
+		{Name: "Double Riichi Bonus", Value: oneHanClosed, Category: YakuCategoryRegular, Requires: []string{"Riichi"},
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkDoubleRiichi(c.Player, c.GS); return matchIf(ok) }},
+		{Name: "Ippatsu", Value: oneHanClosed, Category: YakuCategoryRegular, Requires: []string{"Riichi"},
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkIppatsu(c.Player, c.GS); return matchIf(ok) }},
+		{Name: "Menzen Tsumo", Value: oneHanClosed, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkMenzenTsumo(c.IsTsumo, c.IsMenzen); return matchIf(ok) }},
+		{Name: "Pinfu", Value: oneHanClosed, Category: YakuCategoryRegular, Supersedes: []string{"Chiitoitsu"},
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _ := checkPinfu(c.Player, c.AgariHai, c.IsMenzen, c.AllTiles, c.GS)
+				return matchIf(ok)
+			}},
+		{Name: "Tanyao", Value: oneHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkTanyao(c.AllTiles); return matchIf(ok) }},
+		{Name: "Yakuhai", Value: oneHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				results, _ := checkYakuhai(c.Player, c.GS, c.AllTiles)
+				var matches []YakuMatch
+				for _, r := range results { // checkYakuhai scores a double wind as 2
+					matches = append(matches, YakuMatch{Name: r.Name, Count: r.Han})
+				}
+				return matches
+			}},
+		{Name: "Haitei Raoyue", Value: oneHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, name, _ := checkHaiteiHoutei(c.GS, c.IsTsumo)
+				return matchNamed(ok, name, "Haitei Raoyue")
+			}},
+		{Name: "Houtei Raoyui", Value: oneHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, name, _ := checkHaiteiHoutei(c.GS, c.IsTsumo)
+				return matchNamed(ok, name, "Houtei Raoyui")
+			}},
+		{Name: "Rinshan Kaihou", Value: oneHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkRinshanKaihou(c.GS, c.IsTsumo); return matchIf(ok) }},
+		{Name: "Chankan", Value: oneHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkChankan(c.GS); return matchIf(ok && !c.IsTsumo) }},
+
+		// == 2 HAN ==
+		{Name: "Chiitoitsu", Value: YakuValue{Closed: 2}, Category: YakuCategoryRegular, Supersedes: notWithChiitoitsu,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _ := checkChiitoitsu(c.Player, c.AllTiles, c.IsMenzen)
+				return matchIf(ok)
+			}},
+		{Name: "Toitoi", Value: twoHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkToitoi(c.Player, c.AllTiles); return matchIf(ok) }},
+		{Name: "Sanankou", Value: twoHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _ := checkSanankou(c.Player, c.AgariHai, c.IsTsumo, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: "Sanshoku Doukou", Value: twoHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkSanshokuDoukou(c.Player, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: "Shousangen", Value: twoHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkShousangen(c.Player, c.AllTiles); return matchIf(ok) }},
+		{Name: "Sankantsu", Value: twoHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _, _ := checkSankantsu(c.Player); return matchIf(ok) }},
+		{Name: "Honroutou", Value: twoHan, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkHonroutou(c.AllTiles); return matchIf(ok) }},
+		{Name: "Sanshoku Doujun", Value: YakuValue{Closed: 2, Open: 1}, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkSanshokuDoujun(c.Player, c.IsMenzen, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: "Ittsuu", Value: YakuValue{Closed: 2, Open: 1}, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _, _ := checkIttsuu(c.Player, c.IsMenzen, c.AllTiles)
+				return matchIf(ok)
+			}},
+
+		// == 3+ HAN ==
+		{Name: "Ryanpeikou", Value: YakuValue{Closed: 3}, Category: YakuCategoryRegular, Supersedes: []string{"Iipeikou"},
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _ := checkRyanpeikou(c.Player, c.IsMenzen, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: "Iipeikou", Value: oneHanClosed, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _ := checkIipeikou(c.Player, c.IsMenzen, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: "Junchan Taiyou", Value: YakuValue{Closed: 3, Open: 2}, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch {
+				ok, _ := checkJunchan(c.Player, c.IsMenzen, c.AllTiles)
+				return matchIf(ok)
+			}},
+		{Name: "Chinitsu", Value: YakuValue{Closed: 6, Open: 5}, Category: YakuCategoryRegular, Supersedes: []string{"Honitsu"},
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkChinitsu(c.AllTiles, c.IsMenzen); return matchIf(ok) }},
+		{Name: "Honitsu", Value: YakuValue{Closed: 3, Open: 2}, Category: YakuCategoryRegular,
+			Evaluate: func(c *YakuContext) []YakuMatch { ok, _ := checkHonitsu(c.AllTiles, c.IsMenzen); return matchIf(ok) }},
+	}
+}
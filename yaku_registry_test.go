@@ -0,0 +1,111 @@
+package main
+
+import "testing"
+
+func TestIdentifyYaku_ChinitsuSupersedesHonitsu(t *testing.T) {
+	player, agariHai, _, gs := setupLocalYakuHand(t, "1p 2p 3p 4p 5p 6p 7p 8p 9p 2p 3p 4p 5p", "5p", false)
+
+	results, _ := IdentifyYaku(player, agariHai, false, gs)
+	if !hasYaku(results, "Chinitsu", 6) || hasYaku(results, "Honitsu", 3) {
+		t.Errorf("TestIdentifyYaku_ChinitsuSupersedesHonitsu: Expected Chinitsu (6 Han) without Honitsu, got %v", results)
+	}
+}
+
+func TestIdentifyYaku_ChiitoitsuWithHonitsu(t *testing.T) {
+	player, agariHai, _, gs := setupLocalYakuHand(t, "1m 1m 3m 3m 5m 5m 7m 7m 9m 9m E E S", "S", false)
+
+	results, _ := IdentifyYaku(player, agariHai, false, gs)
+	if !hasYaku(results, "Chiitoitsu", 2) || !hasYaku(results, "Honitsu", 3) {
+		t.Errorf("TestIdentifyYaku_ChiitoitsuWithHonitsu: Expected Chiitoitsu (2 Han) and Honitsu (3 Han), got %v", results)
+	}
+}
+
+func TestIdentifyYaku_RuleSetDisablesAndRevaluesYaku(t *testing.T) {
+	player, agariHai, _, gs := setupLocalYakuHand(t, "2m 3m 4m 5p 6p 7p 3s 4s 5s 6s 6s 8m 8m", "6s", false)
+	if results, _ := IdentifyYaku(player, agariHai, false, gs); !hasYaku(results, "Tanyao", 1) {
+		t.Fatalf("TestIdentifyYaku_RuleSetDisablesAndRevaluesYaku: Expected Tanyao (1 Han) by default, got %v", results)
+	}
+
+	gs.Rules.YakuHan = map[string]YakuValue{"Tanyao": {Closed: 2, Open: 1}}
+	if results, _ := IdentifyYaku(player, agariHai, false, gs); !hasYaku(results, "Tanyao", 2) {
+		t.Errorf("TestIdentifyYaku_RuleSetDisablesAndRevaluesYaku: Expected Tanyao revalued to 2 Han, got %v", results)
+	}
+
+	gs.Rules.DisabledYaku = []string{"Tanyao"}
+	if results, han := IdentifyYaku(player, agariHai, false, gs); len(results) != 0 || han != 0 {
+		t.Errorf("TestIdentifyYaku_RuleSetDisablesAndRevaluesYaku: Expected no yaku with Tanyao disabled, got %v (%d Han)", results, han)
+	}
+}
+
+func TestYakuRegistry_HouseRuleRegistry(t *testing.T) {
+	player, agariHai, _, gs := setupLocalYakuHand(t, "2m 3m 4m 5p 6p 7p 3s 4s 5s 6s 6s 8m 8m", "6s", false)
+
+	registry := StandardYaku.Clone()
+	registry.Register(YakuDefinition{
+		Name: "All Sixes", Value: YakuValue{Closed: 2, Open: 1}, Category: YakuCategoryRegular,
+		Evaluate: func(c *YakuContext) []YakuMatch {
+			sixes := 0
+			for _, tile := range c.AllTiles {
+				if tile.Value == 6 && !IsHonor(tile) {
+					sixes++
+				}
+			}
+			return matchIf(sixes >= 4)
+		},
+	})
+	if !registry.Remove("Tanyao") {
+		t.Fatalf("TestYakuRegistry_HouseRuleRegistry: Expected Tanyao to be registered")
+	}
+	if err := registry.Revalue("All Sixes", YakuValue{Closed: 3, Open: 2}); err != nil {
+		t.Fatalf("TestYakuRegistry_HouseRuleRegistry: Revalue failed: %v", err)
+	}
+	if err := registry.Revalue("No Such Yaku", YakuValue{Closed: 1}); err == nil {
+		t.Errorf("TestYakuRegistry_HouseRuleRegistry: Expected an error revaluing an unknown yaku")
+	}
+	gs.YakuRegistry = registry
+
+	results, _ := IdentifyYaku(player, agariHai, false, gs)
+	if !hasYaku(results, "All Sixes", 3) || hasYaku(results, "Tanyao", 1) {
+		t.Errorf("TestYakuRegistry_HouseRuleRegistry: Expected All Sixes (3 Han) and no Tanyao, got %v", results)
+	}
+	if StandardYaku.Get("Tanyao") == nil || StandardYaku.Get("All Sixes") != nil {
+		t.Errorf("TestYakuRegistry_HouseRuleRegistry: Expected StandardYaku to be unchanged by a cloned registry, got %v", StandardYaku.Names())
+	}
+}
+
+func TestIdentifyYaku_StackLuckYakuman(t *testing.T) {
+	player, agariHai, _, gs := setupLocalYakuHand(t, "w w w g g g r r r 1m 2m 3m 5p", "5p", true)
+	player.HasMadeFirstDiscardThisRound = false
+	gs.IsFirstGoAround = true
+	gs.DealerIndexThisRound = 0
+	gs.TurnNumber = 0
+
+	results, _ := IdentifyYaku(player, agariHai, true, gs)
+	if !hasYaku(results, "Tenhou", 13) || hasYaku(results, "Daisangen", 13) {
+		t.Errorf("TestIdentifyYaku_StackLuckYakuman: Expected Tenhou alone by default, got %v", results)
+	}
+
+	gs.Rules.StackLuckYakuman = true
+	results, _ = IdentifyYaku(player, agariHai, true, gs)
+	if !hasYaku(results, "Tenhou", 13) || !hasYaku(results, "Daisangen", 13) || YakumanMultiple(results) != 2 {
+		t.Errorf("TestIdentifyYaku_StackLuckYakuman: Expected Tenhou + Daisangen as a double yakuman, got %v", results)
+	}
+}
+
+func TestIdentifyYaku_DoubleYakumanVariants(t *testing.T) {
+	player, agariHai, _, gs := setupLocalYakuHand(t, "E E E S S S W W W N N N 1m", "1m", false)
+	east := append([]Tile(nil), player.Hand[1:4]...)                                    // The sorted hand starts 1m, E, E, E
+	player.Melds = []Meld{{Type: "Pon", Tiles: east, CalledOn: east[2], FromPlayer: 3}} // Open, so not Suuankou
+	player.Hand = append(player.Hand[:1:1], player.Hand[4:]...)
+
+	results, _ := IdentifyYaku(player, agariHai, false, gs)
+	if len(results) != 1 || results[0].Name != "Daisuushii" || results[0].Yakuman != 2 {
+		t.Errorf("TestIdentifyYaku_DoubleYakumanVariants: Expected Daisuushii as a double yakuman, got %+v", results)
+	}
+
+	gs.Rules.DoubleYakuman = nil
+	results, _ = IdentifyYaku(player, agariHai, false, gs)
+	if len(results) != 1 || results[0].Han != 13 || results[0].Yakuman != 1 {
+		t.Errorf("TestIdentifyYaku_DoubleYakumanVariants: Expected Daisuushii as a single yakuman with doubles off, got %+v", results)
+	}
+}
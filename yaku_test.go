@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
 	"sort"
 	"strings"
@@ -26,16 +27,9 @@ func createTestPlayer() *Player {
 
 // --- Agari/Tenpai Yame Tests ---
 
-// MockInputReader for testing GetPlayerChoice
-type MockInputReader struct {
-	NextChoice bool // true for 'y', false for 'n'
-}
-
-func (m *MockInputReader) ReadLine() (string, error) {
-	if m.NextChoice {
-		return "y", nil
-	}
-	return "n", nil
+// mockInputReader answers GetPlayerChoice with 'y' or 'n'.
+func mockInputReader(choice bool) *bufio.Reader {
+	return bufio.NewReader(strings.NewReader(If(choice, "y\n", "n\n")))
 }
 
 func setupGameForYameTest(t *testing.T, currentWindRoundNum, maxWindRounds, roundNum, dealerIndex int, dealerScore, p2Score, p3Score, p4Score int) (*GameState, *Player) {
@@ -77,7 +71,7 @@ func TestAgariYame_DealerWins_Top_ChoosesYame(t *testing.T) {
 
 	// Mock GetPlayerChoice to return true (yes to Yame)
 	originalReader := gs.InputReader
-	gs.InputReader = &MockInputReader{NextChoice: true}
+	gs.InputReader = mockInputReader(true)
 	defer func() { gs.InputReader = originalReader }() // Restore original reader
 
 	// --- Simulate relevant part of Round End Processing from main.go ---
@@ -148,7 +142,7 @@ func TestTenpaiYame_DealerTenpai_Top_ChoosesYame(t *testing.T) {
 	dealer.IsTenpai = true                                                        // Dealer is Tenpai
 
 	originalReader := gs.InputReader
-	gs.InputReader = &MockInputReader{NextChoice: true}
+	gs.InputReader = mockInputReader(true)
 	defer func() { gs.InputReader = originalReader }()
 
 	// --- Simulate relevant part of Round End Processing from main.go ---
@@ -326,7 +320,7 @@ func TestAgariYame_DealerDeclinesYame(t *testing.T) {
 	dealer.IsTenpai = true
 
 	originalReader := gs.InputReader
-	gs.InputReader = &MockInputReader{NextChoice: false} // Decline Yame
+	gs.InputReader = mockInputReader(false) // Decline Yame
 	defer func() { gs.InputReader = originalReader }()
 
 	// --- Simulate relevant part of Round End Processing from main.go ---
@@ -420,7 +414,7 @@ func TestHandleWin_PaoTsumoYakuman_Daisangen(t *testing.T) {
 		// Red dragon will be the Tsumo tile, completing the 3rd pung for Daisangen
 	}
 	// The Tsumo tile that completes Daisangen
-	agariHai := TilesFromString("r")[0] // Red Dragon
+	_ = TilesFromString("r")[0] // Red Dragon, the Tsumo tile (HandleWin is simulated below)
 
 	// Mark Pao source
 	winner.PaoSourcePlayerIndex = 2 // P2 is responsible for Pao (e.g. for the White Dragon meld)
@@ -440,16 +434,16 @@ func TestHandleWin_PaoTsumoYakuman_Daisangen(t *testing.T) {
 	// Non-dealer Yakuman Ron value: 32000 base.
 	// Honba payment for Ron: Honba * 300.
 	// So, Pao player pays 32000 + (gs.Honba * 300)
-	expectedPaoPayment := YakumanBasePointsNonDealer + (gs.Honba * RonHonbaBonus) // 32000 + 1*300 = 32300
+	expectedPaoPayment := 32000 + (gs.Honba * 300) // 32000 + 1*300 = 32300
 
 	// Call HandleWin
 	// To simplify, we won't call IdentifyYaku or CalculateFu here, but assume they provide correct inputs
 	// to the Pao logic part of HandleWin. We are testing the Pao payout specifically.
 	// We will directly modify the payment struct to simulate the outcome of CalculatePointPayment for a Yakuman.
 	mockPayment := Payment{
-		RonValue:          expectedPaoPayment,         // This is what Pao player will pay
-		TsumoDealerPay:    YakumanBasePointsDealer,    // For context, not directly used by Pao Tsumo logic
-		TsumoNonDealerPay: YakumanBasePointsNonDealer, // For context
+		RonValue:          expectedPaoPayment, // This is what Pao player will pay
+		TsumoDealerPay:    16000,              // For context, not directly used by Pao Tsumo logic
+		TsumoNonDealerPay: 8000,               // For context
 		Description:       "Yakuman (Daisangen)",
 	}
 
@@ -463,7 +457,7 @@ func TestHandleWin_PaoTsumoYakuman_Daisangen(t *testing.T) {
 	// and CalculatePointPayment has returned the correct payment structure.
 
 	// Manually set values that HandleWin would calculate before Pao logic
-	gs.GamePhase = PhaseInProgress // Will be set to RoundEnd in HandleWin
+	gs.GamePhase = PhasePlayerTurn // Will be set to RoundEnd in HandleWin
 	gs.RoundWinner = nil           // Will be set in HandleWin
 
 	// --- Direct call to HandleWin ---
@@ -579,84 +573,82 @@ func createTestGameState(playerNames []string) *GameState {
 	return gs
 }
 
+// testTileIDCounter gives every tile built by TilesFromString its own ID, so hand, meld and agari
+// tiles from separate calls never collide in the ID-based bookkeeping of getAllTilesInHand and
+// DecomposeWinningHand.
+var testTileIDCounter = 0
+
 // TilesFromString parses a string like "1m2m3p4s E S W N Wh Gr Rd" into a slice of Tiles.
 // Assumes valid input for simplicity in tests.
 func TilesFromString(s string) []Tile {
 	var tiles []Tile
-	parts := strings.Fields(s) // Split by space for individual tiles or groups like "1m2m3m"
-
-	tileIDCounter := 0 // Simple ID assignment for testing
-
-	for _, part := range parts {
-		currentSuit := ""
-		valueStr := ""
+	add := func(suit string, value int, name string) {
+		tiles = append(tiles, Tile{Suit: suit, Value: value, Name: name, ID: testTileIDCounter})
+		testTileIDCounter++
+	}
+	winds := []string{"East", "South", "West", "North"}
+	dragons := []string{"White", "Green", "Red"}
 
-		for _, char := range part {
-			r := rune(char)
+	// Parts are split by space; a part may hold runs such as "123m" or "1m2m3m".
+	for _, part := range strings.Fields(s) {
+		pending := "" // Digits waiting for their suit letter
+		for i := 0; i < len(part); i++ {
+			r := part[i]
 			if r >= '1' && r <= '9' {
-				valueStr += string(r)
-			} else {
-				// Process previous tile if valueStr is populated
-				if valueStr != "" && currentSuit != "" {
-					val := MustParseInt(valueStr)
-					tiles = append(tiles, Tile{Suit: currentSuit, Value: val, Name: fmt.Sprintf("%s %d", currentSuit, val), ID: tileIDCounter})
-					tileIDCounter++
-					valueStr = "" // Reset for next potential number in this part
+				pending += string(r)
+				continue
+			}
+			// Two-letter dragon names
+			if i+1 < len(part) {
+				switch part[i : i+2] {
+				case "Wh":
+					add("Dragon", 1, "White")
+					i++
+					continue
+				case "Gr":
+					add("Dragon", 2, "Green")
+					i++
+					continue
+				case "Rd":
+					add("Dragon", 3, "Red")
+					i++
+					continue
 				}
-
-				// Determine suit based on character
-				switch r {
-				case 'm':
-					currentSuit = "Man"
-				case 'p':
-					currentSuit = "Pin"
-				case 's':
-					currentSuit = "Sou"
-				case 'E':
-					tiles = append(tiles, Tile{Suit: "Wind", Value: 1, Name: "East", ID: tileIDCounter})
-					currentSuit = ""
-					valueStr = "" // Reset
-					tileIDCounter++
-				case 'S':
-					tiles = append(tiles, Tile{Suit: "Wind", Value: 2, Name: "South", ID: tileIDCounter})
-					currentSuit = ""
-					valueStr = ""
-					tileIDCounter++
-				case 'W':
-					tiles = append(tiles, Tile{Suit: "Wind", Value: 3, Name: "West", ID: tileIDCounter})
-					currentSuit = ""
-					valueStr = ""
-					tileIDCounter++
-				case 'N':
-					tiles = append(tiles, Tile{Suit: "Wind", Value: 4, Name: "North", ID: tileIDCounter})
-					currentSuit = ""
-					valueStr = ""
-					tileIDCounter++
-				case 'w': // Lowercase 'w' for White Dragon
-					tiles = append(tiles, Tile{Suit: "Dragon", Value: 1, Name: "White", ID: tileIDCounter})
-					currentSuit = ""
-					valueStr = ""
-					tileIDCounter++
-				case 'g': // Lowercase 'g' for Green Dragon
-					tiles = append(tiles, Tile{Suit: "Dragon", Value: 2, Name: "Green", ID: tileIDCounter})
-					currentSuit = ""
-					valueStr = ""
-					tileIDCounter++
-				case 'r': // Lowercase 'r' for Red Dragon
-					tiles = append(tiles, Tile{Suit: "Dragon", Value: 3, Name: "Red", ID: tileIDCounter})
-					currentSuit = ""
-					valueStr = ""
-					tileIDCounter++
-				default:
-					// Potentially handle errors or ignore unknown characters
+			}
+			switch r {
+			case 'm', 'p', 's':
+				suit := map[byte]string{'m': "Man", 'p': "Pin", 's': "Sou"}[r]
+				for _, d := range pending {
+					val := int(d - '0')
+					add(suit, val, fmt.Sprintf("%s %d", suit, val))
 				}
+			case 'z': // 1-4 are the winds, 5-7 the dragons
+				for _, d := range pending {
+					val := int(d - '0')
+					if val <= 4 {
+						add("Wind", val, winds[val-1])
+					} else if val <= 7 {
+						add("Dragon", val-4, dragons[val-5])
+					}
+				}
+			case 'E':
+				add("Wind", 1, "East")
+			case 'S':
+				add("Wind", 2, "South")
+			case 'W':
+				add("Wind", 3, "West")
+			case 'N':
+				add("Wind", 4, "North")
+			case 'w': // Lowercase 'w' for White Dragon
+				add("Dragon", 1, "White")
+			case 'g': // Lowercase 'g' for Green Dragon
+				add("Dragon", 2, "Green")
+			case 'r': // Lowercase 'r' for Red Dragon
+				add("Dragon", 3, "Red")
+			default:
+				// Ignore unknown characters
 			}
-		}
-		// Process any remaining numbered tile at the end of a part
-		if valueStr != "" && currentSuit != "" {
-			val := MustParseInt(valueStr)
-			tiles = append(tiles, Tile{Suit: currentSuit, Value: val, Name: fmt.Sprintf("%s %d", currentSuit, val), ID: tileIDCounter})
-			tileIDCounter++
+			pending = ""
 		}
 	}
 	sort.Sort(BySuitValue(tiles)) // Sort for consistency
@@ -679,6 +671,11 @@ func setupTestHandAndGameState(t *testing.T, handStr string, melds []Meld, agari
 	player.Melds = melds
 
 	agariHai := TilesFromString(agariTileStr)[0] // Assuming agariTileStr is a single tile
+	if isTsumo {
+		// The drawn tile is in the hand when a Tsumo is declared.
+		player.Hand = append(player.Hand, agariHai)
+		sort.Sort(BySuitValue(player.Hand))
+	}
 
 	// For Tsumo, agariHai is part of the 14 tiles that form the complete hand.
 	// For Ron, agariHai completes the 13 tiles in hand+melds.
@@ -691,9 +688,16 @@ func setupTestHandAndGameState(t *testing.T, handStr string, melds []Meld, agari
 	// If Tsumo, agariHai is drawn and added. If Ron, agariHai is taken.
 
 	// getAllTilesInHand will construct the 14-tile hand including agariHai.
+	// Each Kan adds a fourth tile on top of the 14.
 	allTiles := getAllTilesInHand(player, agariHai, isTsumo)
-	if len(allTiles) != 14 {
-		t.Fatalf("setupTestHandAndGameState: allTiles length is %d, expected 14. Hand: %s, Melds: %v, Agari: %s, Tsumo: %v", len(allTiles), handStr, melds, agariTileStr, isTsumo)
+	wantTiles := 14
+	for _, m := range melds {
+		if len(m.Tiles) == 4 {
+			wantTiles++
+		}
+	}
+	if len(allTiles) != wantTiles {
+		t.Fatalf("setupTestHandAndGameState: allTiles length is %d, expected %d. Hand: %s, Melds: %v, Agari: %s, Tsumo: %v", len(allTiles), wantTiles, handStr, melds, agariTileStr, isTsumo)
 	}
 
 	gs := createTestGameState(nil) // Use default player names for now
@@ -795,24 +799,16 @@ func TestTilesFromString(t *testing.T) {
 
 // --- Pinfu Tests ---
 func TestCheckPinfu_Valid_RyanmenLow(t *testing.T) {
-	player, agariHai, allTiles, gs := setupTestHandAndGameState(t, "2m3m 4s5s6s 7p8p9p", []Meld{}, "1m", false)
-	// Expected decomposition: Seq(1m2m3m), Seq(4s5s6s), Seq(7p8p9p), Pair(SouthWind)
-	// Pair is player's Seat Wind, assuming player.SeatWind = "South", gs.PrevalentWind = "East"
+	player, agariHai, allTiles, gs := setupTestHandAndGameState(t, "2m3m 2p3p4p 4s5s6s 7p8p9p N N", []Meld{}, "1m", false)
+	// Expected decomposition: Seq(1m2m3m), Seq(2p3p4p), Seq(4s5s6s), Seq(7p8p9p), Pair(NorthWind)
+	// Pair is neither the seat wind (South) nor the prevalent wind (East)
 	player.SeatWind = "South" // Make pair non-yakuhai for this test
 	gs.PrevalentWind = "East"
-	pairTile := Tile{Suit: "Wind", Value: 2, Name: "South"} // South Wind (Value 2)
-	player.Hand = append(player.Hand, pairTile, pairTile)   // Add non-yakuhai pair
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, false) // Re-generate allTiles with the pair
-
-	// Mock DecomposeWinningHand if necessary, or ensure it works.
-	// For Pinfu, DecomposeWinningHand must correctly identify 4 sequences and 1 pair.
-	// And the pair must not be Yakuhai, and the wait must be Ryanmen.
 
 	// Manually verify decomposition for test logic:
-	// Hand: 1m 2m 3m 4s 5s 6s 7p 8p 9p S S
+	// Hand: 1m 2m 3m 2p 3p 4p 4s 5s 6s 7p 8p 9p N N
 	// Agari: 1m on 2m3m -> 123m (Ryanmen)
-	// Pair: SS (South Wind), player seat South, prevalent East -> Not Yakuhai
+	// Pair: NN (North Wind), player seat South, prevalent East -> Not Yakuhai
 
 	ok, han := checkPinfu(player, agariHai, true, allTiles, gs)
 	if !ok || han != 1 {
@@ -821,11 +817,8 @@ func TestCheckPinfu_Valid_RyanmenLow(t *testing.T) {
 }
 
 func TestCheckPinfu_Invalid_YakuhaiPair(t *testing.T) {
-	player, agariHai, allTiles, gs := setupTestHandAndGameState(t, "1m2m3m 4s5s6s 7p8p9p", []Meld{}, "1m", false) // Agari doesn't matter for this check
 	// Pair is Green Dragon (Yakuhai)
-	player.Hand = append(player.Hand, Tile{Suit: "Dragon", Value: 2, Name: "Green"}, Tile{Suit: "Dragon", Value: 2, Name: "Green"})
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, false)
+	player, agariHai, allTiles, gs := setupTestHandAndGameState(t, "2m3m 2p3p4p 4s5s6s 7p8p9p g g", []Meld{}, "1m", false)
 
 	ok, _ := checkPinfu(player, agariHai, true, allTiles, gs)
 	if ok {
@@ -834,14 +827,11 @@ func TestCheckPinfu_Invalid_YakuhaiPair(t *testing.T) {
 }
 
 func TestCheckPinfu_Invalid_KanchanWait(t *testing.T) {
-	// Hand: 1m3m 4s5s6s 7p8p9p NN (Non-Yakuhai pair: North, if player West, prevalent East)
+	// Hand: 1m3m 2p3p4p 4s5s6s 7p8p9p NN (Non-Yakuhai pair: North, if player West, prevalent East)
 	// Agari on 2m (completes 1m2m3m Kanchan)
-	player, agariHai, _, gs := setupTestHandAndGameState(t, "1m3m 4s5s6s 7p8p9p", []Meld{}, "2m", false)
+	player, agariHai, allTiles, gs := setupTestHandAndGameState(t, "1m3m 2p3p4p 4s5s6s 7p8p9p N N", []Meld{}, "2m", false)
 	player.SeatWind = "West"
 	gs.PrevalentWind = "East"
-	player.Hand = append(player.Hand, Tile{Suit: "Wind", Value: 4, Name: "North"}, Tile{Suit: "Wind", Value: 4, Name: "North"})
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles := getAllTilesInHand(player, agariHai, false)
 
 	// Manually ensure decomposition would have 123m for the wait check in checkPinfu
 	// This is tricky without a perfect decomposer. We assume checkPinfu internally uses the agari tile correctly.
@@ -967,15 +957,11 @@ func TestCheckSuuankou_Tanki_Tsumo(t *testing.T) {
 func TestCheckSuuankou_Invalid_RonCompletesPung(t *testing.T) {
 	// 3 concealed pungs, pair, Ron completes the 4th pung (making it Sanankou, not Suuankou).
 	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
-		"1m1m1m 2p2p2p 3s3s 4z4z", // Hand: 2 pungs, 2 parts of 3rd pung, pair
+		"1m1m1m 2p2p2p 5z5z5z 3s3s 4z4z", // Hand: 3 concealed pungs, 2 parts of 4th pung, pair
 		[]Meld{},
-		"3s",  // Ron on this to complete 3rd pung
+		"3s",  // Ron on this to complete 4th pung
 		false, // isTsumo = false (Ron)
 	)
-	// Add another concealed pung to make it 3 before the Ron
-	player.Hand = append(player.Hand, TilesFromString("5z5z5z")...)
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, false) // Re-generate allTiles
 
 	// Expected: Not Suuankou. (Should be Sanankou + Toitoi potentially)
 	// DecomposeWinningHand: Pung 1m, Pung 2p, Pung 5z, Pung 3s (completed by Ron), Pair 4z
@@ -991,7 +977,8 @@ func TestCheckSuuankou_Invalid_RonCompletesPung(t *testing.T) {
 // --- Chuuren Poutou Tests ---
 
 func TestCheckChuurenPoutou_Standard_Extra2m(t *testing.T) {
-	// Hand (all Manzu): 1,1,1, 2,2, 3,4,5,6,7,8, 9,9,9. agariHai is 2m.
+	// Hand (all Manzu): 1,1,1, 2,2, 3,4,5,6,7,8, 9,9,9. agariHai is 5m.
+	// Before the agari the hand was 1112234678999, which waits on 5m only (not the nine-sided Junsei wait).
 	// For setupTestHandAndGameState, handStr should be the 13 tiles before agari.
 	// If agariHai is 2m and it's Tsumo, player.Hand + agariHai = allTiles.
 	// If Ron, player.Hand = 13 tiles, agariHai is the 14th.
@@ -999,7 +986,7 @@ func TestCheckChuurenPoutou_Standard_Extra2m(t *testing.T) {
 	// Let's assume the 14 tiles are "1m1m1m2m2m3m4m5m6m7m8m9m9m9m" and agari is one of the "2m".
 
 	allTiles := TilesFromString("1m1m1m2m2m3m4m5m6m7m8m9m9m9m")
-	agariHai := TilesFromString("2m")[0] // This is one of the 2m in allTiles
+	agariHai := TilesFromString("5m")[0] // The extra tile is a 2m, but the hand was completed on 5m
 
 	// Ensure allTiles is correctly constructed for the check function.
 	// checkChuurenPoutou expects all 14 tiles.
@@ -1040,11 +1027,14 @@ func TestCheckChuurenPoutou_Junsei_AgariOn5p(t *testing.T) {
 // --- Yakuhai Tests ---
 func TestCheckYakuhai(t *testing.T) {
 	player := createTestPlayer()
-	_, _, _, gs := setupTestHandAndGameState(t, "", []Meld{}, "1m", false) // Hand details don't matter as much as melds
+	gs := createTestGameState(nil) // Hand details don't matter as much as melds
+	// Three concealed sequences and a 9p tanki wait; the Pon under test completes the hand
+	player.Hand = TilesFromString("1m2m3m 4p5p6p 7s8s9s 9p")
+	agariHai := TilesFromString("9p")[0]
 
 	// Test case 1: Pung of White Dragon
 	player.Melds = []Meld{{Type: "Pon", Tiles: TilesFromString("w w w")}}
-	allTiles := getAllTilesInHand(player, TilesFromString("1m")[0], false) // Dummy agari
+	allTiles := getAllTilesInHand(player, agariHai, false)
 	results, han := checkYakuhai(player, gs, allTiles)
 	if han != 1 || len(results) != 1 || results[0].Name != "Yakuhai (White)" {
 		t.Errorf("TestCheckYakuhai_DragonPung: Expected Yakuhai (White) (1 Han), got %v han %d", results, han)
@@ -1054,7 +1044,7 @@ func TestCheckYakuhai(t *testing.T) {
 	player.Melds = []Meld{{Type: "Pon", Tiles: TilesFromString("E E E")}}
 	player.SeatWind = "South"
 	gs.PrevalentWind = "East"
-	allTiles = getAllTilesInHand(player, TilesFromString("1m")[0], false)
+	allTiles = getAllTilesInHand(player, agariHai, false)
 	results, han = checkYakuhai(player, gs, allTiles)
 	if han != 1 || len(results) != 1 || !strings.Contains(results[0].Name, "Prevalent Wind East") {
 		t.Errorf("TestCheckYakuhai_PrevalentWind: Expected Yakuhai (Prevalent Wind East) (1 Han), got %v han %d", results, han)
@@ -1065,23 +1055,13 @@ func TestCheckYakuhai(t *testing.T) {
 	player.SeatWind = "East"
 	gs.PrevalentWind = "East"
 	gs.DealerIndexThisRound = 0 // Player 0 is East
-	allTiles = getAllTilesInHand(player, TilesFromString("1m")[0], false)
+	allTiles = getAllTilesInHand(player, agariHai, false)
 	results, han = checkYakuhai(player, gs, allTiles)
-	if han != 2 || len(results) != 2 { // Expecting two YakuResult entries for double wind
+	if han != 2 || len(results) != 1 { // Double wind is one 2 Han YakuResult entry
 		t.Errorf("TestCheckYakuhai_DoubleWind: Expected 2 Han from double wind, got %v han %d", results, han)
 	}
-	foundPrev := false
-	foundSeat := false
-	for _, r := range results {
-		if strings.Contains(r.Name, "Prevalent Wind East") {
-			foundPrev = true
-		}
-		if strings.Contains(r.Name, "Seat Wind East") {
-			foundSeat = true
-		}
-	}
-	if !foundPrev || !foundSeat {
-		t.Errorf("TestCheckYakuhai_DoubleWind: Did not find both Prevalent and Seat wind Yaku. Results: %v", results)
+	if len(results) == 1 && !strings.Contains(results[0].Name, "Seat & Prevalent East") {
+		t.Errorf("TestCheckYakuhai_DoubleWind: Expected Yakuhai (Seat & Prevalent East). Results: %v", results)
 	}
 }
 
@@ -1089,15 +1069,12 @@ func TestCheckYakuhai(t *testing.T) {
 
 func TestCheckIipeikou_Valid(t *testing.T) {
 	// Hand: 223344m 123p 789s EEz. Menzen.
-	player, agariHai, allTiles, gs := setupTestHandAndGameState(t,
-		"2m3m4m 2m3m4m 1p2p3p 7s8s9s", // Hand without pair, assuming EEz is pair
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"2m3m4m 2m3m4m 1p2p3p 7s8s9s E", // Hand with half of the EEz pair
 		[]Meld{},
 		"E", // Agari on East wind to complete pair
 		false,
 	)
-	player.Hand = append(player.Hand, TilesFromString("E E")...) // Add pair
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, false)
 
 	// Mocking decomposition for Iipeikou:
 	// Seq(2m3m4m), Seq(2m3m4m), Seq(1p2p3p), Seq(7s8s9s), Pair(EE)
@@ -1110,15 +1087,12 @@ func TestCheckIipeikou_Valid(t *testing.T) {
 
 func TestCheckRyanpeikou_Valid(t *testing.T) {
 	// Hand: 223344m 223344p 77s. Menzen.
-	player, agariHai, allTiles, gs := setupTestHandAndGameState(t,
-		"2m3m4m 2m3m4m 2p3p4p 2p3p4p", // Hand without pair
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"2m3m4m 2m3m4m 2p3p4p 2p3p4p 7s", // Hand with half of the pair
 		[]Meld{},
 		"7s", // Agari on 7s to complete pair
 		false,
 	)
-	player.Hand = append(player.Hand, TilesFromString("7s7s")...) // Add pair
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, false)
 
 	// Mocking decomposition for Ryanpeikou:
 	// Seq(2m3m4m), Seq(2m3m4m), Seq(2p3p4p), Seq(2p3p4p), Pair(7s7s)
@@ -1143,12 +1117,6 @@ func TestCheckSanankou_Tsumo(t *testing.T) {
 	// The pungs themselves must be concealed.
 	// DecomposeWinningHand should identify: Pung 1m (conc), Pung 2p (conc), Pung 3s (conc), Seq 456s (open), Pair EEz (conc)
 
-	// Forcing player.Hand to be the full 14 tiles for decomposition
-	player.Hand = TilesFromString("1m1m1m 2p2p2p 3s3s3s E") // AgariHai 'E' is already part of this for Tsumo
-	player.Hand = append(player.Hand, agariHai)             // Add the tsumo'd tile
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, true)
-
 	ok, han := checkSanankou(player, agariHai, true, allTiles)
 	if !ok || han != 2 {
 		t.Errorf("TestCheckSanankou_Tsumo: Expected Sanankou (2 Han), got ok:%v, han:%d. AllTiles: %s", ok, han, TilesToNames(allTiles))
@@ -1173,13 +1141,13 @@ func TestCheckSanankou_Ron_NotCompletingPung(t *testing.T) {
 func TestCheckSanankou_Invalid_RonCompletesOneOfThePungs(t *testing.T) {
 	// Two concealed pungs, Ron completes a third one (this third one is not counted as concealed for Sanankou).
 	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
-		"1m1m1m 2p2p2p 3s3s 4z4z WW", // 2 concealed pungs, 2 tiles of a 3rd pung, a pair, another pair/group
+		"1m1m1m 2p2p2p 3s3s 4z4z 7p8p9p", // 2 concealed pungs, 2 tiles of a 3rd pung, a pair, a sequence
 		[]Meld{},
 		"3s", // Ron on 3s, completes the 3s pung
 		false,
 	)
-	// Expected: Not Sanankou (would be Toitoi if other group is a pung, or just 2 concealed pungs)
-	ok, _, _ := checkSanankou(player, agariHai, false, allTiles)
+	// Expected: Not Sanankou (just 2 concealed pungs)
+	ok, _ := checkSanankou(player, agariHai, false, allTiles)
 	if ok {
 		t.Errorf("TestCheckSanankou_Invalid_RonCompletesOneOfThePungs: Expected NOT Sanankou, but got one. AllTiles: %s", TilesToNames(allTiles))
 	}
@@ -1188,15 +1156,12 @@ func TestCheckSanankou_Invalid_RonCompletesOneOfThePungs(t *testing.T) {
 // --- Chiitoitsu Tests ---
 func TestCheckChiitoitsu_Valid(t *testing.T) {
 	// Hand: 11m 22p 33s 44m 55p 66s EEz (7 pairs)
-	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
-		"1m1m 2p2p 3s3s 4m4m 5p5p 6s6s", // 6 pairs
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"1m1m 2p2p 3s3s 4m4m 5p5p 6s6s E", // 6 pairs and a single East
 		[]Meld{},
 		"E",   // Agari completes 7th pair (East wind)
 		false, // isTsumo = Ron
 	)
-	player.Hand = append(player.Hand, TilesFromString("E E")...) // Add the pair that will be completed by agariHai
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, false) // Reconstruct allTiles
 
 	ok, han := checkChiitoitsu(player, allTiles, true) // isMenzen = true
 	if !ok || han != 2 {
@@ -1205,15 +1170,12 @@ func TestCheckChiitoitsu_Valid(t *testing.T) {
 }
 
 func TestCheckChiitoitsu_Invalid_NotMenzen(t *testing.T) {
-	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
-		"1m1m 2p2p 3s3s 4m4m 5p5p 6s",
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"1m1m 2p2p 3s3s 4m4m 5p 6s",
 		[]Meld{{Type: "Pon", Tiles: TilesFromString("E E E"), IsConcealed: false}}, // Open meld
 		"6s", // Completes a pair
 		false,
 	)
-	player.Hand = append(player.Hand, TilesFromString("6s")...) // Add the other part of the pair
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, false)
 
 	ok, _ := checkChiitoitsu(player, allTiles, false) // isMenzen = false
 	if ok {
@@ -1223,8 +1185,8 @@ func TestCheckChiitoitsu_Invalid_NotMenzen(t *testing.T) {
 
 // --- Daisangen Tests ---
 func TestCheckDaisangen_Valid(t *testing.T) {
-	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
-		"1m1m", // Pair of 1m
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"1m", // Half of the 1m pair
 		[]Meld{
 			{Type: "Pon", Tiles: TilesFromString("w w w")},    // Pung White Dragon
 			{Type: "Pon", Tiles: TilesFromString("g g g")},    // Pung Green Dragon
@@ -1234,9 +1196,6 @@ func TestCheckDaisangen_Valid(t *testing.T) {
 		"1m", // Doesn't matter for this Yaku, but need a valid agari
 		false,
 	)
-	// Manually set player hand for this specific meld setup
-	player.Hand = TilesFromString("1m1m")
-	allTiles = getAllTilesInHand(player, agariHai, false)
 
 	ok, name, han := checkDaisangen(player, allTiles)
 	if !ok || name != "Daisangen" || han != 13 {
@@ -1246,19 +1205,17 @@ func TestCheckDaisangen_Valid(t *testing.T) {
 
 // --- Shousuushii / Daisuushii Tests ---
 func TestCheckShousuushii_Valid(t *testing.T) {
-	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
-		"1m1m", // Non-wind pair
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"1m1m1m N", // Non-wind pung and one North
 		[]Meld{
 			{Type: "Pon", Tiles: TilesFromString("E E E")}, // Pung East
 			{Type: "Pon", Tiles: TilesFromString("S S S")}, // Pung South
 			{Type: "Pon", Tiles: TilesFromString("W W W")}, // Pung West
 			// North wind is the pair in hand
 		},
-		"N", // Agari completes North pair (conceptually, hand has one N)
+		"N", // Agari completes North pair
 		false,
 	)
-	player.Hand = TilesFromString("1m1m N") // Player hand before ronning on N
-	allTiles = getAllTilesInHand(player, agariHai, false)
 
 	ok, name, han := checkShousuushii(player, allTiles)
 	if !ok || name != "Shousuushii" || han != 13 {
@@ -1267,7 +1224,7 @@ func TestCheckShousuushii_Valid(t *testing.T) {
 }
 
 func TestCheckDaisuushii_Valid(t *testing.T) {
-	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
 		"1m", // Part of pair
 		[]Meld{
 			{Type: "Pon", Tiles: TilesFromString("E E E")},
@@ -1278,8 +1235,6 @@ func TestCheckDaisuushii_Valid(t *testing.T) {
 		"1m", // Completes 1m pair
 		false,
 	)
-	player.Hand = TilesFromString("1m")
-	allTiles = getAllTilesInHand(player, agariHai, false)
 
 	ok, name, han := checkDaisuushii(player, allTiles)
 	if !ok || name != "Daisuushii" || han != 26 { // Now expects 26
@@ -1289,16 +1244,13 @@ func TestCheckDaisuushii_Valid(t *testing.T) {
 
 // --- Tsuuiisou Tests ---
 func TestCheckTsuuiisou_Valid_Standard(t *testing.T) {
-	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
-		"E S W N Wh Gr", // Hand part
+	// Hand setup for Tsuuiisou: Pung(EEE) + Pung(SSS) + Pung(WWW) + Pung(Rd, open) + Wh Wh (pair)
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"E E E S S S W W W Wh",                                 // Hand part
 		[]Meld{{Type: "Pon", Tiles: TilesFromString("r r r")}}, // Pung Red Dragon
-		"Rd", // Agari completes pair of Red Dragon
+		"Wh", // Agari completes pair of White Dragon
 		false,
 	)
-	// Hand setup for Tsuuiisou: E S W N Wh Gr Rd + Rd (pair) + Pung(EEE) + Pung(SSS) + Pung(WWW)
-	// This is complex to set up with current helper. Forcing allTiles:
-	allTiles = TilesFromString("E E E S S S W W W N N r r Wh Wh") // Example Tsuuiisou
-	agariHai = TilesFromString("Wh")[0]                           // Assume last Wh completes the pair
 
 	ok, name, han := checkTsuuiisou(player, allTiles)
 	if !ok || name != "Tsuuiisou" || han != 13 {
@@ -1308,7 +1260,7 @@ func TestCheckTsuuiisou_Valid_Standard(t *testing.T) {
 
 func TestCheckTsuuiisou_Valid_Chiitoitsu(t *testing.T) {
 	allTiles := TilesFromString("E E S S W W N N Wh Wh Gr Gr r r") // 7 pairs of honors
-	agariHai := TilesFromString("r")[0]                            // Completes Red Dragon pair
+	_ = TilesFromString("r")[0]                                    // Completes Red Dragon pair
 	player := createTestPlayer()                                   // Player needed for DecomposeWinningHand context
 	player.Hand = allTiles                                         // For IsChiitoitsu check
 
@@ -1321,7 +1273,7 @@ func TestCheckTsuuiisou_Valid_Chiitoitsu(t *testing.T) {
 // --- Chinroutou Tests ---
 func TestCheckChinroutou_Valid_Standard(t *testing.T) {
 	allTiles := TilesFromString("1m1m1m 9m9m9m 1p1p1p 9p9p 1s1s1s") // 4 pungs of terminals, 1 pair of terminals
-	agariHai := TilesFromString("9p")[0]                            // Completes 9p pair
+	_ = TilesFromString("9p")[0]                                    // Completes 9p pair
 	player := createTestPlayer()
 	player.Hand = allTiles
 
@@ -1333,7 +1285,7 @@ func TestCheckChinroutou_Valid_Standard(t *testing.T) {
 
 // --- Suukantsu Test ---
 func TestCheckSuukantsu_Valid(t *testing.T) {
-	player, _, _, _ := setupTestHandAndGameState(t, "1m1m", // Pair
+	player, _, _, _ := setupTestHandAndGameState(t, "1m", // Half of the pair
 		[]Meld{
 			{Type: "Ankan", Tiles: TilesFromString("2m2m2m2m")},
 			{Type: "Daiminkan", Tiles: TilesFromString("3p3p3p3p")},
@@ -1426,7 +1378,7 @@ func TestCheckSankantsu_Valid(t *testing.T) {
 
 // --- Haitei/Houtei Tests ---
 func TestCheckHaiteiHoutei_Haitei(t *testing.T) {
-	player, _, _, gs := setupTestHandAndGameState(t, "1m2m3m 4p5p6p 7s8s9s 1z1z", []Meld{}, "2m", true) // Hand doesn't matter
+	_, _, _, gs := setupTestHandAndGameState(t, "1m3m 4p5p6p 7s8s9s 2s3s4s 1z1z", []Meld{}, "2m", true) // Hand doesn't matter
 	gs.Wall = []Tile{}                                                                                  // Wall is empty after this Tsumo
 	ok, name, han := checkHaiteiHoutei(gs, true)                                                        // isTsumo = true
 	if !ok || name != "Haitei Raoyue" || han != 1 {
@@ -1435,9 +1387,9 @@ func TestCheckHaiteiHoutei_Haitei(t *testing.T) {
 }
 
 func TestCheckHaiteiHoutei_Houtei(t *testing.T) {
-	_, _, _, gs := setupTestHandAndGameState(t, "", []Meld{}, "1m", false) // Player/hand don't matter
-	gs.IsHouteiDiscard = true                                              // This discard is Houtei
-	ok, name, han := checkHaiteiHoutei(gs, false)                          // isTsumo = false
+	gs := createTestGameState(nil)                // Player/hand don't matter
+	gs.IsHouteiDiscard = true                     // This discard is Houtei
+	ok, name, han := checkHaiteiHoutei(gs, false) // isTsumo = false
 	if !ok || name != "Houtei Raoyui" || han != 1 {
 		t.Errorf("TestCheckHaiteiHoutei_Houtei: Expected Houtei Raoyui (1 Han), got name:'%s' han:%d ok:%v", name, han, ok)
 	}
@@ -1445,7 +1397,7 @@ func TestCheckHaiteiHoutei_Houtei(t *testing.T) {
 
 // --- Rinshan Kaihou Test ---
 func TestCheckRinshanKaihou_Valid(t *testing.T) {
-	_, _, _, gs := setupTestHandAndGameState(t, "", []Meld{}, "1m", true)
+	gs := createTestGameState(nil)
 	gs.IsRinshanWin = true                  // Win was on Rinshan tile
 	ok, han := checkRinshanKaihou(gs, true) // isTsumo = true
 	if !ok || han != 1 {
@@ -1455,8 +1407,8 @@ func TestCheckRinshanKaihou_Valid(t *testing.T) {
 
 // --- Chankan Test ---
 func TestCheckChankan_Valid(t *testing.T) {
-	_, _, _, gs := setupTestHandAndGameState(t, "", []Meld{}, "1m", false) // Ron
-	gs.IsChankanOpportunity = true                                         // Win was Chankan
+	gs := createTestGameState(nil) // Ron
+	gs.IsChankanOpportunity = true // Win was Chankan
 	ok, han := checkChankan(gs)
 	if !ok || han != 1 {
 		t.Errorf("TestCheckChankan_Valid: Expected Chankan (1 Han), got ok:%v, han:%d", ok, han)
@@ -1465,8 +1417,8 @@ func TestCheckChankan_Valid(t *testing.T) {
 
 // --- Sanshoku Doukou (Triple Pungs) ---
 func TestCheckSanshokuDoukou_Valid(t *testing.T) {
-	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
-		"1m1m", // Pair
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"1m", // Half of the pair
 		[]Meld{
 			{Type: "Pon", Tiles: TilesFromString("2m2m2m")},
 			{Type: "Pon", Tiles: TilesFromString("2p2p2p")},
@@ -1474,8 +1426,6 @@ func TestCheckSanshokuDoukou_Valid(t *testing.T) {
 			{Type: "Pon", Tiles: TilesFromString("E E E")}, // Another group to complete hand
 		},
 		"1m", false)
-	player.Hand = TilesFromString("1m1m") // Ensure player hand is just the pair
-	allTiles = getAllTilesInHand(player, agariHai, false)
 
 	ok, name, han := checkSanshokuDoukou(player, allTiles)
 	if !ok || name != "Sanshoku Doukou" || han != 2 {
@@ -1485,8 +1435,8 @@ func TestCheckSanshokuDoukou_Valid(t *testing.T) {
 
 // --- Toitoi (All Pungs) ---
 func TestCheckToitoi_Valid(t *testing.T) {
-	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
-		"1m1m", // Pair
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"1m", // Half of the pair
 		[]Meld{
 			{Type: "Pon", Tiles: TilesFromString("2m2m2m")},
 			{Type: "Pon", Tiles: TilesFromString("3p3p3p")},
@@ -1494,8 +1444,6 @@ func TestCheckToitoi_Valid(t *testing.T) {
 			{Type: "Ankan", Tiles: TilesFromString("E E E E")},
 		},
 		"1m", false)
-	player.Hand = TilesFromString("1m1m")
-	allTiles = getAllTilesInHand(player, agariHai, false)
 
 	ok, han := checkToitoi(player, allTiles)
 	if !ok || han != 2 {
@@ -1514,15 +1462,11 @@ func TestCheckHonroutou_Valid(t *testing.T) {
 
 // --- Sanshoku Doujun (Mixed Triple Sequence) ---
 func TestCheckSanshokuDoujun_Valid_Concealed(t *testing.T) {
-	player, agariHai, allTiles, gs := setupTestHandAndGameState(t,
-		"2m3m4m 2p3p4p 2s3s4s 1z1z", // 3 seq + pair
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"2m3m4m 2p3p4p 2s3s4s 7p8p9p E", // 4 seq + half of the pair
 		[]Meld{},
-		"E", // Dummy agari, doesn't matter for this structure if hand is full
+		"E", // Tsumo completes the East pair
 		true)
-	player.Hand = TilesFromString("2m3m4m2p3p4p2s3s4s1z1zE")   // Full hand for decomposition
-	player.Hand = append(player.Hand, TilesFromString("E")[0]) // Add the pair part
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, true)
 
 	ok, name, han := checkSanshokuDoujun(player, true, allTiles) // isMenzen = true
 	if !ok || name != "Sanshoku Doujun" || han != 2 {
@@ -1532,15 +1476,11 @@ func TestCheckSanshokuDoujun_Valid_Concealed(t *testing.T) {
 
 // --- Ittsuu (Pure Straight) ---
 func TestCheckIttsuu_Valid_Concealed_Manzu(t *testing.T) {
-	player, agariHai, allTiles, gs := setupTestHandAndGameState(t,
-		"1m2m3m 4m5m6m 7m8m9m 1p1p", // Ittsuu in Manzu + pair
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"1m2m3m 4m5m6m 7m8m9m 1p1p E E", // Ittsuu in Manzu + pair + two East
 		[]Meld{},
-		"E", // Dummy agari
+		"E", // Tsumo completes the East pung
 		true)
-	player.Hand = TilesFromString("1m2m3m4m5m6m7m8m9m1p1pE")
-	player.Hand = append(player.Hand, TilesFromString("E")[0])
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, true)
 
 	ok, name, han := checkIttsuu(player, true, allTiles) // isMenzen = true
 	if !ok || name != "Ittsuu" || han != 2 {
@@ -1550,15 +1490,12 @@ func TestCheckIttsuu_Valid_Concealed_Manzu(t *testing.T) {
 
 // --- Honitsu (Half Flush) ---
 func TestCheckHonitsu_Valid_Concealed(t *testing.T) {
-	// Hand: 123m 456m EEEz SSp (SSp is pair of South Wind). Menzen.
-	player, agariHai, allTiles, gs := setupTestHandAndGameState(t,
-		"1m2m3m 4m5m6m EEE S", // Hand part
+	// Hand: 123m 456m 789m EEEz SSz (SSz is pair of South Wind). Menzen.
+	_, _, allTiles, _ := setupTestHandAndGameState(t,
+		"1m2m3m 4m5m6m 7m8m9m EEE S", // Hand part
 		[]Meld{},
 		"S", // Agari completes SS pair
 		true)
-	player.Hand = TilesFromString("1m2m3m4m5m6mEEESS")
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, true)
 
 	ok, han := checkHonitsu(allTiles, true) // isMenzen = true
 	if !ok || han != 3 {
@@ -1568,15 +1505,12 @@ func TestCheckHonitsu_Valid_Concealed(t *testing.T) {
 
 // --- Chinitsu (Full Flush) ---
 func TestCheckChinitsu_Valid_Concealed(t *testing.T) {
-	// Hand: 123456789m 22m 33m. Menzen.
-	player, agariHai, allTiles, gs := setupTestHandAndGameState(t,
-		"1m2m3m4m5m6m7m8m9m2m2m3m", // Hand part
+	// Hand: 123456789m 22m 333m. Menzen.
+	_, _, allTiles, _ := setupTestHandAndGameState(t,
+		"1m2m3m4m5m6m7m8m9m2m2m3m3m", // Hand part
 		[]Meld{},
-		"3m", // Agari completes 3m pair
+		"3m", // Agari completes 3m pung
 		true)
-	player.Hand = TilesFromString("1m2m3m4m5m6m7m8m9m2m2m3m3m")
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, true)
 
 	ok, han := checkChinitsu(allTiles, true) // isMenzen = true
 	if !ok || han != 6 {
@@ -1588,16 +1522,11 @@ func TestCheckChinitsu_Valid_Concealed(t *testing.T) {
 func TestCheckJunchan_Valid_Concealed(t *testing.T) {
 	// Hand: 123m 789p 11s 999s EEE (This example has honors, Junchan cannot have honors)
 	// Corrected Junchan: 123m 789p 11s 999s + 123s (all groups have terminals, no honors)
-	player, agariHai, allTiles, gs := setupTestHandAndGameState(t,
-		"1m2m3m 7p8p9p 1s1s 9s9s9s", // Hand part
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"1m2m3m 7p8p9p 1s 9s9s9s 1s2s3s", // Hand part
 		[]Meld{},
-		"1s", // Agari completes 1s sequence start (e.g. waiting on 1s or 4s for 123s or 234s)
-		// Let's make it simple: agari on the pair 1s
+		"1s", // Let's make it simple: agari on the pair 1s
 		true)
-	player.Hand = TilesFromString("1m2m3m7p8p9p1s9s9s9s1s2s3s") // Ensure 14 tiles
-	player.Hand = append(player.Hand, TilesFromString("1s")[0])
-	sort.Sort(BySuitValue(player.Hand))
-	allTiles = getAllTilesInHand(player, agariHai, true)
 
 	ok, han := checkJunchan(player, true, allTiles) // isMenzen = true
 	if !ok || han != 3 {
@@ -1608,7 +1537,7 @@ func TestCheckJunchan_Valid_Concealed(t *testing.T) {
 // --- Luck Yakuman Tests (Tenhou, Chihou, Renhou) ---
 
 func TestCheckTenhou_Valid(t *testing.T) {
-	player, agariHai, _, gs := setupTestHandAndGameState(t,
+	player, _, _, gs := setupTestHandAndGameState(t,
 		"1m2m3m4p5p6p7s8s9s1z1z2z2z", // A complete hand (example)
 		[]Meld{},
 		"2z", // Tsumo on the last tile of the pair
@@ -1659,7 +1588,7 @@ func TestCheckChihou_Valid(t *testing.T) {
 
 func TestCheckRenhou_Valid(t *testing.T) {
 	player, agariHai, _, gs := setupTestHandAndGameState(t,
-		"1m2m3m4p5p6p7s8s9s1z1z2z", // 13 tiles for Ron
+		"1m2m3m4p5p6p7s8s9s1z1z2z2z", // 13 tiles for Ron
 		[]Meld{},
 		"2z",  // Ron on this tile
 		false, // isTsumo = false
@@ -1684,15 +1613,13 @@ func TestCheckRenhou_Valid(t *testing.T) {
 
 // --- Ryuuiisou Test ---
 func TestCheckRyuuiisou_Valid_Standard(t *testing.T) {
-	// Hand: 2s3s4s 2s3s4s 6s6s6s 8s8s GrGr (Gr = Green Dragon)
-	player, agariHai, allTiles, _ := setupTestHandAndGameState(t,
-		"2s3s4s2s3s4s6s6s6s8s8s", // Hand part
+	// Hand: 2s3s4s 2s3s4s 6s6s6s 8s8s8s GrGr (Gr = Green Dragon)
+	player, _, allTiles, _ := setupTestHandAndGameState(t,
+		"2s3s4s2s3s4s6s6s6s8s8s8sg", // Hand part
 		[]Meld{},
 		"g", // Agari on Green Dragon to complete pair
 		false,
 	)
-	player.Hand = TilesFromString("2s3s4s2s3s4s6s6s6s8s8sg") // Add one green dragon
-	allTiles = getAllTilesInHand(player, agariHai, false)
 
 	ok, name, han := checkRyuuiisou(player, allTiles)
 	if !ok || name != "Ryuuiisou" || han != 13 {
@@ -1700,22 +1627,22 @@ func TestCheckRyuuiisou_Valid_Standard(t *testing.T) {
 	}
 }
 
-func TestCheckRyuuiisou_Valid_Chiitoitsu(t *testing.T) {
-	// Hand: 22s 33s 44s 66s 88s GrGr GrGr (Gr = Green Dragon) - 7 pairs of green tiles
-	allTiles := TilesFromString("2s2s 3s3s 4s4s 6s6s 8s8s g g g g") // 4 green dragons to make two pairs
-	agariHai := TilesFromString("g")[0]                             // Last Green Dragon
+func TestCheckRyuuiisou_Invalid_FourGreenAsTwoPairs(t *testing.T) {
+	// Hand: 22s 33s 44s 66s 88s GrGr GrGr (Gr = Green Dragon). Only six green tile kinds exist, so an
+	// all-green Chiitoitsu would need four Green Dragons as two pairs, which Chiitoitsu does not allow.
+	allTiles := TilesFromString("2s2s 3s3s 4s4s 6s6s 8s8s g g g g")
 	player := createTestPlayer()
 	player.Hand = allTiles // For IsChiitoitsu check
 
-	ok, name, han := checkRyuuiisou(player, allTiles)
-	if !ok || name != "Ryuuiisou" || han != 13 {
-		t.Errorf("TestCheckRyuuiisou_Valid_Chiitoitsu: Expected Ryuuiisou (13 Han), got name:'%s' han:%d ok:%v. AllTiles: %s", name, han, ok, TilesToNames(allTiles))
+	ok, name, _ := checkRyuuiisou(player, allTiles)
+	if ok {
+		t.Errorf("TestCheckRyuuiisou_Invalid_FourGreenAsTwoPairs: Expected NOT Ryuuiisou, got name:'%s'. AllTiles: %s", name, TilesToNames(allTiles))
 	}
 }
 
 func TestCheckRyuuiisou_Invalid_ContainsNonGreen(t *testing.T) {
 	allTiles := TilesFromString("2s3s4s 5s6s7s 2s2s g g 1m1m1m") // Contains 5s, 7s (non-green sou) and 1m
-	agariHai := TilesFromString("1m")[0]
+	_ = TilesFromString("1m")[0]
 	player := createTestPlayer()
 	player.Hand = allTiles
 
@@ -1729,7 +1656,7 @@ func TestCheckRyuuiisou_Invalid_ContainsNonGreen(t *testing.T) {
 func TestCheckNagashiMangan_Valid(t *testing.T) {
 	player := createTestPlayer()
 	gs := createTestGameState(nil)
-	player.Discards = TilesFromString("1m 9p 1s E S W N w g r") // All terminals/honors
+	player.Discards = riverFromString("1m 9p 1s E S W N w g r") // All terminals/honors
 	player.HasHadDiscardCalledThisRound = false
 
 	ok, name, han := checkNagashiMangan(player, gs)
@@ -1741,7 +1668,7 @@ func TestCheckNagashiMangan_Valid(t *testing.T) {
 func TestCheckNagashiMangan_Invalid_DiscardCalled(t *testing.T) {
 	player := createTestPlayer()
 	gs := createTestGameState(nil)
-	player.Discards = TilesFromString("1m 9p E S w")
+	player.Discards = riverFromString("1m 9p E S w")
 	player.HasHadDiscardCalledThisRound = true // Discard was called
 
 	ok, _, _ := checkNagashiMangan(player, gs)
@@ -1753,7 +1680,7 @@ func TestCheckNagashiMangan_Invalid_DiscardCalled(t *testing.T) {
 func TestCheckNagashiMangan_Invalid_ContainsSimple(t *testing.T) {
 	player := createTestPlayer()
 	gs := createTestGameState(nil)
-	player.Discards = TilesFromString("1m 9p E S 2m") // Contains a simple (2m)
+	player.Discards = riverFromString("1m 9p E S 2m") // Contains a simple (2m)
 	player.HasHadDiscardCalledThisRound = false
 
 	ok, _, _ := checkNagashiMangan(player, gs)
@@ -1824,7 +1751,7 @@ func TestIdentifyYaku_Chihou(t *testing.T) {
 
 func TestIdentifyYaku_Renhou(t *testing.T) {
 	player, agariHai, _, gs := setupTestHandAndGameState(t,
-		"1m2m3m4p5p6p7s8s9s1z1z2z", // 13 tiles for Ron
+		"1m2m3m4p5p6p7s8s9s1z1z2z2z", // 13 tiles for Ron
 		[]Meld{},
 		"2z", // Ron on this tile
 		false,
@@ -1837,7 +1764,6 @@ func TestIdentifyYaku_Renhou(t *testing.T) {
 	gs.IsFirstGoAround = true
 	gs.AnyCallMadeThisRound = false
 	player.Melds = []Meld{}
-	player.Hand = TilesFromString("1m2m3m4p5p6p7s8s9s1z1z2z") // Ensure hand is 13 tiles before Ron for IdentifyYaku
 
 	results, totalHan := IdentifyYaku(player, agariHai, false, gs) // isTsumo = false
 	foundRenhou := false
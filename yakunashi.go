@@ -0,0 +1,194 @@
+package main
+
+import "fmt"
+
+// Yakunashi: an open hand can only win with a yaku that does not need a closed hand (no Riichi,
+// Menzen Tsumo or Pinfu). Before a Pon, Chi or open Kan the engine checks whether one is still
+// within reach, so that the human is warned in the call prompt and the AI seats skip the call.
+
+// NoYakuCallNote is added to a call prompt when the call would leave no possible yaku.
+const NoYakuCallNote = "no possible yaku after this call"
+
+// OpenYakuCandidates lists the yaku an open hand with the concealed tiles hand and melds can
+// still reach: yakuhai (a called triplet or a pair in hand), Tanyao, Toitoi, Honitsu, Chanta,
+// Junchan, Ittsu, Sanshoku, Sanankou, Sanshoku Doukou and Sankantsu. Only the melds and the
+// yakuhai pairs are looked at, so a yaku is listed whenever the melds allow it, however far away
+// it is.
+func OpenYakuCandidates(gs *GameState, player *Player, hand []Tile, melds []Meld) []string {
+	candidates := []string{}
+	isYakuhai := func(t Tile) bool {
+		return t.Suit == "Dragon" || (t.Suit == "Wind" &&
+			(t.Value == WindValueFromName(player.SeatWind) || t.Value == WindValueFromName(gs.PrevalentWind)))
+	}
+
+	yakuhai := false
+	for kind, n := range tileKindCounts(hand) {
+		yakuhai = yakuhai || (n >= 2 && isYakuhai(kindTile(kind)))
+	}
+	simples, triplets, chanta, honors := true, true, true, false
+	openMelds, chis := 0, 0
+	suits := make(map[string]bool)
+	for _, m := range melds {
+		yakuhai = yakuhai || (m.Type != "Chi" && isYakuhai(m.Tiles[0]))
+		triplets = triplets && m.Type != "Chi"
+		hasTerminal := false
+		for _, t := range m.Tiles {
+			simples = simples && !IsTerminalOrHonor(t)
+			hasTerminal = hasTerminal || IsTerminalOrHonor(t)
+			honors = honors || IsHonor(t)
+			if !IsHonor(t) {
+				suits[t.Suit] = true
+			}
+		}
+		chanta = chanta && hasTerminal
+		openMelds += IfElseInt(m.IsConcealed, 0, 1)
+		chis += IfElseInt(m.Type == "Chi", 1, 0)
+	}
+
+	if yakuhai {
+		candidates = append(candidates, "Yakuhai")
+	}
+	if simples {
+		candidates = append(candidates, "Tanyao")
+	}
+	if triplets {
+		candidates = append(candidates, "Toitoi")
+	}
+	if len(suits) <= 1 {
+		candidates = append(candidates, "Honitsu")
+	}
+	if chanta {
+		candidates = append(candidates, "Chanta")
+	}
+	if chanta && !honors {
+		candidates = append(candidates, "Junchan")
+	}
+	if openSequenceYakuPossible(melds, true) {
+		candidates = append(candidates, "Ittsu")
+	}
+	if openSequenceYakuPossible(melds, false) {
+		candidates = append(candidates, "Sanshoku")
+	}
+	if openMelds <= 1 { // Three concealed triplets beside the one called set
+		candidates = append(candidates, "Sanankou")
+	}
+	if openTripletSanshokuPossible(melds) {
+		candidates = append(candidates, "Sanshoku Doukou")
+	}
+	if chis <= 1 { // Any Pon can still become a Kan
+		candidates = append(candidates, "Sankantsu")
+	}
+	return candidates
+}
+
+// openTripletSanshokuPossible reports whether the melds fit Sanshoku Doukou, a triplet of the
+// same value in each suit, with at most one meld (the fourth set) outside it.
+func openTripletSanshokuPossible(melds []Meld) bool {
+	for value := 1; value <= 9; value++ {
+		used := make(map[string]bool)
+		outside := 0
+		for _, m := range melds {
+			t := m.Tiles[0]
+			if m.Type != "Chi" && !IsHonor(t) && t.Value == value && !used[t.Suit] {
+				used[t.Suit] = true
+			} else {
+				outside++
+			}
+		}
+		if outside <= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// openSequenceYakuPossible reports whether the melds fit three sequences of one yaku, with at
+// most one meld (the fourth set) outside it: 123, 456 and 789 of one suit for Ittsu, or one
+// sequence starting on the same value in each suit for Sanshoku.
+func openSequenceYakuPossible(melds []Meld, ittsu bool) bool {
+	fits := func(want func(suit string, low int) bool) bool {
+		used := make(map[string]bool)
+		outside := 0
+		for _, m := range melds {
+			low := m.Tiles[0].Value
+			for _, t := range m.Tiles {
+				low = min(low, t.Value)
+			}
+			key := fmt.Sprintf("%s%d", m.Tiles[0].Suit, low)
+			if m.Type == "Chi" && !used[key] && want(m.Tiles[0].Suit, low) {
+				used[key] = true
+			} else {
+				outside++
+			}
+		}
+		return outside <= 1
+	}
+	for _, suit := range []string{"Man", "Pin", "Sou"} {
+		if ittsu && fits(func(s string, low int) bool { return s == suit && low%3 == 1 }) {
+			return true
+		}
+	}
+	for start := 1; start <= 7 && !ittsu; start++ {
+		if fits(func(s string, low int) bool { return low == start }) {
+			return true
+		}
+	}
+	return false
+}
+
+// CallLeavesNoYaku reports whether calling callType (ActionPon, ActionKan for an open Kan, or
+// ActionChi) on discardedTile would leave player's hand without a possible yaku. For Chi,
+// sequence is the chosen 3-tile sequence; with none, every sequence the player could use must
+// leave no yaku.
+func CallLeavesNoYaku(gs *GameState, player *Player, callType string, discardedTile Tile, sequence []Tile) bool {
+	if callType == ActionChi && sequence == nil {
+		options := FindPossibleChiSequences(player, discardedTile, gs)
+		for _, pair := range options {
+			if !CallLeavesNoYaku(gs, player, callType, discardedTile, append([]Tile{discardedTile}, pair...)) {
+				return false
+			}
+		}
+		return len(options) > 0
+	}
+
+	hand := append([]Tile{}, player.Hand...)
+	meld := Meld{Type: callType, CalledOn: discardedTile, Tiles: []Tile{discardedTile}}
+	if callType == ActionChi {
+		for _, t := range sequence {
+			if t.ID != discardedTile.ID {
+				hand = removeTileByID(hand, t.ID)
+				meld.Tiles = append(meld.Tiles, t)
+			}
+		}
+	} else {
+		taken := IfElseInt(callType == ActionKan, 3, 2)
+		for _, t := range player.Hand {
+			if len(meld.Tiles) <= taken && tileKind(t) == tileKind(discardedTile) {
+				hand = removeTileByID(hand, t.ID)
+				meld.Tiles = append(meld.Tiles, t)
+			}
+		}
+	}
+	melds := append(append([]Meld{}, player.Melds...), meld)
+	return len(OpenYakuCandidates(gs, player, hand, melds)) == 0
+}
+
+// warnNoYakuCall logs that player's call would leave no possible yaku and returns the prompt
+// note for it, or "" when the call keeps a yaku within reach.
+func warnNoYakuCall(gs *GameState, player *Player, callType string, discardedTile Tile) string {
+	if !CallLeavesNoYaku(gs, player, callType, discardedTile, nil) {
+		return ""
+	}
+	gs.AddToGameLog(fmt.Sprintf("Warning: %s on %s would leave %s with no possible yaku.", callType, discardedTile.Name, player.Name))
+	// fmt.Printf("Warning: %s on %s would leave no possible yaku.\n", callType, discardedTile.Name)
+	return fmt.Sprintf(" (%s)", NoYakuCallNote)
+}
+
+// chiPrompt is the GUI and web question for a Chi, with NoYakuCallNote when no sequence keeps a
+// yaku within reach.
+func chiPrompt(gs *GameState, player *Player, discardedTile Tile) string {
+	if CallLeavesNoYaku(gs, player, ActionChi, discardedTile, nil) {
+		return fmt.Sprintf("Call Chi on %s (%s)?", discardedTile.Name, NoYakuCallNote)
+	}
+	return fmt.Sprintf("Call Chi on %s?", discardedTile.Name)
+}
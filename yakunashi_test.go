@@ -0,0 +1,143 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// openMeld builds a called meld from MPSZ tiles, with IDs apart from the hand's.
+func openMeld(t *testing.T, meldType, tiles string, firstID int) Meld {
+	t.Helper()
+	meldTiles := mustParseMPSZ(t, tiles)
+	for i := range meldTiles {
+		meldTiles[i].ID = firstID + i
+	}
+	return Meld{Type: meldType, Tiles: meldTiles, CalledOn: meldTiles[0], FromPlayer: 0}
+}
+
+func TestOpenYakuCandidates(t *testing.T) {
+	gs := createTestGameState(nil)
+	player := gs.Players[1] // South seat, East round
+	ankan := openMeld(t, "Ankan", "4444z", 103)
+	ankan.IsConcealed = true
+	tests := []struct {
+		hand  string
+		melds []Meld
+		want  []string
+	}{
+		{"2345678p", []Meld{openMeld(t, "Chi", "234m", 100), openMeld(t, "Pon", "555s", 103)}, []string{"Tanyao", "Sanshoku", "Sanshoku Doukou", "Sankantsu"}},
+		{"11z3p", []Meld{openMeld(t, "Chi", "234m", 100), openMeld(t, "Chi", "567p", 103), openMeld(t, "Pon", "999s", 106)}, []string{"Yakuhai"}},
+		{"22z3p", []Meld{openMeld(t, "Chi", "234m", 100), openMeld(t, "Chi", "567p", 103), openMeld(t, "Pon", "999s", 106)}, []string{"Yakuhai"}},
+		{"33z3p", []Meld{openMeld(t, "Chi", "234m", 100), openMeld(t, "Chi", "567p", 103), openMeld(t, "Pon", "999s", 106)}, []string{}},
+		{"2345678p", []Meld{openMeld(t, "Chi", "123m", 100), openMeld(t, "Chi", "789m", 103)}, []string{"Honitsu", "Chanta", "Junchan", "Ittsu", "Sanshoku"}},
+		{"2345678p", []Meld{openMeld(t, "Chi", "345m", 100), openMeld(t, "Chi", "345s", 103)}, []string{"Tanyao", "Sanshoku"}},
+		{"1155m334p", []Meld{openMeld(t, "Chi", "234m", 100), openMeld(t, "Pon", "444z", 103)}, []string{"Honitsu", "Sanshoku", "Sankantsu"}},
+		{"1155m334p", []Meld{openMeld(t, "Chi", "234m", 100), ankan}, []string{"Honitsu", "Sanshoku", "Sanankou", "Sankantsu"}},
+		{"1155m334p", []Meld{openMeld(t, "Pon", "111m", 100), openMeld(t, "Pon", "111p", 103), openMeld(t, "Chi", "567s", 106)}, []string{"Sanshoku Doukou", "Sankantsu"}},
+	}
+	for _, tt := range tests {
+		got := OpenYakuCandidates(gs, player, mustParseMPSZ(t, tt.hand), tt.melds)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("TestOpenYakuCandidates: Expected %v for %s with %d melds, got %v", tt.want, tt.hand, len(tt.melds), got)
+		}
+	}
+}
+
+// noYakuCallState gives the caller seat a hand that a Pon on 9s would leave without a yaku, and
+// P2 a lone 9s to discard.
+func noYakuCallState(t *testing.T, caller int) (*GameState, *Player) {
+	t.Helper()
+	gs := createTestGameState(nil)
+	for _, p := range gs.Players {
+		p.Hand = []Tile{}
+	}
+	player := gs.Players[caller]
+	player.Hand = mustParseMPSZ(t, "99s13579m")
+	player.Melds = []Meld{openMeld(t, "Chi", "234m", 100), openMeld(t, "Chi", "567p", 103)}
+	discarder := gs.Players[1]
+	discarder.Hand = mustParseMPSZ(t, "9s")
+	discarder.Hand[0].ID = 120
+	gs.CurrentPlayerIndex = 1
+	return gs, player
+}
+
+func TestCallLeavesNoYaku(t *testing.T) {
+	gs, player := noYakuCallState(t, 2)
+	nineSou := mustParseMPSZ(t, "9s")[0]
+	nineSou.ID = 120
+	if !CallLeavesNoYaku(gs, player, ActionPon, nineSou, nil) {
+		t.Errorf("TestCallLeavesNoYaku: Expected a Pon on 9s to leave no yaku")
+	}
+	player.Hand = mustParseMPSZ(t, "55s13579m")
+	fiveSou := mustParseMPSZ(t, "5s")[0]
+	fiveSou.ID = 120
+	if CallLeavesNoYaku(gs, player, ActionPon, fiveSou, nil) {
+		t.Errorf("TestCallLeavesNoYaku: Expected a Pon on 5s to keep Tanyao possible")
+	}
+}
+
+func TestDiscardTile_AISkipsCallWithoutYaku(t *testing.T) {
+	gs, caller := noYakuCallState(t, 2)
+	DiscardTile(gs, gs.Players[1], 0)
+	if len(caller.Melds) != 2 {
+		t.Errorf("TestDiscardTile_AISkipsCallWithoutYaku: Expected the AI to skip the Pon, got %d melds", len(caller.Melds))
+	}
+	warned := false
+	for _, entry := range gs.GameLog {
+		warned = warned || strings.Contains(entry, "Warning: Pon on")
+	}
+	if !warned {
+		t.Errorf("TestDiscardTile_AISkipsCallWithoutYaku: Expected a no yaku warning in the log, got %v", gs.GameLog)
+	}
+}
+
+// promptInput declines every confirmation, keeping the prompts.
+type promptInput struct {
+	AutoInput
+	prompts []string
+}
+
+func (in *promptInput) Confirm(gs *GameState, player *Player, action string, tile Tile, prompt string) bool {
+	in.prompts = append(in.prompts, prompt)
+	return false
+}
+
+func TestDiscardTile_HumanCallPromptWarnsWithoutYaku(t *testing.T) {
+	gs, _ := noYakuCallState(t, 0)
+	input := &promptInput{}
+	gs.Input = input
+	DiscardTile(gs, gs.Players[1], 0)
+	if len(input.prompts) != 1 || !strings.Contains(input.prompts[0], NoYakuCallNote) {
+		t.Errorf("TestDiscardTile_HumanCallPromptWarnsWithoutYaku: Expected a Pon prompt with %q, got %v", NoYakuCallNote, input.prompts)
+	}
+}
+
+func TestCanDeclareRon_NoYakuAndRyanhanShibari(t *testing.T) {
+	gs := createTestGameState(nil)
+	gs.DoraIndicators = mustParseMPSZ(t, "1z")
+	gs.DoraIndicators[0].ID = 200
+	player := gs.Players[1]
+	threeSou := mustParseMPSZ(t, "3s")[0]
+	threeSou.ID = 130
+
+	player.Hand = mustParseMPSZ(t, "456p789s11z45s")
+	player.Melds = []Meld{openMeld(t, "Pon", "999m", 100)}
+	logLength := len(gs.GameLog)
+	if CanDeclareRon(player, threeSou, gs) {
+		t.Errorf("TestCanDeclareRon_NoYakuAndRyanhanShibari: Expected no Ron for an open hand without yaku")
+	}
+	if len(gs.GameLog) != logLength {
+		t.Errorf("TestCanDeclareRon_NoYakuAndRyanhanShibari: Expected the precheck not to log, got %v", gs.GameLog[logLength:])
+	}
+
+	player.Hand = mustParseMPSZ(t, "45677p45s")
+	player.Melds = []Meld{openMeld(t, "Chi", "234m", 100), openMeld(t, "Chi", "678s", 103)}
+	if !CanDeclareRon(player, threeSou, gs) {
+		t.Errorf("TestCanDeclareRon_NoYakuAndRyanhanShibari: Expected a Ron with open Tanyao")
+	}
+	gs.Honba = RyanhanShibariHonbaThreshold
+	if CanDeclareRon(player, threeSou, gs) {
+		t.Errorf("TestCanDeclareRon_NoYakuAndRyanhanShibari: Expected no one-han Ron at %d Honba", gs.Honba)
+	}
+}